@@ -0,0 +1,146 @@
+// Command backup exports the database of the service to a portable JSON lines file and restores it.
+// It connects to DATABASE_URL directly, like the service itself.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/backup"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+const usage = `Usage:
+  backup export [-anonymize] [-o FILE]   write all data to FILE or stdout
+  backup verify FILE|-                   check integrity and consistency of a backup
+  backup import FILE|-                   restore a backup into an empty database
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = runExport(ctx, os.Args[2:])
+	case "verify":
+		err = runVerify(os.Args[2:])
+	case "import":
+		err = runImport(ctx, os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backup: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	anonymize := fs.Bool("anonymize", false, "replace usernames and emails with ones derived from user ids")
+	output := fs.String("o", "", "output file (default: stdout)")
+	fs.Parse(args)
+
+	store, err := db.NewDB()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", *output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := backup.Export(ctx, store, w, backup.ExportOptions{Anonymize: *anonymize}); err != nil {
+		if *output != "" {
+			os.Remove(*output)
+		}
+		return fmt.Errorf("failed to export: %w", err)
+	}
+
+	return nil
+}
+
+func runVerify(args []string) error {
+	dataset, err := readBackup(args)
+	if err != nil {
+		return err
+	}
+
+	printSummary(dataset)
+	return nil
+}
+
+func runImport(ctx context.Context, args []string) error {
+	// The whole file is checked before connecting, a broken backup never touches the database
+	dataset, err := readBackup(args)
+	if err != nil {
+		return err
+	}
+
+	store, err := db.NewDB()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := backup.Restore(ctx, store, dataset); err != nil {
+		return fmt.Errorf("failed to restore: %w", err)
+	}
+
+	printSummary(dataset)
+	return nil
+}
+
+func readBackup(args []string) (*backup.Dataset, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("expected a single FILE argument, - for stdin")
+	}
+
+	var r io.Reader = os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", args[0], err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	return backup.Read(r)
+}
+
+func printSummary(dataset *backup.Dataset) {
+	counts := dataset.Counts()
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	fmt.Fprintf(os.Stderr, "backup of %s, anonymized: %t\n", dataset.CreatedAt().Format("2006-01-02 15:04:05 MST"), dataset.Anonymized())
+	for _, kind := range kinds {
+		fmt.Fprintf(os.Stderr, "  %-15s %d\n", kind, counts[kind])
+	}
+}
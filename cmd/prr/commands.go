@@ -0,0 +1,823 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+)
+
+type cli struct {
+	client *api.ClientWithResponses
+	out    *printer
+	stderr io.Writer
+}
+
+func (c *cli) dispatch(ctx context.Context, args []string) error {
+	switch args[0] {
+	case "team":
+		return c.subcommand(ctx, args, map[string]func(context.Context, []string) error{
+			"import": c.teamImport,
+			"show":   c.teamShow,
+		})
+	case "user":
+		return c.subcommand(ctx, args, map[string]func(context.Context, []string) error{
+			"activate":   func(ctx context.Context, args []string) error { return c.userSetIsActive(ctx, args, true) },
+			"deactivate": func(ctx context.Context, args []string) error { return c.userSetIsActive(ctx, args, false) },
+			"digest":     c.userDigest,
+		})
+	case "pr":
+		return c.subcommand(ctx, args, map[string]func(context.Context, []string) error{
+			"create":   c.prCreate,
+			"merge":    c.prMerge,
+			"reassign": c.prReassign,
+			"review":   c.prReview,
+			"list":     c.prList,
+			"simulate": c.prSimulate,
+		})
+	case "roster":
+		return c.subcommand(ctx, args, map[string]func(context.Context, []string) error{
+			"import": c.rosterImport,
+			"export": c.rosterExport,
+		})
+	case "stats":
+		return c.stats(ctx, args[1:])
+	default:
+		return usagef("unknown command %q, see prr -help", args[0])
+	}
+}
+
+func (c *cli) subcommand(ctx context.Context, args []string, commands map[string]func(context.Context, []string) error) error {
+	if len(args) < 2 {
+		return usagef("%s needs a subcommand, see prr -help", args[0])
+	}
+
+	cmd, ok := commands[args[1]]
+	if !ok {
+		return usagef("unknown command %q, see prr -help", args[0]+" "+args[1])
+	}
+
+	return cmd(ctx, args[2:])
+}
+
+// parseFlags lets flags go before and after positional arguments, e.g. "pr merge pr-1 --if-match 3"
+func (c *cli) parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(c.stderr)
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if err == flag.ErrHelp {
+				return nil, err
+			}
+			return nil, usagef("%s: %v", fs.Name(), err)
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// exactArgs checks the number of positional arguments
+func exactArgs(name string, args []string, names ...string) error {
+	if len(args) != len(names) {
+		return usagef("%s expects %s", name, strings.Join(names, " "))
+	}
+	return nil
+}
+
+// teamImport creates teams from a JSON file in the /team/add format, one team or an array of them
+func (c *cli) teamImport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("team import", flag.ContinueOnError)
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args, "<file|->"); err != nil {
+		return err
+	}
+
+	var data []byte
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read teams: %w", err)
+	}
+
+	var teams []api.Team
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = decodeJSON(trimmed, &teams)
+	} else {
+		teams = make([]api.Team, 1)
+		err = decodeJSON(trimmed, &teams[0])
+	}
+	if err != nil {
+		return usagef("failed to parse teams: %v", err)
+	}
+
+	created := make([]api.Team, 0, len(teams))
+	for _, team := range teams {
+		resp, err := c.client.PostTeamAddWithResponse(ctx, team)
+		if err != nil {
+			return fmt.Errorf("failed to send request: %w", err)
+		}
+		if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return fmt.Errorf("team %s: %w", team.TeamName, err)
+		}
+
+		created = append(created, resp.JSON201.Team)
+	}
+
+	if c.out.json() {
+		return c.out.printJSON(created)
+	}
+
+	for i, team := range created {
+		if i > 0 {
+			fmt.Fprintln(c.out.w)
+		}
+		if err := c.out.team(team); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *cli) teamShow(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("team show", flag.ContinueOnError)
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args, "<team_name>"); err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetTeamGetWithResponse(ctx, &api.GetTeamGetParams{TeamName: args[0]})
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	return c.out.team(*resp.JSON200)
+}
+
+func (c *cli) userSetIsActive(ctx context.Context, args []string, isActive bool) error {
+	name := "user deactivate"
+	if isActive {
+		name = "user activate"
+	}
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args, "<user_id>"); err != nil {
+		return err
+	}
+
+	resp, err := c.client.PostUsersSetIsActiveWithResponse(ctx, api.PostUsersSetIsActiveJSONRequestBody{
+		UserId:   args[0],
+		IsActive: isActive,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	return c.out.user(resp.JSON200.User)
+}
+
+// userDigest shows digest settings of the user, or changes the ones given by flags
+func (c *cli) userDigest(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("user digest", flag.ContinueOnError)
+	enable := fs.Bool("enable", false, "send the daily digest")
+	disable := fs.Bool("disable", false, "opt out of the daily digest")
+	timezone := fs.String("timezone", "", "IANA time zone of the user")
+	at := fs.String("time", "", "local time of the digest, HH:MM")
+	email := fs.String("email", "", "address for email digests, empty removes it")
+
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args, "<user_id>"); err != nil {
+		return err
+	}
+	if *enable && *disable {
+		return usagef("user digest takes either --enable or --disable")
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	if len(set) == 0 {
+		resp, err := c.client.GetUsersGetDigestWithResponse(ctx, &api.GetUsersGetDigestParams{UserId: args[0]})
+		if err != nil {
+			return fmt.Errorf("failed to send request: %w", err)
+		}
+		if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return err
+		}
+		return c.out.digest(resp.JSON200.Digest)
+	}
+
+	body := api.PostUsersSetDigestJSONRequestBody{UserId: args[0]}
+	if set["enable"] {
+		body.Enabled = enable
+	} else if set["disable"] {
+		enabled := !*disable
+		body.Enabled = &enabled
+	}
+	if set["timezone"] {
+		body.Timezone = timezone
+	}
+	if set["time"] {
+		body.Time = at
+	}
+	if set["email"] {
+		body.Email = email
+	}
+
+	resp, err := c.client.PostUsersSetDigestWithResponse(ctx, body)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	return c.out.digest(resp.JSON200.Digest)
+}
+
+// stringList is a flag which can be repeated
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+func (c *cli) prCreate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pr create", flag.ContinueOnError)
+	id := fs.String("id", "", "pull_request_id")
+	name := fs.String("name", "", "pull_request_name")
+	author := fs.String("author", "", "author user_id")
+	var files stringList
+	fs.Var(&files, "file", "changed file, used to pick code owners (repeatable)")
+	explain := fs.Bool("explain", false, "show how reviewers were picked")
+
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args); err != nil {
+		return err
+	}
+	if *id == "" || *name == "" || *author == "" {
+		return usagef("%s needs --id, --name and --author", fs.Name())
+	}
+
+	body := api.PostPullRequestCreateJSONRequestBody{
+		PullRequestId:   *id,
+		PullRequestName: *name,
+		AuthorId:        *author,
+	}
+	if len(files) > 0 {
+		body.ChangedFiles = (*[]string)(&files)
+	}
+
+	params := &api.PostPullRequestCreateParams{Explain: explain}
+	resp, err := c.client.PostPullRequestCreateWithResponse(ctx, params, body)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	if resp.JSON201.CapacityLimited && !c.out.json() {
+		fmt.Fprintln(c.stderr, "prr: fewer reviewers assigned because some candidates are at capacity")
+	}
+
+	if err := c.out.pullRequest(resp.JSON201, resp.JSON201.Pr, resp.HTTPResponse.Header.Get("ETag")); err != nil {
+		return err
+	}
+	return c.out.explanation(resp.JSON201.Explanation)
+}
+
+func (c *cli) prMerge(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pr merge", flag.ContinueOnError)
+	ifMatch := fs.String("if-match", "", "merge only if PR still has this ETag")
+
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args, "<pull_request_id>"); err != nil {
+		return err
+	}
+
+	params := &api.PostPullRequestMergeParams{IfMatch: etagParam(*ifMatch)}
+	resp, err := c.client.PostPullRequestMergeWithResponse(ctx, params, api.PostPullRequestMergeJSONRequestBody{
+		PullRequestId: args[0],
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	return c.out.pullRequest(resp.JSON200, resp.JSON200.Pr, resp.HTTPResponse.Header.Get("ETag"))
+}
+
+func (c *cli) prReview(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pr review", flag.ContinueOnError)
+	user := fs.String("user", "", "reviewer who has reviewed the PR")
+
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args, "<pull_request_id>"); err != nil {
+		return err
+	}
+	if *user == "" {
+		return usagef("pr review needs --user")
+	}
+
+	resp, err := c.client.PostPullRequestReviewWithResponse(ctx, api.PostPullRequestReviewJSONRequestBody{
+		PullRequestId: args[0],
+		UserId:        *user,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	return c.out.pullRequest(resp.JSON200, resp.JSON200.Pr, resp.HTTPResponse.Header.Get("ETag"))
+}
+
+func (c *cli) prReassign(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pr reassign", flag.ContinueOnError)
+	oldUser := fs.String("old", "", "reviewer to replace")
+	newUser := fs.String("new", "", "new reviewer (default: random candidate)")
+	ifMatch := fs.String("if-match", "", "reassign only if PR still has this ETag")
+	explain := fs.Bool("explain", false, "show how the new reviewer was picked")
+
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args, "<pull_request_id>"); err != nil {
+		return err
+	}
+	if *oldUser == "" {
+		return usagef("%s needs --old", fs.Name())
+	}
+
+	body := api.PostPullRequestReassignJSONRequestBody{
+		PullRequestId: args[0],
+		OldUserId:     *oldUser,
+	}
+	if *newUser != "" {
+		body.NewUserId = newUser
+	}
+
+	params := &api.PostPullRequestReassignParams{IfMatch: etagParam(*ifMatch), Explain: explain}
+	resp, err := c.client.PostPullRequestReassignWithResponse(ctx, params, body)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	if !c.out.json() {
+		fmt.Fprintf(c.stderr, "prr: %s replaced by %s\n", *oldUser, resp.JSON200.ReplacedBy)
+	}
+
+	if err := c.out.pullRequest(resp.JSON200, resp.JSON200.Pr, resp.HTTPResponse.Header.Get("ETag")); err != nil {
+		return err
+	}
+	return c.out.explanation(resp.JSON200.Explanation)
+}
+
+// etagParam accepts both a bare version and a quoted ETag as printed by other commands
+func etagParam(v string) *api.IfMatch {
+	if v == "" {
+		return nil
+	}
+	if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+		v = strconv.Quote(v)
+	}
+	return &v
+}
+
+func (c *cli) prList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pr list", flag.ContinueOnError)
+	status := fs.String("status", "", "OPEN or MERGED")
+	author := fs.String("author", "", "author user_id")
+	reviewer := fs.String("reviewer", "", "assigned reviewer user_id")
+	team := fs.String("team", "", "author's team")
+	q := fs.String("q", "", "substring of PR name")
+	limit := fs.Int("limit", 0, "page size (default: server default)")
+	cursor := fs.String("cursor", "", "next_cursor from the previous page")
+	all := fs.Bool("all", false, "fetch all pages")
+
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args); err != nil {
+		return err
+	}
+	if *all && *cursor != "" {
+		return usagef("%s: --all and --cursor cannot be used together", fs.Name())
+	}
+
+	params := &api.GetPullRequestListParams{
+		AuthorId:   optional(*author),
+		ReviewerId: optional(*reviewer),
+		TeamName:   optional(*team),
+		Q:          optional(*q),
+		Cursor:     optional(*cursor),
+	}
+	if *status != "" {
+		s := api.GetPullRequestListParamsStatus(strings.ToUpper(*status))
+		params.Status = &s
+	}
+	if *limit > 0 {
+		params.Limit = limit
+	}
+
+	var prs []api.PullRequest
+	var next *string
+	for {
+		resp, err := c.client.GetPullRequestListWithResponse(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to send request: %w", err)
+		}
+		if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+			return err
+		}
+
+		prs = append(prs, resp.JSON200.PullRequests...)
+		next = resp.JSON200.NextCursor
+
+		if !*all || next == nil {
+			break
+		}
+		params.Cursor = next
+	}
+
+	if c.out.json() {
+		return c.out.printJSON(struct {
+			PullRequests []api.PullRequest `json:"pull_requests"`
+			NextCursor   *string           `json:"next_cursor"`
+		}{prs, next})
+	}
+
+	if err := c.out.pullRequests(prs); err != nil {
+		return err
+	}
+
+	if next != nil {
+		fmt.Fprintf(c.stderr, "prr: more results, use --cursor %s or --all\n", *next)
+	}
+
+	return nil
+}
+
+// prSimulate shows who would review PRs of the author, nothing is created
+func (c *cli) prSimulate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pr simulate", flag.ContinueOnError)
+	author := fs.String("author", "", "author user_id")
+	count := fs.Int("count", 1, "how many PRs to simulate")
+	var files, fallbacks, inactive stringList
+	fs.Var(&files, "file", "changed file, used to pick code owners (repeatable)")
+	selection := fs.String("selection", "", "override selection mode: random or fair")
+	window := fs.String("window", "", "override fairness window, e.g. 168h")
+	capacity := fs.Int("capacity", -1, "override default max open reviews of the author's team")
+	fs.Var(&fallbacks, "fallback", "override fallback teams of the author's team (repeatable, in priority order)")
+	fs.Var(&inactive, "inactive", "treat the user as inactive (repeatable)")
+
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args); err != nil {
+		return err
+	}
+	if *author == "" {
+		return usagef("%s needs --author", fs.Name())
+	}
+
+	overrides := api.SimulationOverrides{FairnessWindow: optional(*window)}
+	if *selection != "" {
+		s := api.SimulationOverridesSelection(*selection)
+		overrides.Selection = &s
+	}
+	if *capacity >= 0 {
+		overrides.DefaultMaxOpenReviews = capacity
+	}
+	if len(fallbacks) > 0 {
+		overrides.FallbackTeams = (*[]string)(&fallbacks)
+	}
+	if len(inactive) > 0 {
+		overrides.InactiveUsers = (*[]string)(&inactive)
+	}
+
+	body := api.PostPullRequestSimulateJSONRequestBody{AuthorId: *author, Count: count, Overrides: &overrides}
+	if len(files) > 0 {
+		body.ChangedFiles = (*[]string)(&files)
+	}
+
+	resp, err := c.client.PostPullRequestSimulateWithResponse(ctx, body)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	sim := resp.JSON200
+	if c.out.json() {
+		return c.out.printJSON(sim)
+	}
+
+	fmt.Fprintf(c.out.w, "%d PRs of %s (%s), %s selection, %d with fewer than 2 reviewers\n\n",
+		len(sim.PullRequests), sim.AuthorId, sim.TeamName, sim.Selection, sim.Understaffed)
+
+	rows := make([][]string, 0, len(sim.Load))
+	for _, l := range sim.Load {
+		limit := "-"
+		if l.MaxOpenReviews != nil {
+			limit = strconv.Itoa(*l.MaxOpenReviews)
+		}
+		rows = append(rows, []string{
+			l.UserId, l.TeamName, strconv.Itoa(l.Assigned),
+			strconv.Itoa(l.OpenReviewsBefore), strconv.Itoa(l.OpenReviewsAfter), limit,
+		})
+	}
+
+	return c.out.table([]string{"USER_ID", "TEAM", "ASSIGNED", "OPEN_BEFORE", "OPEN_AFTER", "LIMIT"}, rows)
+}
+
+// rosterImport sends the roster file as is, the format is taken from --format or the file extension
+func (c *cli) rosterImport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("roster import", flag.ContinueOnError)
+	format := fs.String("format", "", "json, yaml or csv (default: by file extension, json for stdin)")
+	dryRun := fs.Bool("dry-run", false, "only show the changes")
+
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args, "<file|->"); err != nil {
+		return err
+	}
+
+	if *format == "" {
+		*format = formatByExtension(args[0])
+	}
+	contentType, ok := rosterContentTypes[*format]
+	if !ok {
+		return usagef("%s: --format must be json, yaml or csv", fs.Name())
+	}
+
+	var data []byte
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read roster: %w", err)
+	}
+
+	params := &api.PostRosterImportParams{DryRun: dryRun}
+	resp, err := c.client.PostRosterImportWithBodyWithResponse(ctx, params, contentType, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	result := resp.JSON200
+	if c.out.json() {
+		return c.out.printJSON(result)
+	}
+
+	if err := c.out.rosterChanges(result.Changes); err != nil {
+		return err
+	}
+
+	if result.DryRun {
+		fmt.Fprintf(c.out.w, "\n%d changes, nothing applied (dry run)\n", len(result.Changes))
+	} else {
+		fmt.Fprintf(c.out.w, "\n%d changes applied\n", len(result.Changes))
+	}
+
+	return nil
+}
+
+var rosterContentTypes = map[string]string{
+	"json": "application/json",
+	"yaml": "application/yaml",
+	"csv":  "text/csv",
+}
+
+func formatByExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".csv":
+		return "csv"
+	default:
+		return "json"
+	}
+}
+
+// rosterExport prints the roster in the format /roster/import accepts, so it can be edited and imported back
+func (c *cli) rosterExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("roster export", flag.ContinueOnError)
+	format := fs.String("format", "yaml", "json, yaml or csv")
+
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args); err != nil {
+		return err
+	}
+	if _, ok := rosterContentTypes[*format]; !ok {
+		return usagef("%s: --format must be json, yaml or csv", fs.Name())
+	}
+
+	f := api.GetRosterExportParamsFormat(*format)
+	resp, err := c.client.GetRosterExportWithResponse(ctx, &api.GetRosterExportParams{Format: &f})
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	_, err = c.out.w.Write(resp.Body)
+	return err
+}
+
+func (c *cli) stats(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	team := fs.String("team", "", "only PRs and reviewers of this team")
+	pairings := fs.Bool("pairings", false, "show who reviews whom in the team instead")
+	breaches := fs.Bool("sla-breaches", false, "show review SLA breaches instead")
+
+	args, err := c.parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs.Name(), args); err != nil {
+		return err
+	}
+
+	if *pairings {
+		if *team == "" {
+			return usagef("stats --pairings needs --team")
+		}
+		return c.statsPairings(ctx, *team)
+	}
+	if *breaches {
+		return c.statsSLABreaches(ctx, *team)
+	}
+
+	resp, err := c.client.GetStatsWithResponse(ctx, &api.GetStatsParams{TeamName: optional(*team)})
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	stats := resp.JSON200
+	if c.out.json() {
+		return c.out.printJSON(stats)
+	}
+
+	fmt.Fprintf(c.out.w, "Pull requests: %d open, %d merged\n\n", stats.PullRequests.Open, stats.PullRequests.Merged)
+
+	rows := make([][]string, 0, len(stats.Reviewers))
+	for _, r := range stats.Reviewers {
+		rows = append(rows, []string{
+			r.UserId, r.Username, r.TeamName, activity(r.IsActive),
+			strconv.Itoa(r.OpenReviews), strconv.Itoa(r.TotalReviews),
+		})
+	}
+
+	return c.out.table([]string{"USER_ID", "USERNAME", "TEAM", "STATUS", "OPEN", "TOTAL"}, rows)
+}
+
+func (c *cli) statsPairings(ctx context.Context, team string) error {
+	resp, err := c.client.GetStatsPairingsWithResponse(ctx, &api.GetStatsPairingsParams{TeamName: team})
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	matrix := resp.JSON200
+	if c.out.json() {
+		return c.out.printJSON(matrix)
+	}
+
+	rows := make([][]string, 0)
+	for _, a := range matrix.Authors {
+		for _, p := range a.Reviewers {
+			rows = append(rows, []string{
+				a.AuthorId, p.ReviewerId, strconv.Itoa(p.Reviews),
+				strconv.FormatFloat(p.Weight, 'f', 2, 64), p.LastReviewedAt.Format(time.RFC3339),
+			})
+		}
+	}
+
+	return c.out.table([]string{"AUTHOR", "REVIEWER", "REVIEWS", "WEIGHT", "LAST"}, rows)
+}
+
+func (c *cli) statsSLABreaches(ctx context.Context, team string) error {
+	limit := 100
+	resp, err := c.client.GetStatsSlaBreachesWithResponse(ctx, &api.GetStatsSlaBreachesParams{
+		TeamName: optional(team),
+		Limit:    &limit,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+
+	breaches := resp.JSON200
+	if c.out.json() {
+		return c.out.printJSON(breaches)
+	}
+
+	rows := make([][]string, 0, len(breaches.Breaches))
+	for _, b := range breaches.Breaches {
+		replacedBy := "-"
+		if b.ReplacedBy != nil {
+			replacedBy = *b.ReplacedBy
+		}
+		rows = append(rows, []string{
+			b.PullRequestId, b.UserId, b.TeamName, b.DueAt.Format(time.RFC3339), string(b.Escalation), replacedBy,
+		})
+	}
+
+	return c.out.table([]string{"PR", "REVIEWER", "TEAM", "DUE", "ESCALATION", "REPLACED_BY"}, rows)
+}
+
+func optional(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// decodeJSON is strict, so typos in import files are reported instead of silently ignored
+func decodeJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	defaultServer = "http://localhost:8080"
+	defaultOutput = formatTable
+)
+
+// profile is a named set of defaults, e.g. one per environment
+type profile struct {
+	Server string `json:"server"`
+	Actor  string `json:"actor"`
+	Output string `json:"output"`
+}
+
+// config is the content of config.json
+type config struct {
+	DefaultProfile string             `json:"default_profile"`
+	Profiles       map[string]profile `json:"profiles"`
+}
+
+// settings are what the command runs with after flags, environment and profile are merged
+type settings struct {
+	Server string
+	Actor  string
+	Output string
+}
+
+// configPath is PRR_CONFIG or prr/config.json in the user config directory
+func configPath() (string, error) {
+	if path := os.Getenv("PRR_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config directory: %w", err)
+	}
+
+	return filepath.Join(dir, "prr", "config.json"), nil
+}
+
+// loadConfig reads the config file, a missing file is the same as an empty one
+func loadConfig() (*config, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	cfg := &config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// resolveSettings merges flags, environment and the profile, in that order of priority
+func resolveSettings(profileName, server, output, actor string) (settings, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return settings{}, err
+	}
+
+	if profileName == "" {
+		profileName = os.Getenv("PRR_PROFILE")
+	}
+	if profileName == "" {
+		profileName = cfg.DefaultProfile
+	}
+
+	var p profile
+	if profileName != "" {
+		var ok bool
+		if p, ok = cfg.Profiles[profileName]; !ok {
+			return settings{}, fmt.Errorf("profile %q not found in config", profileName)
+		}
+	}
+
+	s := settings{
+		Server: firstNonEmpty(server, os.Getenv("PRR_SERVER"), p.Server, defaultServer),
+		Actor:  firstNonEmpty(actor, os.Getenv("PRR_ACTOR"), p.Actor),
+		Output: firstNonEmpty(output, p.Output, defaultOutput),
+	}
+
+	if s.Output != formatTable && s.Output != formatJSON {
+		return settings{}, fmt.Errorf("output must be %s or %s", formatTable, formatJSON)
+	}
+
+	return s, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,208 @@
+// Command prr is an admin CLI for the reviewers service. It wraps the HTTP API, so everything it does
+// can be done with curl as well.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+)
+
+// Exit codes, so scripts can tell what went wrong without parsing the output
+const (
+	exitOK       = 0
+	exitFailure  = 1 // transport or internal server error
+	exitUsage    = 2 // bad arguments or INVALID_REQUEST
+	exitNotFound = 3
+	exitConflict = 4 // entity already exists or is already assigned
+	exitRule     = 5 // request breaks a domain rule
+	exitNoReview = 6 // no reviewer can be assigned
+	exitChanged  = 7 // PR version or idempotency key mismatch
+)
+
+var exitCodes = map[api.ErrorResponseErrorCode]int{
+	api.INVALIDREQUEST:       exitUsage,
+	api.NOTFOUND:             exitNotFound,
+	api.TEAMEXISTS:           exitConflict,
+	api.PREXISTS:             exitConflict,
+	api.ALREADYASSIGNED:      exitConflict,
+	api.PRMERGED:             exitRule,
+	api.NOTASSIGNED:          exitRule,
+	api.REVIEWERNOTELIGIBLE:  exitRule,
+	api.TOOMANYREVIEWERS:     exitRule,
+	api.NOCANDIDATE:          exitNoReview,
+	api.ATCAPACITY:           exitNoReview,
+	api.PRVERSIONMISMATCH:    exitChanged,
+	api.IDEMPOTENCYKEYINUSE:  exitChanged,
+	api.IDEMPOTENCYKEYREUSED: exitChanged,
+}
+
+const requestTimeout = 30 * time.Second
+
+const usage = `Usage: prr [global flags] <command> [flags]
+
+Commands:
+  team import <file|->              create teams from JSON file (a team or an array of teams)
+  team show <team_name>
+  user activate <user_id>
+  user deactivate <user_id>
+  user digest <user_id> [--enable | --disable] [--timezone TZ] [--time HH:MM] [--email ADDR]
+                                    show or change the daily digest of open reviews
+  pr create --id ID --name NAME --author USER_ID [--file PATH]... [--explain]
+  pr merge <pull_request_id> [--if-match ETAG]
+  pr reassign <pull_request_id> --old USER_ID [--new USER_ID] [--if-match ETAG] [--explain]
+  pr review <pull_request_id> --user USER_ID
+                                    record that the reviewer has reviewed the PR
+  pr simulate --author USER_ID [--count N] [--file PATH]... [--selection random|fair] [--window DURATION]
+              [--capacity N] [--fallback TEAM]... [--inactive USER_ID]...
+                                    who would review PRs of the author, nothing is created
+  pr list [--status OPEN|MERGED] [--author ID] [--reviewer ID] [--team NAME] [--q TEXT] [--limit N] [--cursor C | --all]
+  roster import <file|-> [--dry-run] [--format json|yaml|csv]
+  roster export [--format json|yaml|csv]
+  stats [--team NAME]
+  stats --team NAME --pairings      who reviews whom in the team, weighted as fair selection sees it
+  stats --sla-breaches [--team NAME]
+                                    latest reviews that missed the review SLA of the team
+
+Global flags:
+`
+
+// apiError is ErrorResponse returned by the service
+type apiError struct {
+	status  int
+	code    api.ErrorResponseErrorCode
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s: %s", e.code, e.message)
+}
+
+// usageError is a mistake in command line arguments
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	global := flag.NewFlagSet("prr", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		global.PrintDefaults()
+	}
+
+	profileName := global.String("profile", "", "config profile (default from config file or PRR_PROFILE)")
+	server := global.String("server", "", "service URL (default from profile, PRR_SERVER or http://localhost:8080)")
+	output := global.String("output", "", "output format: table or json")
+	actor := global.String("actor", "", "user_id sent as X-Actor-Id for the audit log")
+
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if global.NArg() == 0 {
+		global.Usage()
+		return exitUsage
+	}
+
+	settings, err := resolveSettings(*profileName, *server, *output, *actor)
+	if err != nil {
+		fmt.Fprintf(stderr, "prr: %v\n", err)
+		return exitUsage
+	}
+
+	client, err := newClient(settings)
+	if err != nil {
+		fmt.Fprintf(stderr, "prr: %v\n", err)
+		return exitUsage
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	c := &cli{client: client, out: &printer{w: stdout, format: settings.Output}, stderr: stderr}
+
+	err = c.dispatch(ctx, global.Args())
+	if err == nil {
+		return exitOK
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	fmt.Fprintf(stderr, "prr: %v\n", err)
+	return exitCode(err)
+}
+
+// exitCode maps an error to the process exit code
+func exitCode(err error) int {
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return exitUsage
+	}
+
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		if code, ok := exitCodes[apiErr.code]; ok {
+			return code
+		}
+	}
+
+	return exitFailure
+}
+
+func newClient(s settings) (*api.ClientWithResponses, error) {
+	opts := []api.ClientOption{api.WithHTTPClient(&http.Client{Timeout: requestTimeout})}
+	if s.Actor != "" {
+		opts = append(opts, api.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("X-Actor-Id", s.Actor)
+			return nil
+		}))
+	}
+
+	client, err := api.NewClientWithResponses(strings.TrimSuffix(s.Server, "/"), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return client, nil
+}
+
+// checkResponse turns a non-2xx response into apiError
+func checkResponse(resp *http.Response, body []byte) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	var errResp api.ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Code == "" {
+		return fmt.Errorf("unexpected response %s", resp.Status)
+	}
+
+	return &apiError{status: resp.StatusCode, code: errResp.Error.Code, message: errResp.Error.Message}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+)
+
+// request is what a fake service has seen
+type request struct {
+	server string
+	actor  string
+}
+
+// fakeService answers /users/setIsActive with the status and body, recording requests to seen
+func fakeService(t *testing.T, name string, status int, body string, seen *[]request) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if seen != nil {
+			*seen = append(*seen, request{server: name, actor: r.Header.Get("X-Actor-Id")})
+		}
+		if r.URL.Path != "/users/setIsActive" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+const activated = `{"user":{"user_id":"u1","username":"Alice","team_name":"backend","is_active":true}}`
+
+// isolate keeps the config and environment of the machine out of the test
+func isolate(t *testing.T) {
+	t.Setenv("PRR_CONFIG", filepath.Join(t.TempDir(), "config.json"))
+	for _, name := range []string{"PRR_PROFILE", "PRR_SERVER", "PRR_ACTOR"} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	isolate(t)
+
+	errorBody := func(code api.ErrorResponseErrorCode) string {
+		return fmt.Sprintf(`{"error":{"code":%q,"message":"failed"}}`, code)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		status int
+		body   string
+		code   int
+	}{
+		{"success", http.StatusOK, activated, exitOK},
+		{"invalid request", http.StatusBadRequest, errorBody(api.INVALIDREQUEST), exitUsage},
+		{"not found", http.StatusNotFound, errorBody(api.NOTFOUND), exitNotFound},
+		{"team exists", http.StatusBadRequest, errorBody(api.TEAMEXISTS), exitConflict},
+		{"PR exists", http.StatusConflict, errorBody(api.PREXISTS), exitConflict},
+		{"already assigned", http.StatusConflict, errorBody(api.ALREADYASSIGNED), exitConflict},
+		{"PR merged", http.StatusConflict, errorBody(api.PRMERGED), exitRule},
+		{"not assigned", http.StatusConflict, errorBody(api.NOTASSIGNED), exitRule},
+		{"reviewer not eligible", http.StatusConflict, errorBody(api.REVIEWERNOTELIGIBLE), exitRule},
+		{"too many reviewers", http.StatusConflict, errorBody(api.TOOMANYREVIEWERS), exitRule},
+		{"no candidate", http.StatusConflict, errorBody(api.NOCANDIDATE), exitNoReview},
+		{"at capacity", http.StatusConflict, errorBody(api.ATCAPACITY), exitNoReview},
+		{"version mismatch", http.StatusPreconditionFailed, errorBody(api.PRVERSIONMISMATCH), exitChanged},
+		{"idempotency key in use", http.StatusConflict, errorBody(api.IDEMPOTENCYKEYINUSE), exitChanged},
+		{"idempotency key reused", http.StatusUnprocessableEntity, errorBody(api.IDEMPOTENCYKEYREUSED), exitChanged},
+		{"internal error", http.StatusInternalServerError, errorBody(api.INTERNALERROR), exitFailure},
+		{"unknown code", http.StatusConflict, errorBody("SOMETHING_NEW"), exitFailure},
+		{"not an error response", http.StatusBadGateway, `<html>bad gateway</html>`, exitFailure},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := fakeService(t, "", tc.status, tc.body, nil)
+
+			var stdout, stderr bytes.Buffer
+			code := run([]string{"--server", srv.URL, "user", "activate", "u1"}, &stdout, &stderr)
+			if code != tc.code {
+				t.Errorf("expected exit code %d, got %d: %s", tc.code, code, stderr.String())
+			}
+			if code != exitOK && !strings.HasPrefix(stderr.String(), "prr: ") {
+				t.Errorf("expected the error on stderr, got %q", stderr.String())
+			}
+		})
+	}
+
+	t.Run("unreachable service", func(t *testing.T) {
+		srv := fakeService(t, "", http.StatusOK, activated, nil)
+		srv.Close()
+
+		if code := run([]string{"--server", srv.URL, "user", "activate", "u1"}, &bytes.Buffer{}, &bytes.Buffer{}); code != exitFailure {
+			t.Errorf("expected exit code %d, got %d", exitFailure, code)
+		}
+	})
+
+	for _, args := range [][]string{
+		{},
+		{"unknown"},
+		{"user"},
+		{"user", "promote", "u1"},
+		{"user", "activate"},
+		{"user", "activate", "u1", "u2"},
+		{"--no-such-flag", "user", "activate", "u1"},
+		{"user", "activate", "u1", "--no-such-flag"},
+	} {
+		t.Run(strings.Join(append([]string{"usage"}, args...), " "), func(t *testing.T) {
+			srv := fakeService(t, "", http.StatusOK, activated, nil)
+
+			var stderr bytes.Buffer
+			code := run(append([]string{"--server", srv.URL}, args...), &bytes.Buffer{}, &stderr)
+			if code != exitUsage {
+				t.Errorf("expected exit code %d, got %d: %s", exitUsage, code, stderr.String())
+			}
+		})
+	}
+
+	if code := run([]string{"-help"}, &bytes.Buffer{}, &bytes.Buffer{}); code != exitOK {
+		t.Errorf("expected -help to exit with %d, got %d", exitOK, code)
+	}
+}
+
+// TestSettingsPriority checks that flags win over the environment and the environment over the profile.
+// Output has no environment variable.
+func TestSettingsPriority(t *testing.T) {
+	var seen []request
+	servers := make(map[string]string)
+	for _, name := range []string{"flag", "env", "profile", "default profile", "env profile"} {
+		servers[name] = fakeService(t, name, http.StatusOK, activated, &seen).URL
+	}
+
+	writeConfig := func(t *testing.T, cfg config) {
+		t.Helper()
+		data, err := json.Marshal(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(os.Getenv("PRR_CONFIG"), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := config{
+		DefaultProfile: "prod",
+		Profiles: map[string]profile{
+			"prod":    {Server: servers["default profile"], Actor: "u-prod", Output: formatJSON},
+			"staging": {Server: servers["env profile"], Actor: "u-staging"},
+			"local":   {Server: servers["profile"], Actor: "u-local", Output: formatJSON},
+		},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		flags  []string
+		env    map[string]string
+		server string
+		actor  string
+		json   bool
+	}{
+		{
+			name:   "default profile",
+			server: "default profile", actor: "u-prod", json: true,
+		},
+		{
+			name:   "profile from the environment over the default one",
+			env:    map[string]string{"PRR_PROFILE": "staging"},
+			server: "env profile", actor: "u-staging",
+		},
+		{
+			name:   "profile flag over the environment",
+			flags:  []string{"--profile", "local"},
+			env:    map[string]string{"PRR_PROFILE": "staging"},
+			server: "profile", actor: "u-local", json: true,
+		},
+		{
+			name:   "environment over the profile",
+			env:    map[string]string{"PRR_SERVER": servers["env"], "PRR_ACTOR": "u-env"},
+			server: "env", actor: "u-env", json: true,
+		},
+		{
+			name:   "flags over the environment and the profile",
+			flags:  []string{"--server", servers["flag"], "--actor", "u-flag", "--output", formatTable},
+			env:    map[string]string{"PRR_SERVER": servers["env"], "PRR_ACTOR": "u-env"},
+			server: "flag", actor: "u-flag",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			isolate(t)
+			writeConfig(t, cfg)
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+			seen = nil
+
+			var stdout, stderr bytes.Buffer
+			if code := run(append(tc.flags, "user", "activate", "u1"), &stdout, &stderr); code != exitOK {
+				t.Fatalf("expected exit code %d, got %d: %s", exitOK, code, stderr.String())
+			}
+
+			expected := []request{{server: tc.server, actor: tc.actor}}
+			if len(seen) != 1 || seen[0] != expected[0] {
+				t.Errorf("expected %+v, got %+v", expected, seen)
+			}
+			if json.Valid(stdout.Bytes()) != tc.json {
+				t.Errorf("expected JSON output to be %v, got %q", tc.json, stdout.String())
+			}
+		})
+	}
+
+	t.Run("without config", func(t *testing.T) {
+		isolate(t)
+
+		s, err := resolveSettings("", "", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != (settings{Server: defaultServer, Output: defaultOutput}) {
+			t.Errorf("expected defaults, got %+v", s)
+		}
+	})
+
+	for _, tc := range []struct {
+		name  string
+		flags []string
+		env   map[string]string
+	}{
+		{name: "unknown profile flag", flags: []string{"--profile", "qa"}},
+		{name: "unknown profile in the environment", env: map[string]string{"PRR_PROFILE": "qa"}},
+		{name: "unknown output", flags: []string{"--output", "yaml"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			isolate(t)
+			writeConfig(t, cfg)
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+			seen = nil
+
+			var stderr bytes.Buffer
+			if code := run(append(tc.flags, "user", "activate", "u1"), &bytes.Buffer{}, &stderr); code != exitUsage {
+				t.Errorf("expected exit code %d, got %d: %s", exitUsage, code, stderr.String())
+			}
+			if len(seen) != 0 {
+				t.Errorf("expected no requests, got %+v", seen)
+			}
+		})
+	}
+}
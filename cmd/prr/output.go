@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// printer writes results either as aligned tables for people or as JSON for scripts
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func (p *printer) json() bool {
+	return p.format == formatJSON
+}
+
+// printJSON prints v indented, the same way for every command
+func (p *printer) printJSON(v interface{}) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// table prints rows under the header, columns are aligned
+func (p *printer) table(header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (p *printer) team(team api.Team) error {
+	if p.json() {
+		return p.printJSON(team)
+	}
+
+	rows := make([][]string, 0, len(team.Members))
+	for _, m := range team.Members {
+		rows = append(rows, []string{team.TeamName, m.UserId, m.Username, activity(m.IsActive)})
+	}
+
+	return p.table([]string{"TEAM", "USER_ID", "USERNAME", "STATUS"}, rows)
+}
+
+func (p *printer) user(user api.User) error {
+	if p.json() {
+		return p.printJSON(user)
+	}
+
+	return p.table([]string{"USER_ID", "USERNAME", "TEAM", "STATUS"}, [][]string{
+		{user.UserId, user.Username, user.TeamName, activity(user.IsActive)},
+	})
+}
+
+func (p *printer) digest(d api.DigestSettings) error {
+	if p.json() {
+		return p.printJSON(d)
+	}
+
+	status := "subscribed"
+	if !d.Enabled {
+		status = "opted out"
+	}
+	email, lastSent := "-", "-"
+	if d.Email != nil {
+		email = *d.Email
+	}
+	if d.LastSentOn != nil {
+		lastSent = *d.LastSentOn
+	}
+
+	return p.table([]string{"USER_ID", "STATUS", "TIMEZONE", "TIME", "EMAIL", "LAST_SENT"}, [][]string{
+		{d.UserId, status, d.Timezone, d.Time, email, lastSent},
+	})
+}
+
+func (p *printer) pullRequests(prs []api.PullRequest) error {
+	rows := make([][]string, 0, len(prs))
+	for _, pr := range prs {
+		reviewers := strings.Join(pr.AssignedReviewers, ",")
+		if reviewers == "" {
+			reviewers = "-"
+		}
+		rows = append(rows, []string{pr.PullRequestId, pr.PullRequestName, pr.AuthorId, string(pr.Status), reviewers})
+	}
+
+	return p.table([]string{"PR_ID", "NAME", "AUTHOR", "STATUS", "REVIEWERS"}, rows)
+}
+
+// pullRequest prints a single PR. ETag goes after the table so it can be passed to --if-match later.
+func (p *printer) pullRequest(v interface{}, pr api.PullRequest, etag string) error {
+	if p.json() {
+		return p.printJSON(v)
+	}
+
+	if err := p.pullRequests([]api.PullRequest{pr}); err != nil {
+		return err
+	}
+
+	if etag != "" {
+		fmt.Fprintf(p.w, "\nETag: %s\n", etag)
+	}
+
+	return nil
+}
+
+// explanation prints candidates considered for a PR after the PR itself. JSON output has it inside the response.
+func (p *printer) explanation(e *api.AssignmentExplanation) error {
+	if e == nil || p.json() {
+		return nil
+	}
+
+	fmt.Fprintf(p.w, "\nCandidates (%s selection):\n", e.Selection)
+
+	rows := make([][]string, 0, len(e.Candidates))
+	for _, c := range e.Candidates {
+		team, pool, rank, score, result := "-", "-", "-", "-", "-"
+		if c.TeamName != nil {
+			team = *c.TeamName
+		}
+		if c.Pool != nil {
+			pool = string(*c.Pool)
+		}
+		if c.Rank != nil {
+			rank = strconv.Itoa(*c.Rank)
+		}
+		if c.Score != nil {
+			score = strconv.FormatFloat(*c.Score, 'f', 3, 64)
+		}
+		switch {
+		case c.Picked:
+			result = "picked"
+		case c.Reason != nil:
+			result = string(*c.Reason)
+		}
+		rows = append(rows, []string{c.UserId, team, pool, rank, score, result})
+	}
+
+	return p.table([]string{"USER_ID", "TEAM", "POOL", "RANK", "SCORE", "RESULT"}, rows)
+}
+
+func (p *printer) rosterChanges(changes []api.RosterChange) error {
+	rows := make([][]string, 0, len(changes))
+	for _, c := range changes {
+		userId, details := "-", ""
+		if c.UserId != nil {
+			userId = *c.UserId
+		}
+
+		switch {
+		case c.Action == api.CreateUser:
+			details = fmt.Sprintf("%s, %s", c.After.Username, activity(c.After.IsActive))
+		case c.Before != nil && c.After != nil:
+			var diff []string
+			if c.Before.TeamName != c.After.TeamName {
+				diff = append(diff, fmt.Sprintf("team %s -> %s", c.Before.TeamName, c.After.TeamName))
+			}
+			if c.Before.Username != c.After.Username {
+				diff = append(diff, fmt.Sprintf("username %s -> %s", c.Before.Username, c.After.Username))
+			}
+			if c.Before.IsActive != c.After.IsActive {
+				diff = append(diff, fmt.Sprintf("%s -> %s", activity(c.Before.IsActive), activity(c.After.IsActive)))
+			}
+			details = strings.Join(diff, ", ")
+		}
+
+		rows = append(rows, []string{string(c.Action), c.TeamName, userId, details})
+	}
+
+	return p.table([]string{"ACTION", "TEAM", "USER_ID", "CHANGE"}, rows)
+}
+
+func activity(isActive bool) string {
+	if isActive {
+		return "active"
+	}
+	return "inactive"
+}
@@ -1,38 +1,147 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	// Time zones of digests don't depend on tzdata of the image
+	_ "time/tzdata"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/config"
 	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/events"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/grpcserver"
 	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/idempotency"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/notify"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/scheduler"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/validator"
 )
 
+// pruneInterval is how often expired idempotency keys, stream events and audit records past retention are deleted
+const pruneInterval = time.Hour
+
 func main() {
+	cfg, cfgerr := config.Load()
+	if cfgerr != nil {
+		panic(cfgerr)
+	}
+
 	db, dberr := db.NewDB()
 	if dberr != nil {
 		panic(dberr)
 	}
 	defer db.Close()
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	swagger, swaggererr := api.GetSwagger()
+	if swaggererr != nil {
+		panic(swaggererr)
+	}
+
+	validate, validateerr := validator.Middleware(swagger, validator.Options{ValidateResponses: cfg.ValidateResponses})
+	if validateerr != nil {
+		panic(validateerr)
+	}
+
 	router := chi.NewRouter()
 	router.Use(middleware.Logger)
+	router.Use(validate)
+
+	idempotencyStore := idempotency.New(db, cfg.IdempotencyTTL)
+	router.Use(idempotencyStore.Middleware)
+
+	broker := events.NewBroker(db)
+	go broker.Run(ctx)
+
+	opts := handler.Options{
+		Selection:      handler.Selection(cfg.ReviewerSelection),
+		FairnessWindow: cfg.FairnessWindow,
+	}
+	if cfg.RandomSeed != nil {
+		opts.Random = handler.NewRandom(*cfg.RandomSeed)
+	}
+	if cfg.DigestWebhookURL != "" {
+		opts.Notifiers = append(opts.Notifiers, notify.NewWebhook(cfg.DigestWebhookURL))
+	}
+	if cfg.DigestSlackWebhookURL != "" {
+		opts.Notifiers = append(opts.Notifiers, notify.NewSlack(cfg.DigestSlackWebhookURL))
+	}
+	if cfg.SMTPAddr != "" {
+		opts.Notifiers = append(opts.Notifiers, notify.NewSMTP(cfg.SMTPAddr, cfg.SMTPFrom, cfg.SMTPUsername, cfg.SMTPPassword))
+	}
 
-	h := handler.NewHandler(db)
+	h := handler.NewHandler(db, broker, opts)
 	apiHandler := api.Handler(h)
 
 	router.Mount("/", apiHandler)
 
+	if cfg.AbsenceReassignInterval > 0 {
+		go scheduler.Run(ctx, "absence reassign", cfg.AbsenceReassignInterval, h.ReassignAbsentReviewers)
+	}
+
+	if cfg.SLACheckInterval > 0 {
+		go scheduler.Run(ctx, "review SLA escalation", cfg.SLACheckInterval, h.EscalateReviewSLA)
+	}
+
+	if cfg.DigestCheckInterval > 0 && len(opts.Notifiers) > 0 {
+		go scheduler.Run(ctx, "review digests", cfg.DigestCheckInterval, h.SendDigests)
+	}
+
+	go scheduler.Run(ctx, "idempotency keys expiry", pruneInterval, idempotencyStore.Prune)
+
+	go scheduler.Run(ctx, "stream events retention", pruneInterval, func(ctx context.Context) error {
+		return broker.Prune(ctx, cfg.EventsRetention)
+	})
+
+	if cfg.AuditRetention > 0 {
+		go scheduler.Run(ctx, "audit log retention", pruneInterval, func(ctx context.Context) error {
+			return h.PruneAuditLog(ctx, cfg.AuditRetention)
+		})
+	}
+
+	if cfg.GRPCPort > 0 {
+		listener, listenerr := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
+		if listenerr != nil {
+			panic(listenerr)
+		}
+
+		grpcServer := grpcserver.New(h)
+
+		go func() {
+			<-ctx.Done()
+			grpcServer.GracefulStop()
+		}()
+
+		go func() {
+			if grpcerr := grpcServer.Serve(listener); grpcerr != nil {
+				fmt.Println("Failed to start gRPC server:", grpcerr)
+			}
+		}()
+	}
+
 	server := &http.Server{
 		Addr:    ":8080",
 		Handler: router,
 	}
 
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+
 	servererr := server.ListenAndServe()
-	if servererr != nil {
+	if servererr != nil && servererr != http.ErrServerClosed {
 		fmt.Println("Failed to start server:", servererr)
 	}
 }
@@ -0,0 +1,180 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+func addAbsence(t *testing.T, s *server, userId string, startsAt, endsAt time.Time) {
+	t.Helper()
+
+	body, err := json.Marshal(api.PostUsersAddAbsenceJSONBody{UserId: userId, StartsAt: startsAt.UTC(), EndsAt: endsAt.UTC()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, resp := s.do(t, http.MethodPost, "/users/addAbsence", nil, json.RawMessage(body)); status != http.StatusCreated {
+		t.Fatalf("add absence of %s: expected status 201, got %d: %s", userId, status, resp)
+	}
+}
+
+// withHistory returns the PR together with its history
+func withHistory(t *testing.T, s *server, prId string) (api.PullRequest, []api.PullRequestEvent) {
+	t.Helper()
+
+	status, body := s.do(t, http.MethodGet, "/pullRequest/get", url.Values{"pull_request_id": {prId}, "include": {"history"}}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("get %s: expected status 200, got %d: %s", prId, status, body)
+	}
+
+	var resp struct {
+		Pr      api.PullRequest        `json:"pr"`
+		History []api.PullRequestEvent `json:"history"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp.Pr, resp.History
+}
+
+// TestAwayReviewers checks that users who are away by the handler's clock are not picked on creation
+// and reassignment, while absences which have ended or not started yet don't matter. The clock is set
+// into the seeded absence of Dave, which the database's own clock wouldn't see.
+func TestAwayReviewers(t *testing.T) {
+	dbtest.Run(t, testAwayReviewers)
+}
+
+func testAwayReviewers(t *testing.T, open dbtest.Open) {
+	now := time.Date(2099, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name string
+		run  func(t *testing.T, s *server) []string
+		want []string
+	}{
+		{
+			// Bob is away and Dave of payments too, Carol is back and Eve fills the fallback slot
+			name: "create",
+			run: func(t *testing.T, s *server) []string {
+				reviewers, err := createPR(t, s, "pr-away", "u1")
+				if err != nil {
+					t.Fatal(err)
+				}
+				return reviewers
+			},
+			want: []string{"u3", "u5"},
+		},
+		{
+			// Bob is already assigned, so the replacement of Carol comes from payments, where only Eve is here
+			name: "reassign",
+			run: func(t *testing.T, s *server) []string {
+				body := json.RawMessage(`{"pull_request_id":"pr-1001","old_user_id":"u3"}`)
+				if status, resp := s.do(t, http.MethodPost, "/pullRequest/reassign", nil, body); status != http.StatusOK {
+					t.Fatalf("reassign: expected status 200, got %d: %s", status, resp)
+				}
+				pr, _ := withHistory(t, s, "pr-1001")
+				return pr.AssignedReviewers
+			},
+			want: []string{"u2", "u5"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newServer(t, open(t), handler.Options{Clock: func() time.Time { return now }})
+
+			addAbsence(t, s, "u2", now.Add(-time.Hour), now.Add(time.Hour))
+			addAbsence(t, s, "u3", now.Add(-2*time.Hour), now.Add(-time.Hour))
+			addAbsence(t, s, "u5", now.Add(time.Hour), now.Add(2*time.Hour))
+
+			got := slices.Sorted(slices.Values(tc.run(t, s)))
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("expected reviewers %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	t.Run("add away reviewer", func(t *testing.T) {
+		s := newServer(t, open(t), handler.Options{Clock: func() time.Time { return now }})
+
+		status, body := s.do(t, http.MethodPost, "/pullRequest/addReviewer", nil, json.RawMessage(`{"pull_request_id":"pr-1002","user_id":"u4"}`))
+		var resp api.ErrorResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatal(err)
+		}
+		if status != http.StatusConflict || resp.Error.Code != api.REVIEWERNOTELIGIBLE {
+			t.Errorf("expected 409 %s, got %d %s", api.REVIEWERNOTELIGIBLE, status, resp.Error.Code)
+		}
+	})
+}
+
+// TestReassignAbsentReviewers runs the job before and after Carol's absence starts. Bob's absence has ended
+// by then, so his reviews stay. Two runs at once stand for two replicas, only one of them acts on the absence.
+func TestReassignAbsentReviewers(t *testing.T) {
+	dbtest.Run(t, testReassignAbsentReviewers)
+}
+
+func testReassignAbsentReviewers(t *testing.T, open dbtest.Open) {
+	clock := newClock()
+	s := newServer(t, open(t), handler.Options{Clock: clock.Now})
+
+	addAbsence(t, s, "u2", clock.Now().Add(-2*time.Hour), clock.Now().Add(-time.Hour))
+	addAbsence(t, s, "u3", clock.Now().Add(time.Hour), clock.Now().Add(24*time.Hour))
+
+	reviewers := func(prId string) []string {
+		pr, _ := withHistory(t, s, prId)
+		return slices.Sorted(slices.Values(pr.AssignedReviewers))
+	}
+
+	if err := s.h.ReassignAbsentReviewers(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if got := reviewers("pr-1001"); !slices.Equal(got, []string{"u2", "u3"}) {
+		t.Fatalf("expected nobody reassigned before the absence, got %v", got)
+	}
+
+	clock.Advance(90 * time.Minute)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for range 2 {
+		wg.Go(func() {
+			errs <- s.h.ReassignAbsentReviewers(t.Context())
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pr, history := withHistory(t, s, "pr-1001")
+	if slices.Contains(pr.AssignedReviewers, "u3") || len(pr.AssignedReviewers) != 2 {
+		t.Errorf("expected Carol replaced on pr-1001, got %v", pr.AssignedReviewers)
+	}
+
+	replaced := 0
+	for _, e := range history {
+		if e.Type == api.PullRequestEventTypeREVIEWERREPLACED {
+			replaced++
+			if e.Actor == nil || *e.Actor != "system" || e.UserId == nil || *e.UserId != "u3" {
+				t.Errorf("unexpected replacement %+v", e)
+			}
+		}
+	}
+	if replaced != 1 {
+		t.Errorf("expected Carol replaced once, got %d replacements", replaced)
+	}
+
+	if got := reviewers("pr-1002"); !slices.Equal(got, []string{"u2"}) {
+		t.Errorf("expected Bob to keep pr-1002 after his absence, got %v", got)
+	}
+}
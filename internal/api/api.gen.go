@@ -4,22 +4,73 @@
 package api
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
+	"gopkg.in/yaml.v2"
+
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for AssignmentExplanationSelection.
+const (
+	AssignmentExplanationSelectionFair   AssignmentExplanationSelection = "fair"
+	AssignmentExplanationSelectionRandom AssignmentExplanationSelection = "random"
+)
+
+// Defines values for AuditRecordTargetType.
+const (
+	AuditRecordTargetTypeAbsence     AuditRecordTargetType = "absence"
+	AuditRecordTargetTypePullRequest AuditRecordTargetType = "pull_request"
+	AuditRecordTargetTypeTeam        AuditRecordTargetType = "team"
+	AuditRecordTargetTypeUser        AuditRecordTargetType = "user"
+)
+
+// Defines values for CandidateExplanationPool.
+const (
+	CandidateExplanationPoolFallback CandidateExplanationPool = "fallback"
+	CandidateExplanationPoolOwner    CandidateExplanationPool = "owner"
+	CandidateExplanationPoolTeam     CandidateExplanationPool = "team"
+)
+
+// Defines values for CandidateExplanationReason.
+const (
+	AlreadyAssigned CandidateExplanationReason = "already_assigned"
+	AtCapacity      CandidateExplanationReason = "at_capacity"
+	Author          CandidateExplanationReason = "author"
+	Away            CandidateExplanationReason = "away"
+	Inactive        CandidateExplanationReason = "inactive"
+)
+
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	ALREADYASSIGNED      ErrorResponseErrorCode = "ALREADY_ASSIGNED"
+	ATCAPACITY           ErrorResponseErrorCode = "AT_CAPACITY"
+	IDEMPOTENCYKEYINUSE  ErrorResponseErrorCode = "IDEMPOTENCY_KEY_IN_USE"
+	IDEMPOTENCYKEYREUSED ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	INTERNALERROR        ErrorResponseErrorCode = "INTERNAL_ERROR"
+	INVALIDREQUEST       ErrorResponseErrorCode = "INVALID_REQUEST"
+	NOCANDIDATE          ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED          ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND             ErrorResponseErrorCode = "NOT_FOUND"
+	PREXISTS             ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED             ErrorResponseErrorCode = "PR_MERGED"
+	PRVERSIONMISMATCH    ErrorResponseErrorCode = "PR_VERSION_MISMATCH"
+	REVIEWERNOTELIGIBLE  ErrorResponseErrorCode = "REVIEWER_NOT_ELIGIBLE"
+	TEAMEXISTS           ErrorResponseErrorCode = "TEAM_EXISTS"
+	TOOMANYREVIEWERS     ErrorResponseErrorCode = "TOO_MANY_REVIEWERS"
 )
 
 // Defines values for PullRequestStatus.
@@ -28,12 +79,208 @@ const (
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
+// Defines values for PullRequestEventType.
+const (
+	PullRequestEventTypeCREATED          PullRequestEventType = "CREATED"
+	PullRequestEventTypeMERGED           PullRequestEventType = "MERGED"
+	PullRequestEventTypeREVIEWED         PullRequestEventType = "REVIEWED"
+	PullRequestEventTypeREVIEWERASSIGNED PullRequestEventType = "REVIEWER_ASSIGNED"
+	PullRequestEventTypeREVIEWERREMOVED  PullRequestEventType = "REVIEWER_REMOVED"
+	PullRequestEventTypeREVIEWERREPLACED PullRequestEventType = "REVIEWER_REPLACED"
+)
+
 // Defines values for PullRequestShortStatus.
 const (
 	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for ReviewSlaEscalation.
+const (
+	ReviewSlaEscalationEvent      ReviewSlaEscalation = "event"
+	ReviewSlaEscalationNotifyLead ReviewSlaEscalation = "notify_lead"
+	ReviewSlaEscalationReassign   ReviewSlaEscalation = "reassign"
+)
+
+// Defines values for RosterChangeAction.
+const (
+	CreateTeam     RosterChangeAction = "create_team"
+	CreateUser     RosterChangeAction = "create_user"
+	DeactivateUser RosterChangeAction = "deactivate_user"
+	MoveUser       RosterChangeAction = "move_user"
+	UpdateUser     RosterChangeAction = "update_user"
+)
+
+// Defines values for SimulationSelection.
+const (
+	SimulationSelectionFair   SimulationSelection = "fair"
+	SimulationSelectionRandom SimulationSelection = "random"
+)
+
+// Defines values for SimulationOverridesSelection.
+const (
+	Fair   SimulationOverridesSelection = "fair"
+	Random SimulationOverridesSelection = "random"
+)
+
+// Defines values for SlaBreachEscalation.
+const (
+	SlaBreachEscalationEvent      SlaBreachEscalation = "event"
+	SlaBreachEscalationNotifyLead SlaBreachEscalation = "notify_lead"
+	SlaBreachEscalationReassign   SlaBreachEscalation = "reassign"
+)
+
+// Defines values for GetAuditParamsTargetType.
+const (
+	GetAuditParamsTargetTypeAbsence     GetAuditParamsTargetType = "absence"
+	GetAuditParamsTargetTypePullRequest GetAuditParamsTargetType = "pull_request"
+	GetAuditParamsTargetTypeTeam        GetAuditParamsTargetType = "team"
+	GetAuditParamsTargetTypeUser        GetAuditParamsTargetType = "user"
+)
+
+// Defines values for GetPullRequestGetParamsInclude.
+const (
+	History GetPullRequestGetParamsInclude = "history"
+)
+
+// Defines values for GetPullRequestListParamsStatus.
+const (
+	GetPullRequestListParamsStatusMERGED GetPullRequestListParamsStatus = "MERGED"
+	GetPullRequestListParamsStatusOPEN   GetPullRequestListParamsStatus = "OPEN"
+)
+
+// Defines values for GetRosterExportParamsFormat.
+const (
+	Csv  GetRosterExportParamsFormat = "csv"
+	Json GetRosterExportParamsFormat = "json"
+	Yaml GetRosterExportParamsFormat = "yaml"
+)
+
+// Defines values for PostTeamSetReviewSlaJSONBodyEscalation.
+const (
+	Event      PostTeamSetReviewSlaJSONBodyEscalation = "event"
+	NotifyLead PostTeamSetReviewSlaJSONBodyEscalation = "notify_lead"
+	Reassign   PostTeamSetReviewSlaJSONBodyEscalation = "reassign"
+)
+
+// Defines values for GetUsersGetReviewParamsStatus.
+const (
+	ALL    GetUsersGetReviewParamsStatus = "ALL"
+	MERGED GetUsersGetReviewParamsStatus = "MERGED"
+	OPEN   GetUsersGetReviewParamsStatus = "OPEN"
+)
+
+// Defines values for GetUsersGetReviewParamsOrder.
+const (
+	Asc  GetUsersGetReviewParamsOrder = "asc"
+	Desc GetUsersGetReviewParamsOrder = "desc"
+)
+
+// Absence defines model for Absence.
+type Absence struct {
+	AbsenceId int64     `json:"absence_id"`
+	EndsAt    time.Time `json:"ends_at"`
+	Reason    string    `json:"reason"`
+	StartsAt  time.Time `json:"starts_at"`
+	UserId    string    `json:"user_id"`
+}
+
+// AssignmentExplanation Как выбирались ревьюверы. Кандидаты рассматриваются по пулам — владельцы файлов, команда, команды-партнёры
+// по приоритету — и внутри пула в случайном порядке с учётом score; следующий пул нужен, только если
+// не хватило предыдущих. Пользователи из непросмотренных пулов не перечисляются.
+type AssignmentExplanation struct {
+	// Candidates Сначала подходящие кандидаты по rank, затем неподходящие
+	Candidates []CandidateExplanation         `json:"candidates"`
+	Selection  AssignmentExplanationSelection `json:"selection"`
+}
+
+// AssignmentExplanationSelection defines model for AssignmentExplanation.Selection.
+type AssignmentExplanationSelection string
+
+// AuditRecord defines model for AuditRecord.
+type AuditRecord struct {
+	// Actor Значение заголовка X-Actor-Id, system для фоновых задач, null если неизвестно
+	Actor *string `json:"actor"`
+
+	// After Состояние объекта после операции (null, если объект удалён)
+	After   *map[string]interface{} `json:"after"`
+	At      time.Time               `json:"at"`
+	AuditId int64                   `json:"audit_id"`
+
+	// Before Состояние объекта до операции (null, если объект создан)
+	Before *map[string]interface{} `json:"before"`
+
+	// Operation Операция, например pullRequest.reassign
+	Operation  string                `json:"operation"`
+	TargetId   string                `json:"target_id"`
+	TargetType AuditRecordTargetType `json:"target_type"`
+}
+
+// AuditRecordTargetType defines model for AuditRecord.TargetType.
+type AuditRecordTargetType string
+
+// AuthorPairings defines model for AuthorPairings.
+type AuthorPairings struct {
+	AuthorId string `json:"author_id"`
+
+	// Reviewers Кто ревьювил PR автора, от самых частых в окне
+	Reviewers []Pairing `json:"reviewers"`
+}
+
+// CandidateExplanation defines model for CandidateExplanation.
+type CandidateExplanation struct {
+	// Eligible Мог ли пользователь быть выбран
+	Eligible bool `json:"eligible"`
+	Picked   bool `json:"picked"`
+
+	// Pool Откуда взят кандидат — владельцы файлов по CODEOWNERS, команда или команда-партнёр
+	Pool *CandidateExplanationPool `json:"pool,omitempty"`
+
+	// Rank Место в очереди кандидатов после жеребьёвки, начиная с 1, только для eligible=true
+	Rank *int `json:"rank,omitempty"`
+
+	// Reason Почему пользователь не мог быть выбран, только для eligible=false
+	Reason *CandidateExplanationReason `json:"reason,omitempty"`
+
+	// Score Вес кандидата при выборе, только для eligible=true. При REVIEWER_SELECTION=random у всех 1,
+	// при fair — 1/(1+weight) по матрице пар /stats/pairings
+	Score    *float64 `json:"score,omitempty"`
+	TeamName *string  `json:"team_name,omitempty"`
+	UserId   string   `json:"user_id"`
+}
+
+// CandidateExplanationPool Откуда взят кандидат — владельцы файлов по CODEOWNERS, команда или команда-партнёр
+type CandidateExplanationPool string
+
+// CandidateExplanationReason Почему пользователь не мог быть выбран, только для eligible=false
+type CandidateExplanationReason string
+
+// Codeowners defines model for Codeowners.
+type Codeowners struct {
+	Rules    []OwnershipRule `json:"rules"`
+	TeamName string          `json:"team_name"`
+}
+
+// DigestSettings Настройки ежедневного дайджеста открытых ревью пользователя. Дайджест отправляется раз в день, когда
+// в часовом поясе пользователя наступает time, если у активного пользователя есть открытые ревью.
+type DigestSettings struct {
+	// Email Адрес для отправки по SMTP, null — письма не отправляются
+	Email *string `json:"email"`
+
+	// Enabled false — пользователь отписался от дайджеста
+	Enabled bool `json:"enabled"`
+
+	// LastSentOn Дата (в часовом поясе пользователя) последнего отправленного дайджеста, ГГГГ-ММ-ДД
+	LastSentOn *string `json:"last_sent_on"`
+
+	// Time Местное время отправки, ЧЧ:ММ
+	Time string `json:"time"`
+
+	// Timezone Часовой пояс IANA, например Europe/Moscow
+	Timezone string `json:"timezone"`
+	UserId   string `json:"user_id"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
@@ -45,12 +292,38 @@ type ErrorResponse struct {
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// OwnershipRule defines model for OwnershipRule.
+type OwnershipRule struct {
+	// Owners Владельцы без "@" — user_id/username пользователя или org/team_name команды
+	Owners []string `json:"owners"`
+
+	// Pattern Шаблон пути в синтаксисе CODEOWNERS
+	Pattern string `json:"pattern"`
+}
+
+// Pairing defines model for Pairing.
+type Pairing struct {
+	// LastReviewedAt Когда создан последний такой PR
+	LastReviewedAt time.Time `json:"last_reviewed_at"`
+	ReviewerId     string    `json:"reviewer_id"`
+
+	// Reviews PR автора, где пользователь назначен ревьювером, за всё время
+	Reviews int `json:"reviews"`
+
+	// Weight Вес пары в окне FAIRNESS_WINDOW: каждое ревью даёт от 1 (только что) до 0 (на границе окна).
+	// При REVIEWER_SELECTION=fair кандидат выбирается с вероятностью, пропорциональной 1/(1+weight)
+	Weight float64 `json:"weight"`
+}
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
 	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	CreatedAt         *time.Time `json:"createdAt"`
+
+	// FallbackReviewers user_id ревьюверов, назначенных из команд-партнёров (подмножество assigned_reviewers)
+	FallbackReviewers *[]string         `json:"fallback_reviewers,omitempty"`
 	MergedAt          *time.Time        `json:"mergedAt"`
 	PullRequestId     string            `json:"pull_request_id"`
 	PullRequestName   string            `json:"pull_request_name"`
@@ -60,9 +333,31 @@ type PullRequest struct {
 // PullRequestStatus defines model for PullRequest.Status.
 type PullRequestStatus string
 
+// PullRequestEvent defines model for PullRequestEvent.
+type PullRequestEvent struct {
+	// Actor Кто внёс изменение — значение заголовка X-Actor-Id (для создания PR по умолчанию автор), system для фоновых задач, null если неизвестно
+	Actor   *string   `json:"actor"`
+	At      time.Time `json:"at"`
+	EventId int64     `json:"event_id"`
+
+	// ReplacedBy Новый ревьювер для REVIEWER_REPLACED
+	ReplacedBy *string              `json:"replaced_by"`
+	Type       PullRequestEventType `json:"type"`
+
+	// UserId Ревьювер, которого касается событие
+	UserId *string `json:"user_id"`
+}
+
+// PullRequestEventType defines model for PullRequestEvent.Type.
+type PullRequestEventType string
+
 // PullRequestShort defines model for PullRequestShort.
 type PullRequestShort struct {
-	AuthorId        string                 `json:"author_id"`
+	AuthorId  string     `json:"author_id"`
+	CreatedAt *time.Time `json:"createdAt"`
+
+	// OtherReviewers Остальные назначенные ревьюверы PR
+	OtherReviewers  *[]string              `json:"other_reviewers,omitempty"`
 	PullRequestId   string                 `json:"pull_request_id"`
 	PullRequestName string                 `json:"pull_request_name"`
 	Status          PullRequestShortStatus `json:"status"`
@@ -71,10 +366,174 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// ReviewSla SLA ревью команды, задаётся через /team/setReviewSla. Отсчитывается от назначения ревьювера до его первого ревью или merge PR.
+type ReviewSla struct {
+	// Escalation Что делать при нарушении: event — событие review.sla_breached в /events/stream, notify_lead — то же
+	// событие, адресованное и лиду команды (только в /events/stream, вебхуки и почта дайджестов его не
+	// доставляют), reassign — переназначить ревьювера, как при /pullRequest/reassign
+	Escalation ReviewSlaEscalation `json:"escalation"`
+
+	// LeadUserId Лид команды, обязателен для notify_lead
+	LeadUserId *string `json:"lead_user_id"`
+
+	// Sla Сколько ревьювер может не отвечать, например 48h
+	Sla string `json:"sla"`
+}
+
+// ReviewSlaEscalation Что делать при нарушении: event — событие review.sla_breached в /events/stream, notify_lead — то же
+// событие, адресованное и лиду команды (только в /events/stream, вебхуки и почта дайджестов его не
+// доставляют), reassign — переназначить ревьювера, как при /pullRequest/reassign
+type ReviewSlaEscalation string
+
+// ReviewerStats defines model for ReviewerStats.
+type ReviewerStats struct {
+	IsActive bool `json:"is_active"`
+
+	// OpenReviews Открытые PR, где пользователь назначен ревьювером
+	OpenReviews int    `json:"open_reviews"`
+	TeamName    string `json:"team_name"`
+
+	// TotalReviews Все PR, где пользователь назначен ревьювером, включая смёрженные
+	TotalReviews int    `json:"total_reviews"`
+	UserId       string `json:"user_id"`
+	Username     string `json:"username"`
+}
+
+// Roster Состав команд. Участники команд из списка, которых в нём нет, деактивируются; остальные команды не меняются.
+type Roster struct {
+	Teams []RosterTeam `json:"teams"`
+}
+
+// RosterChange defines model for RosterChange.
+type RosterChange struct {
+	Action RosterChangeAction `json:"action"`
+	After  *RosterUserState   `json:"after,omitempty"`
+	Before *RosterUserState   `json:"before,omitempty"`
+
+	// TeamName Команда, которую создаёт или в которой окажется пользователь
+	TeamName string `json:"team_name"`
+
+	// UserId Пользователь, для create_team отсутствует
+	UserId *string `json:"user_id,omitempty"`
+}
+
+// RosterChangeAction defines model for RosterChange.Action.
+type RosterChangeAction string
+
+// RosterMember defines model for RosterMember.
+type RosterMember struct {
+	// IsActive По умолчанию true
+	IsActive *bool  `json:"is_active,omitempty"`
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// RosterTeam defines model for RosterTeam.
+type RosterTeam struct {
+	Members  []RosterMember `json:"members"`
+	TeamName string         `json:"team_name"`
+}
+
+// RosterUserState defines model for RosterUserState.
+type RosterUserState struct {
+	IsActive bool   `json:"is_active"`
+	TeamName string `json:"team_name"`
+	Username string `json:"username"`
+}
+
+// SimulatedLoad defines model for SimulatedLoad.
+type SimulatedLoad struct {
+	// Assigned Сколько смоделированных PR досталось пользователю
+	Assigned int `json:"assigned"`
+
+	// MaxOpenReviews Действующий лимит открытых ревью с учётом overrides, null — без лимита
+	MaxOpenReviews    *int   `json:"max_open_reviews"`
+	OpenReviewsAfter  int    `json:"open_reviews_after"`
+	OpenReviewsBefore int    `json:"open_reviews_before"`
+	TeamName          string `json:"team_name"`
+	UserId            string `json:"user_id"`
+}
+
+// SimulatedPullRequest defines model for SimulatedPullRequest.
+type SimulatedPullRequest struct {
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	CapacityLimited   bool     `json:"capacity_limited"`
+	FallbackReviewers []string `json:"fallback_reviewers"`
+}
+
+// Simulation defines model for Simulation.
+type Simulation struct {
+	AuthorId string `json:"author_id"`
+
+	// Load Участники команды автора и команд-партнёров, а также все, кому достались PR, от самых загруженных
+	Load []SimulatedLoad `json:"load"`
+
+	// PullRequests Смоделированные PR по порядку
+	PullRequests []SimulatedPullRequest `json:"pull_requests"`
+	Selection    SimulationSelection    `json:"selection"`
+	TeamName     string                 `json:"team_name"`
+
+	// Understaffed Сколько PR получили меньше 2 ревьюверов
+	Understaffed int `json:"understaffed"`
+}
+
+// SimulationSelection defines model for Simulation.Selection.
+type SimulationSelection string
+
+// SimulationOverrides Настройки, которые меняются только на время моделирования
+type SimulationOverrides struct {
+	// DefaultMaxOpenReviews Лимит открытых ревью для команды автора
+	DefaultMaxOpenReviews *int `json:"default_max_open_reviews,omitempty"`
+
+	// FairnessWindow Окно справедливого выбора, например 168h
+	FairnessWindow *string `json:"fairness_window,omitempty"`
+
+	// FallbackTeams Команды-партнёры команды автора вместо текущих
+	FallbackTeams *[]string `json:"fallback_teams,omitempty"`
+
+	// InactiveUsers Пользователи, которых считать неактивными
+	InactiveUsers *[]string                     `json:"inactive_users,omitempty"`
+	Selection     *SimulationOverridesSelection `json:"selection,omitempty"`
+}
+
+// SimulationOverridesSelection defines model for SimulationOverrides.Selection.
+type SimulationOverridesSelection string
+
+// SlaBreach defines model for SlaBreach.
+type SlaBreach struct {
+	AssignedAt    time.Time           `json:"assigned_at"`
+	BreachId      int64               `json:"breach_id"`
+	DetectedAt    time.Time           `json:"detected_at"`
+	DueAt         time.Time           `json:"due_at"`
+	Escalation    SlaBreachEscalation `json:"escalation"`
+	LeadUserId    *string             `json:"lead_user_id"`
+	PullRequestId string              `json:"pull_request_id"`
+
+	// ReplacedBy Новый ревьювер, если PR переназначен; null, если переназначать было не на кого или это не требовалось
+	ReplacedBy *string `json:"replaced_by"`
+
+	// TeamName Команда автора, чьё SLA нарушено
+	TeamName string `json:"team_name"`
+
+	// UserId Ревьювер, который не ответил вовремя
+	UserId string `json:"user_id"`
+}
+
+// SlaBreachEscalation defines model for SlaBreach.Escalation.
+type SlaBreachEscalation string
+
 // Team defines model for Team.
 type Team struct {
-	Members  []TeamMember `json:"members"`
-	TeamName string       `json:"team_name"`
+	// DefaultMaxOpenReviews Максимум одновременно открытых ревью на участника по умолчанию (null — без ограничений)
+	DefaultMaxOpenReviews *int `json:"default_max_open_reviews"`
+
+	// FallbackTeams Команды-партнёры (в порядке приоритета), из которых берутся ревьюверы, если в команде не хватает кандидатов
+	FallbackTeams *[]string    `json:"fallback_teams,omitempty"`
+	Members       []TeamMember `json:"members"`
+
+	// ReviewSla SLA ревью команды, задаётся через /team/setReviewSla. Отсчитывается от назначения ревьювера до его первого ревью или merge PR.
+	ReviewSla *ReviewSla `json:"review_sla,omitempty"`
+	TeamName  string     `json:"team_name"`
 }
 
 // TeamMember defines model for TeamMember.
@@ -86,34 +545,242 @@ type TeamMember struct {
 
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
-	TeamName string `json:"team_name"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+
+	// MaxOpenReviews Действующий лимит открытых ревью (личный или командный по умолчанию, null — без ограничений)
+	MaxOpenReviews *int `json:"max_open_reviews"`
+
+	// OpenReviews Количество открытых PR, где пользователь назначен ревьювером
+	OpenReviews *int   `json:"open_reviews,omitempty"`
+	TeamName    string `json:"team_name"`
+	UserId      string `json:"user_id"`
+	Username    string `json:"username"`
 }
 
+// Explain defines model for Explain.
+type Explain = bool
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// Error defines model for Error.
+type Error = ErrorResponse
+
+// VersionMismatch defines model for VersionMismatch.
+type VersionMismatch = ErrorResponse
+
+// GetAuditParams defines parameters for GetAudit.
+type GetAuditParams struct {
+	Actor      *string                   `form:"actor,omitempty" json:"actor,omitempty"`
+	Operation  *string                   `form:"operation,omitempty" json:"operation,omitempty"`
+	TargetType *GetAuditParamsTargetType `form:"target_type,omitempty" json:"target_type,omitempty"`
+	TargetId   *string                   `form:"target_id,omitempty" json:"target_id,omitempty"`
+
+	// From Не раньше (включительно)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Раньше (не включительно)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit Размер страницы
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor из предыдущего ответа
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetAuditParamsTargetType defines parameters for GetAudit.
+type GetAuditParamsTargetType string
+
+// GetEventsStreamParams defines parameters for GetEventsStream.
+type GetEventsStreamParams struct {
+	// UserId Только события, касающиеся пользователя
+	UserId *string `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// TeamName Только события, касающиеся команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// LastEventID id последнего полученного события, поток продолжится со следующего
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// PostPullRequestAddReviewerJSONBody defines parameters for PostPullRequestAddReviewer.
+type PostPullRequestAddReviewerJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+	UserId        string `json:"user_id"`
+}
+
+// PostPullRequestAddReviewerParams defines parameters for PostPullRequestAddReviewer.
+type PostPullRequestAddReviewerParams struct {
+	// IfMatch ETag PR, полученный ранее; если PR с тех пор изменился, запрос отклоняется с 412
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
-	PullRequestId   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
+	AuthorId string `json:"author_id"`
+
+	// ChangedFiles Изменённые файлы; владельцы по CODEOWNERS команды автора назначаются в первую очередь
+	ChangedFiles    *[]string `json:"changed_files,omitempty"`
+	PullRequestId   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+}
+
+// PostPullRequestCreateParams defines parameters for PostPullRequestCreate.
+type PostPullRequestCreateParams struct {
+	// Explain Вернуть объяснение выбора ревьюверов (explanation)
+	Explain *Explain `form:"explain,omitempty" json:"explain,omitempty"`
+}
+
+// GetPullRequestGetParams defines parameters for GetPullRequestGet.
+type GetPullRequestGetParams struct {
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+
+	// Include history — добавить в ответ историю статусов и назначений ревьюверов
+	Include *GetPullRequestGetParamsInclude `form:"include,omitempty" json:"include,omitempty"`
+}
+
+// GetPullRequestGetParamsInclude defines parameters for GetPullRequestGet.
+type GetPullRequestGetParamsInclude string
+
+// GetPullRequestListParams defines parameters for GetPullRequestList.
+type GetPullRequestListParams struct {
+	Status   *GetPullRequestListParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	AuthorId *string                         `form:"author_id,omitempty" json:"author_id,omitempty"`
+
+	// ReviewerId PR'ы, где пользователь сейчас назначен ревьювером
+	ReviewerId *string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// TeamName PR'ы авторов из команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// CreatedFrom Создан не раньше (включительно)
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Создан раньше (не включительно)
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// MergedFrom Смёржен не раньше (включительно)
+	MergedFrom *time.Time `form:"merged_from,omitempty" json:"merged_from,omitempty"`
+
+	// MergedTo Смёржен раньше (не включительно)
+	MergedTo *time.Time `form:"merged_to,omitempty" json:"merged_to,omitempty"`
+
+	// Q Подстрока названия PR без учёта регистра
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Limit Размер страницы
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor из предыдущего ответа
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
+// GetPullRequestListParamsStatus defines parameters for GetPullRequestList.
+type GetPullRequestListParamsStatus string
+
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestMergeParams defines parameters for PostPullRequestMerge.
+type PostPullRequestMergeParams struct {
+	// IfMatch ETag PR, полученный ранее; если PR с тех пор изменился, запрос отклоняется с 412
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
 // PostPullRequestReassignJSONBody defines parameters for PostPullRequestReassign.
 type PostPullRequestReassignJSONBody struct {
-	OldUserId     string `json:"old_user_id"`
+	// NewUserId Конкретный новый ревьювер; если не указан, выбирается случайный кандидат. При ручном выборе отбора нет, и explanation не возвращается
+	NewUserId     *string `json:"new_user_id,omitempty"`
+	OldUserId     string  `json:"old_user_id"`
+	PullRequestId string  `json:"pull_request_id"`
+}
+
+// PostPullRequestReassignParams defines parameters for PostPullRequestReassign.
+type PostPullRequestReassignParams struct {
+	// Explain Вернуть объяснение выбора ревьюверов (explanation)
+	Explain *Explain `form:"explain,omitempty" json:"explain,omitempty"`
+
+	// IfMatch ETag PR, полученный ранее; если PR с тех пор изменился, запрос отклоняется с 412
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PostPullRequestRemoveReviewerJSONBody defines parameters for PostPullRequestRemoveReviewer.
+type PostPullRequestRemoveReviewerJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+	UserId        string `json:"user_id"`
+}
+
+// PostPullRequestRemoveReviewerParams defines parameters for PostPullRequestRemoveReviewer.
+type PostPullRequestRemoveReviewerParams struct {
+	// IfMatch ETag PR, полученный ранее; если PR с тех пор изменился, запрос отклоняется с 412
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
+
+	// UserId Ревьювер
+	UserId string `json:"user_id"`
+}
+
+// PostPullRequestSimulateJSONBody defines parameters for PostPullRequestSimulate.
+type PostPullRequestSimulateJSONBody struct {
+	AuthorId     string    `json:"author_id"`
+	ChangedFiles *[]string `json:"changed_files,omitempty"`
+
+	// Count Сколько PR смоделировать
+	Count *int `json:"count,omitempty"`
+
+	// Overrides Настройки, которые меняются только на время моделирования
+	Overrides *SimulationOverrides `json:"overrides,omitempty"`
+}
+
+// GetRosterExportParams defines parameters for GetRosterExport.
+type GetRosterExportParams struct {
+	Format *GetRosterExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetRosterExportParamsFormat defines parameters for GetRosterExport.
+type GetRosterExportParamsFormat string
+
+// PostRosterImportParams defines parameters for PostRosterImport.
+type PostRosterImportParams struct {
+	// DryRun Только показать изменения, не применяя их
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// GetStatsParams defines parameters for GetStats.
+type GetStatsParams struct {
+	// TeamName Только PR авторов из команды и ревьюверы из неё
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+}
+
+// GetStatsPairingsParams defines parameters for GetStatsPairings.
+type GetStatsPairingsParams struct {
+	TeamName string `form:"team_name" json:"team_name"`
+}
+
+// GetStatsSlaBreachesParams defines parameters for GetStatsSlaBreaches.
+type GetStatsSlaBreachesParams struct {
+	// TeamName Только нарушения SLA этой команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// UserId Только нарушения этого ревьювера
+	UserId *string `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// Since Только нарушения, обнаруженные не раньше
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+	Limit *int       `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
 // GetTeamGetParams defines parameters for GetTeamGet.
@@ -122,118 +789,6939 @@ type GetTeamGetParams struct {
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
-// GetUsersGetReviewParams defines parameters for GetUsersGetReview.
-type GetUsersGetReviewParams struct {
-	// UserId Идентификатор пользователя
-	UserId UserIdQuery `form:"user_id" json:"user_id"`
+// GetTeamGetCodeownersParams defines parameters for GetTeamGetCodeowners.
+type GetTeamGetCodeownersParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
-// PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
-type PostUsersSetIsActiveJSONBody struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
+// PostTeamSetCodeownersJSONBody defines parameters for PostTeamSetCodeowners.
+type PostTeamSetCodeownersJSONBody struct {
+	// Codeowners Содержимое файла CODEOWNERS
+	Codeowners string `json:"codeowners"`
+	TeamName   string `json:"team_name"`
 }
 
-// PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
-type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
+// PostTeamSetDefaultCapacityJSONBody defines parameters for PostTeamSetDefaultCapacity.
+type PostTeamSetDefaultCapacityJSONBody struct {
+	DefaultMaxOpenReviews *int   `json:"default_max_open_reviews"`
+	TeamName              string `json:"team_name"`
+}
 
-// PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
-type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
+// PostTeamSetFallbacksJSONBody defines parameters for PostTeamSetFallbacks.
+type PostTeamSetFallbacksJSONBody struct {
+	FallbackTeams []string `json:"fallback_teams"`
+	TeamName      string   `json:"team_name"`
+}
 
-// PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
-type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
+// PostTeamSetReviewSlaJSONBody defines parameters for PostTeamSetReviewSla.
+type PostTeamSetReviewSlaJSONBody struct {
+	Escalation *PostTeamSetReviewSlaJSONBodyEscalation `json:"escalation,omitempty"`
+	LeadUserId *string                                 `json:"lead_user_id"`
 
-// PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
-type PostTeamAddJSONRequestBody = Team
+	// ReviewSla Сколько ревьювер может не отвечать, например 48h; null отключает SLA
+	ReviewSla *string `json:"review_sla"`
+	TeamName  string  `json:"team_name"`
+}
 
-// PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
-type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+// PostTeamSetReviewSlaJSONBodyEscalation defines parameters for PostTeamSetReviewSla.
+type PostTeamSetReviewSlaJSONBodyEscalation string
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
-	// (POST /pullRequest/create)
-	PostPullRequestCreate(w http.ResponseWriter, r *http.Request)
-	// Пометить PR как MERGED (идемпотентная операция)
-	// (POST /pullRequest/merge)
-	PostPullRequestMerge(w http.ResponseWriter, r *http.Request)
-	// Переназначить конкретного ревьювера на другого из его команды
+// PostUsersAddAbsenceJSONBody defines parameters for PostUsersAddAbsence.
+type PostUsersAddAbsenceJSONBody struct {
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   *string   `json:"reason,omitempty"`
+	StartsAt time.Time `json:"starts_at"`
+	UserId   string    `json:"user_id"`
+}
+
+// GetUsersGetParams defines parameters for GetUsersGet.
+type GetUsersGetParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// GetUsersGetAbsencesParams defines parameters for GetUsersGetAbsences.
+type GetUsersGetAbsencesParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// GetUsersGetDigestParams defines parameters for GetUsersGetDigest.
+type GetUsersGetDigestParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// GetUsersGetReviewParams defines parameters for GetUsersGetReview.
+type GetUsersGetReviewParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+
+	// Status Статус PR'ов, ALL — любые
+	Status *GetUsersGetReviewParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Order Сортировка по времени создания
+	Order *GetUsersGetReviewParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Размер страницы
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor из предыдущего ответа (с теми же status и order)
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetUsersGetReviewParamsStatus defines parameters for GetUsersGetReview.
+type GetUsersGetReviewParamsStatus string
+
+// GetUsersGetReviewParamsOrder defines parameters for GetUsersGetReview.
+type GetUsersGetReviewParamsOrder string
+
+// PostUsersRemoveAbsenceJSONBody defines parameters for PostUsersRemoveAbsence.
+type PostUsersRemoveAbsenceJSONBody struct {
+	AbsenceId int64 `json:"absence_id"`
+}
+
+// PostUsersSetCapacityJSONBody defines parameters for PostUsersSetCapacity.
+type PostUsersSetCapacityJSONBody struct {
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+	UserId         string `json:"user_id"`
+}
+
+// PostUsersSetDigestJSONBody defines parameters for PostUsersSetDigest.
+type PostUsersSetDigestJSONBody struct {
+	Email   *string `json:"email,omitempty"`
+	Enabled *bool   `json:"enabled,omitempty"`
+
+	// Time Местное время отправки, ЧЧ:ММ
+	Time *string `json:"time,omitempty"`
+
+	// Timezone Часовой пояс IANA
+	Timezone *string `json:"timezone,omitempty"`
+	UserId   string  `json:"user_id"`
+}
+
+// PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
+type PostUsersSetIsActiveJSONBody struct {
+	IsActive bool   `json:"is_active"`
+	UserId   string `json:"user_id"`
+}
+
+// PostPullRequestAddReviewerJSONRequestBody defines body for PostPullRequestAddReviewer for application/json ContentType.
+type PostPullRequestAddReviewerJSONRequestBody PostPullRequestAddReviewerJSONBody
+
+// PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
+type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
+
+// PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
+type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
+
+// PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
+type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
+
+// PostPullRequestRemoveReviewerJSONRequestBody defines body for PostPullRequestRemoveReviewer for application/json ContentType.
+type PostPullRequestRemoveReviewerJSONRequestBody PostPullRequestRemoveReviewerJSONBody
+
+// PostPullRequestReviewJSONRequestBody defines body for PostPullRequestReview for application/json ContentType.
+type PostPullRequestReviewJSONRequestBody PostPullRequestReviewJSONBody
+
+// PostPullRequestSimulateJSONRequestBody defines body for PostPullRequestSimulate for application/json ContentType.
+type PostPullRequestSimulateJSONRequestBody PostPullRequestSimulateJSONBody
+
+// PostRosterImportJSONRequestBody defines body for PostRosterImport for application/json ContentType.
+type PostRosterImportJSONRequestBody = Roster
+
+// PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
+type PostTeamAddJSONRequestBody = Team
+
+// PostTeamSetCodeownersJSONRequestBody defines body for PostTeamSetCodeowners for application/json ContentType.
+type PostTeamSetCodeownersJSONRequestBody PostTeamSetCodeownersJSONBody
+
+// PostTeamSetDefaultCapacityJSONRequestBody defines body for PostTeamSetDefaultCapacity for application/json ContentType.
+type PostTeamSetDefaultCapacityJSONRequestBody PostTeamSetDefaultCapacityJSONBody
+
+// PostTeamSetFallbacksJSONRequestBody defines body for PostTeamSetFallbacks for application/json ContentType.
+type PostTeamSetFallbacksJSONRequestBody PostTeamSetFallbacksJSONBody
+
+// PostTeamSetReviewSlaJSONRequestBody defines body for PostTeamSetReviewSla for application/json ContentType.
+type PostTeamSetReviewSlaJSONRequestBody PostTeamSetReviewSlaJSONBody
+
+// PostUsersAddAbsenceJSONRequestBody defines body for PostUsersAddAbsence for application/json ContentType.
+type PostUsersAddAbsenceJSONRequestBody PostUsersAddAbsenceJSONBody
+
+// PostUsersRemoveAbsenceJSONRequestBody defines body for PostUsersRemoveAbsence for application/json ContentType.
+type PostUsersRemoveAbsenceJSONRequestBody PostUsersRemoveAbsenceJSONBody
+
+// PostUsersSetCapacityJSONRequestBody defines body for PostUsersSetCapacity for application/json ContentType.
+type PostUsersSetCapacityJSONRequestBody PostUsersSetCapacityJSONBody
+
+// PostUsersSetDigestJSONRequestBody defines body for PostUsersSetDigest for application/json ContentType.
+type PostUsersSetDigestJSONRequestBody PostUsersSetDigestJSONBody
+
+// PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
+type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetAudit request
+	GetAudit(ctx context.Context, params *GetAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEventsStream request
+	GetEventsStream(ctx context.Context, params *GetEventsStreamParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostPullRequestAddReviewerWithBody request with any body
+	PostPullRequestAddReviewerWithBody(ctx context.Context, params *PostPullRequestAddReviewerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostPullRequestAddReviewer(ctx context.Context, params *PostPullRequestAddReviewerParams, body PostPullRequestAddReviewerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostPullRequestCreateWithBody request with any body
+	PostPullRequestCreateWithBody(ctx context.Context, params *PostPullRequestCreateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostPullRequestCreate(ctx context.Context, params *PostPullRequestCreateParams, body PostPullRequestCreateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPullRequestGet request
+	GetPullRequestGet(ctx context.Context, params *GetPullRequestGetParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPullRequestList request
+	GetPullRequestList(ctx context.Context, params *GetPullRequestListParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostPullRequestMergeWithBody request with any body
+	PostPullRequestMergeWithBody(ctx context.Context, params *PostPullRequestMergeParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostPullRequestMerge(ctx context.Context, params *PostPullRequestMergeParams, body PostPullRequestMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostPullRequestReassignWithBody request with any body
+	PostPullRequestReassignWithBody(ctx context.Context, params *PostPullRequestReassignParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostPullRequestReassign(ctx context.Context, params *PostPullRequestReassignParams, body PostPullRequestReassignJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostPullRequestRemoveReviewerWithBody request with any body
+	PostPullRequestRemoveReviewerWithBody(ctx context.Context, params *PostPullRequestRemoveReviewerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostPullRequestRemoveReviewer(ctx context.Context, params *PostPullRequestRemoveReviewerParams, body PostPullRequestRemoveReviewerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostPullRequestReviewWithBody request with any body
+	PostPullRequestReviewWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostPullRequestReview(ctx context.Context, body PostPullRequestReviewJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostPullRequestSimulateWithBody request with any body
+	PostPullRequestSimulateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostPullRequestSimulate(ctx context.Context, body PostPullRequestSimulateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRosterExport request
+	GetRosterExport(ctx context.Context, params *GetRosterExportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostRosterImportWithBody request with any body
+	PostRosterImportWithBody(ctx context.Context, params *PostRosterImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostRosterImport(ctx context.Context, params *PostRosterImportParams, body PostRosterImportJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStats request
+	GetStats(ctx context.Context, params *GetStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStatsPairings request
+	GetStatsPairings(ctx context.Context, params *GetStatsPairingsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStatsSlaBreaches request
+	GetStatsSlaBreaches(ctx context.Context, params *GetStatsSlaBreachesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTeamAddWithBody request with any body
+	PostTeamAddWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTeamAdd(ctx context.Context, body PostTeamAddJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTeamGet request
+	GetTeamGet(ctx context.Context, params *GetTeamGetParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTeamGetCodeowners request
+	GetTeamGetCodeowners(ctx context.Context, params *GetTeamGetCodeownersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTeamSetCodeownersWithBody request with any body
+	PostTeamSetCodeownersWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTeamSetCodeowners(ctx context.Context, body PostTeamSetCodeownersJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTeamSetDefaultCapacityWithBody request with any body
+	PostTeamSetDefaultCapacityWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTeamSetDefaultCapacity(ctx context.Context, body PostTeamSetDefaultCapacityJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTeamSetFallbacksWithBody request with any body
+	PostTeamSetFallbacksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTeamSetFallbacks(ctx context.Context, body PostTeamSetFallbacksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTeamSetReviewSlaWithBody request with any body
+	PostTeamSetReviewSlaWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTeamSetReviewSla(ctx context.Context, body PostTeamSetReviewSlaJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUsersAddAbsenceWithBody request with any body
+	PostUsersAddAbsenceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUsersAddAbsence(ctx context.Context, body PostUsersAddAbsenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUsersGet request
+	GetUsersGet(ctx context.Context, params *GetUsersGetParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUsersGetAbsences request
+	GetUsersGetAbsences(ctx context.Context, params *GetUsersGetAbsencesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUsersGetDigest request
+	GetUsersGetDigest(ctx context.Context, params *GetUsersGetDigestParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUsersGetReview request
+	GetUsersGetReview(ctx context.Context, params *GetUsersGetReviewParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUsersRemoveAbsenceWithBody request with any body
+	PostUsersRemoveAbsenceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUsersRemoveAbsence(ctx context.Context, body PostUsersRemoveAbsenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUsersSetCapacityWithBody request with any body
+	PostUsersSetCapacityWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUsersSetCapacity(ctx context.Context, body PostUsersSetCapacityJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUsersSetDigestWithBody request with any body
+	PostUsersSetDigestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUsersSetDigest(ctx context.Context, body PostUsersSetDigestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUsersSetIsActiveWithBody request with any body
+	PostUsersSetIsActiveWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUsersSetIsActive(ctx context.Context, body PostUsersSetIsActiveJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAudit(ctx context.Context, params *GetAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAuditRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEventsStream(ctx context.Context, params *GetEventsStreamParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEventsStreamRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestAddReviewerWithBody(ctx context.Context, params *PostPullRequestAddReviewerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestAddReviewerRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestAddReviewer(ctx context.Context, params *PostPullRequestAddReviewerParams, body PostPullRequestAddReviewerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestAddReviewerRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestCreateWithBody(ctx context.Context, params *PostPullRequestCreateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestCreateRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestCreate(ctx context.Context, params *PostPullRequestCreateParams, body PostPullRequestCreateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestCreateRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPullRequestGet(ctx context.Context, params *GetPullRequestGetParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPullRequestGetRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPullRequestList(ctx context.Context, params *GetPullRequestListParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPullRequestListRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestMergeWithBody(ctx context.Context, params *PostPullRequestMergeParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestMergeRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestMerge(ctx context.Context, params *PostPullRequestMergeParams, body PostPullRequestMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestMergeRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestReassignWithBody(ctx context.Context, params *PostPullRequestReassignParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestReassignRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestReassign(ctx context.Context, params *PostPullRequestReassignParams, body PostPullRequestReassignJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestReassignRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestRemoveReviewerWithBody(ctx context.Context, params *PostPullRequestRemoveReviewerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestRemoveReviewerRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestRemoveReviewer(ctx context.Context, params *PostPullRequestRemoveReviewerParams, body PostPullRequestRemoveReviewerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestRemoveReviewerRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestReviewWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestReviewRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestReview(ctx context.Context, body PostPullRequestReviewJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestReviewRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestSimulateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestSimulateRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostPullRequestSimulate(ctx context.Context, body PostPullRequestSimulateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPullRequestSimulateRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRosterExport(ctx context.Context, params *GetRosterExportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRosterExportRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostRosterImportWithBody(ctx context.Context, params *PostRosterImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRosterImportRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostRosterImport(ctx context.Context, params *PostRosterImportParams, body PostRosterImportJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostRosterImportRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStats(ctx context.Context, params *GetStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStatsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStatsPairings(ctx context.Context, params *GetStatsPairingsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStatsPairingsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStatsSlaBreaches(ctx context.Context, params *GetStatsSlaBreachesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStatsSlaBreachesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTeamAddWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTeamAddRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTeamAdd(ctx context.Context, body PostTeamAddJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTeamAddRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTeamGet(ctx context.Context, params *GetTeamGetParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTeamGetRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTeamGetCodeowners(ctx context.Context, params *GetTeamGetCodeownersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTeamGetCodeownersRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTeamSetCodeownersWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTeamSetCodeownersRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTeamSetCodeowners(ctx context.Context, body PostTeamSetCodeownersJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTeamSetCodeownersRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTeamSetDefaultCapacityWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTeamSetDefaultCapacityRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTeamSetDefaultCapacity(ctx context.Context, body PostTeamSetDefaultCapacityJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTeamSetDefaultCapacityRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTeamSetFallbacksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTeamSetFallbacksRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTeamSetFallbacks(ctx context.Context, body PostTeamSetFallbacksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTeamSetFallbacksRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTeamSetReviewSlaWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTeamSetReviewSlaRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTeamSetReviewSla(ctx context.Context, body PostTeamSetReviewSlaJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTeamSetReviewSlaRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersAddAbsenceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersAddAbsenceRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersAddAbsence(ctx context.Context, body PostUsersAddAbsenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersAddAbsenceRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUsersGet(ctx context.Context, params *GetUsersGetParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUsersGetRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUsersGetAbsences(ctx context.Context, params *GetUsersGetAbsencesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUsersGetAbsencesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUsersGetDigest(ctx context.Context, params *GetUsersGetDigestParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUsersGetDigestRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUsersGetReview(ctx context.Context, params *GetUsersGetReviewParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUsersGetReviewRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersRemoveAbsenceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersRemoveAbsenceRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersRemoveAbsence(ctx context.Context, body PostUsersRemoveAbsenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersRemoveAbsenceRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersSetCapacityWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersSetCapacityRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersSetCapacity(ctx context.Context, body PostUsersSetCapacityJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersSetCapacityRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersSetDigestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersSetDigestRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersSetDigest(ctx context.Context, body PostUsersSetDigestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersSetDigestRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersSetIsActiveWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersSetIsActiveRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersSetIsActive(ctx context.Context, body PostUsersSetIsActiveJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersSetIsActiveRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAuditRequest generates requests for GetAudit
+func NewGetAuditRequest(server string, params *GetAuditParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/audit")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Actor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "actor", runtime.ParamLocationQuery, *params.Actor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Operation != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "operation", runtime.ParamLocationQuery, *params.Operation); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TargetType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "target_type", runtime.ParamLocationQuery, *params.TargetType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TargetId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "target_id", runtime.ParamLocationQuery, *params.TargetId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEventsStreamRequest generates requests for GetEventsStream
+func NewGetEventsStreamRequest(server string, params *GetEventsStreamParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events/stream")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.UserId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, *params.UserId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TeamName != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "team_name", runtime.ParamLocationQuery, *params.TeamName); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostPullRequestAddReviewerRequest calls the generic PostPullRequestAddReviewer builder with application/json body
+func NewPostPullRequestAddReviewerRequest(server string, params *PostPullRequestAddReviewerParams, body PostPullRequestAddReviewerJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostPullRequestAddReviewerRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostPullRequestAddReviewerRequestWithBody generates requests for PostPullRequestAddReviewer with any type of body
+func NewPostPullRequestAddReviewerRequestWithBody(server string, params *PostPullRequestAddReviewerParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pullRequest/addReviewer")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostPullRequestCreateRequest calls the generic PostPullRequestCreate builder with application/json body
+func NewPostPullRequestCreateRequest(server string, params *PostPullRequestCreateParams, body PostPullRequestCreateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostPullRequestCreateRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostPullRequestCreateRequestWithBody generates requests for PostPullRequestCreate with any type of body
+func NewPostPullRequestCreateRequestWithBody(server string, params *PostPullRequestCreateParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pullRequest/create")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Explain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "explain", runtime.ParamLocationQuery, *params.Explain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetPullRequestGetRequest generates requests for GetPullRequestGet
+func NewGetPullRequestGetRequest(server string, params *GetPullRequestGetParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pullRequest/get")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pull_request_id", runtime.ParamLocationQuery, params.PullRequestId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Include != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include", runtime.ParamLocationQuery, *params.Include); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPullRequestListRequest generates requests for GetPullRequestList
+func NewGetPullRequestListRequest(server string, params *GetPullRequestListParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pullRequest/list")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.AuthorId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "author_id", runtime.ParamLocationQuery, *params.AuthorId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ReviewerId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "reviewer_id", runtime.ParamLocationQuery, *params.ReviewerId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TeamName != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "team_name", runtime.ParamLocationQuery, *params.TeamName); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedFrom != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_from", runtime.ParamLocationQuery, *params.CreatedFrom); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedTo != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_to", runtime.ParamLocationQuery, *params.CreatedTo); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MergedFrom != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "merged_from", runtime.ParamLocationQuery, *params.MergedFrom); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MergedTo != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "merged_to", runtime.ParamLocationQuery, *params.MergedTo); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostPullRequestMergeRequest calls the generic PostPullRequestMerge builder with application/json body
+func NewPostPullRequestMergeRequest(server string, params *PostPullRequestMergeParams, body PostPullRequestMergeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostPullRequestMergeRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostPullRequestMergeRequestWithBody generates requests for PostPullRequestMerge with any type of body
+func NewPostPullRequestMergeRequestWithBody(server string, params *PostPullRequestMergeParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pullRequest/merge")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostPullRequestReassignRequest calls the generic PostPullRequestReassign builder with application/json body
+func NewPostPullRequestReassignRequest(server string, params *PostPullRequestReassignParams, body PostPullRequestReassignJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostPullRequestReassignRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostPullRequestReassignRequestWithBody generates requests for PostPullRequestReassign with any type of body
+func NewPostPullRequestReassignRequestWithBody(server string, params *PostPullRequestReassignParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pullRequest/reassign")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Explain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "explain", runtime.ParamLocationQuery, *params.Explain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostPullRequestRemoveReviewerRequest calls the generic PostPullRequestRemoveReviewer builder with application/json body
+func NewPostPullRequestRemoveReviewerRequest(server string, params *PostPullRequestRemoveReviewerParams, body PostPullRequestRemoveReviewerJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostPullRequestRemoveReviewerRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostPullRequestRemoveReviewerRequestWithBody generates requests for PostPullRequestRemoveReviewer with any type of body
+func NewPostPullRequestRemoveReviewerRequestWithBody(server string, params *PostPullRequestRemoveReviewerParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pullRequest/removeReviewer")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostPullRequestReviewRequest calls the generic PostPullRequestReview builder with application/json body
+func NewPostPullRequestReviewRequest(server string, body PostPullRequestReviewJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostPullRequestReviewRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostPullRequestReviewRequestWithBody generates requests for PostPullRequestReview with any type of body
+func NewPostPullRequestReviewRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pullRequest/review")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostPullRequestSimulateRequest calls the generic PostPullRequestSimulate builder with application/json body
+func NewPostPullRequestSimulateRequest(server string, body PostPullRequestSimulateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostPullRequestSimulateRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostPullRequestSimulateRequestWithBody generates requests for PostPullRequestSimulate with any type of body
+func NewPostPullRequestSimulateRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pullRequest/simulate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetRosterExportRequest generates requests for GetRosterExport
+func NewGetRosterExportRequest(server string, params *GetRosterExportParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/roster/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostRosterImportRequest calls the generic PostRosterImport builder with application/json body
+func NewPostRosterImportRequest(server string, params *PostRosterImportParams, body PostRosterImportJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostRosterImportRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostRosterImportRequestWithBody generates requests for PostRosterImport with any type of body
+func NewPostRosterImportRequestWithBody(server string, params *PostRosterImportParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/roster/import")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.DryRun != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "dry_run", runtime.ParamLocationQuery, *params.DryRun); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetStatsRequest generates requests for GetStats
+func NewGetStatsRequest(server string, params *GetStatsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stats")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.TeamName != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "team_name", runtime.ParamLocationQuery, *params.TeamName); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetStatsPairingsRequest generates requests for GetStatsPairings
+func NewGetStatsPairingsRequest(server string, params *GetStatsPairingsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stats/pairings")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "team_name", runtime.ParamLocationQuery, params.TeamName); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetStatsSlaBreachesRequest generates requests for GetStatsSlaBreaches
+func NewGetStatsSlaBreachesRequest(server string, params *GetStatsSlaBreachesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stats/slaBreaches")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.TeamName != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "team_name", runtime.ParamLocationQuery, *params.TeamName); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.UserId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, *params.UserId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostTeamAddRequest calls the generic PostTeamAdd builder with application/json body
+func NewPostTeamAddRequest(server string, body PostTeamAddJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTeamAddRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTeamAddRequestWithBody generates requests for PostTeamAdd with any type of body
+func NewPostTeamAddRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/team/add")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTeamGetRequest generates requests for GetTeamGet
+func NewGetTeamGetRequest(server string, params *GetTeamGetParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/team/get")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "team_name", runtime.ParamLocationQuery, params.TeamName); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTeamGetCodeownersRequest generates requests for GetTeamGetCodeowners
+func NewGetTeamGetCodeownersRequest(server string, params *GetTeamGetCodeownersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/team/getCodeowners")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "team_name", runtime.ParamLocationQuery, params.TeamName); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostTeamSetCodeownersRequest calls the generic PostTeamSetCodeowners builder with application/json body
+func NewPostTeamSetCodeownersRequest(server string, body PostTeamSetCodeownersJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTeamSetCodeownersRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTeamSetCodeownersRequestWithBody generates requests for PostTeamSetCodeowners with any type of body
+func NewPostTeamSetCodeownersRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/team/setCodeowners")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostTeamSetDefaultCapacityRequest calls the generic PostTeamSetDefaultCapacity builder with application/json body
+func NewPostTeamSetDefaultCapacityRequest(server string, body PostTeamSetDefaultCapacityJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTeamSetDefaultCapacityRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTeamSetDefaultCapacityRequestWithBody generates requests for PostTeamSetDefaultCapacity with any type of body
+func NewPostTeamSetDefaultCapacityRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/team/setDefaultCapacity")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostTeamSetFallbacksRequest calls the generic PostTeamSetFallbacks builder with application/json body
+func NewPostTeamSetFallbacksRequest(server string, body PostTeamSetFallbacksJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTeamSetFallbacksRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTeamSetFallbacksRequestWithBody generates requests for PostTeamSetFallbacks with any type of body
+func NewPostTeamSetFallbacksRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/team/setFallbacks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostTeamSetReviewSlaRequest calls the generic PostTeamSetReviewSla builder with application/json body
+func NewPostTeamSetReviewSlaRequest(server string, body PostTeamSetReviewSlaJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTeamSetReviewSlaRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostTeamSetReviewSlaRequestWithBody generates requests for PostTeamSetReviewSla with any type of body
+func NewPostTeamSetReviewSlaRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/team/setReviewSla")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostUsersAddAbsenceRequest calls the generic PostUsersAddAbsence builder with application/json body
+func NewPostUsersAddAbsenceRequest(server string, body PostUsersAddAbsenceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUsersAddAbsenceRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostUsersAddAbsenceRequestWithBody generates requests for PostUsersAddAbsence with any type of body
+func NewPostUsersAddAbsenceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/addAbsence")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetUsersGetRequest generates requests for GetUsersGet
+func NewGetUsersGetRequest(server string, params *GetUsersGetParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/get")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetUsersGetAbsencesRequest generates requests for GetUsersGetAbsences
+func NewGetUsersGetAbsencesRequest(server string, params *GetUsersGetAbsencesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/getAbsences")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetUsersGetDigestRequest generates requests for GetUsersGetDigest
+func NewGetUsersGetDigestRequest(server string, params *GetUsersGetDigestParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/getDigest")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetUsersGetReviewRequest generates requests for GetUsersGetReview
+func NewGetUsersGetReviewRequest(server string, params *GetUsersGetReviewParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/getReview")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user_id", runtime.ParamLocationQuery, params.UserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Order != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "order", runtime.ParamLocationQuery, *params.Order); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostUsersRemoveAbsenceRequest calls the generic PostUsersRemoveAbsence builder with application/json body
+func NewPostUsersRemoveAbsenceRequest(server string, body PostUsersRemoveAbsenceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUsersRemoveAbsenceRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostUsersRemoveAbsenceRequestWithBody generates requests for PostUsersRemoveAbsence with any type of body
+func NewPostUsersRemoveAbsenceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/removeAbsence")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostUsersSetCapacityRequest calls the generic PostUsersSetCapacity builder with application/json body
+func NewPostUsersSetCapacityRequest(server string, body PostUsersSetCapacityJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUsersSetCapacityRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostUsersSetCapacityRequestWithBody generates requests for PostUsersSetCapacity with any type of body
+func NewPostUsersSetCapacityRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/setCapacity")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostUsersSetDigestRequest calls the generic PostUsersSetDigest builder with application/json body
+func NewPostUsersSetDigestRequest(server string, body PostUsersSetDigestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUsersSetDigestRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostUsersSetDigestRequestWithBody generates requests for PostUsersSetDigest with any type of body
+func NewPostUsersSetDigestRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/setDigest")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostUsersSetIsActiveRequest calls the generic PostUsersSetIsActive builder with application/json body
+func NewPostUsersSetIsActiveRequest(server string, body PostUsersSetIsActiveJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUsersSetIsActiveRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostUsersSetIsActiveRequestWithBody generates requests for PostUsersSetIsActive with any type of body
+func NewPostUsersSetIsActiveRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/setIsActive")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAuditWithResponse request
+	GetAuditWithResponse(ctx context.Context, params *GetAuditParams, reqEditors ...RequestEditorFn) (*GetAuditResponse, error)
+
+	// GetEventsStreamWithResponse request
+	GetEventsStreamWithResponse(ctx context.Context, params *GetEventsStreamParams, reqEditors ...RequestEditorFn) (*GetEventsStreamResponse, error)
+
+	// PostPullRequestAddReviewerWithBodyWithResponse request with any body
+	PostPullRequestAddReviewerWithBodyWithResponse(ctx context.Context, params *PostPullRequestAddReviewerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestAddReviewerResponse, error)
+
+	PostPullRequestAddReviewerWithResponse(ctx context.Context, params *PostPullRequestAddReviewerParams, body PostPullRequestAddReviewerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestAddReviewerResponse, error)
+
+	// PostPullRequestCreateWithBodyWithResponse request with any body
+	PostPullRequestCreateWithBodyWithResponse(ctx context.Context, params *PostPullRequestCreateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestCreateResponse, error)
+
+	PostPullRequestCreateWithResponse(ctx context.Context, params *PostPullRequestCreateParams, body PostPullRequestCreateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestCreateResponse, error)
+
+	// GetPullRequestGetWithResponse request
+	GetPullRequestGetWithResponse(ctx context.Context, params *GetPullRequestGetParams, reqEditors ...RequestEditorFn) (*GetPullRequestGetResponse, error)
+
+	// GetPullRequestListWithResponse request
+	GetPullRequestListWithResponse(ctx context.Context, params *GetPullRequestListParams, reqEditors ...RequestEditorFn) (*GetPullRequestListResponse, error)
+
+	// PostPullRequestMergeWithBodyWithResponse request with any body
+	PostPullRequestMergeWithBodyWithResponse(ctx context.Context, params *PostPullRequestMergeParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestMergeResponse, error)
+
+	PostPullRequestMergeWithResponse(ctx context.Context, params *PostPullRequestMergeParams, body PostPullRequestMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestMergeResponse, error)
+
+	// PostPullRequestReassignWithBodyWithResponse request with any body
+	PostPullRequestReassignWithBodyWithResponse(ctx context.Context, params *PostPullRequestReassignParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestReassignResponse, error)
+
+	PostPullRequestReassignWithResponse(ctx context.Context, params *PostPullRequestReassignParams, body PostPullRequestReassignJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestReassignResponse, error)
+
+	// PostPullRequestRemoveReviewerWithBodyWithResponse request with any body
+	PostPullRequestRemoveReviewerWithBodyWithResponse(ctx context.Context, params *PostPullRequestRemoveReviewerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestRemoveReviewerResponse, error)
+
+	PostPullRequestRemoveReviewerWithResponse(ctx context.Context, params *PostPullRequestRemoveReviewerParams, body PostPullRequestRemoveReviewerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestRemoveReviewerResponse, error)
+
+	// PostPullRequestReviewWithBodyWithResponse request with any body
+	PostPullRequestReviewWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestReviewResponse, error)
+
+	PostPullRequestReviewWithResponse(ctx context.Context, body PostPullRequestReviewJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestReviewResponse, error)
+
+	// PostPullRequestSimulateWithBodyWithResponse request with any body
+	PostPullRequestSimulateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestSimulateResponse, error)
+
+	PostPullRequestSimulateWithResponse(ctx context.Context, body PostPullRequestSimulateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestSimulateResponse, error)
+
+	// GetRosterExportWithResponse request
+	GetRosterExportWithResponse(ctx context.Context, params *GetRosterExportParams, reqEditors ...RequestEditorFn) (*GetRosterExportResponse, error)
+
+	// PostRosterImportWithBodyWithResponse request with any body
+	PostRosterImportWithBodyWithResponse(ctx context.Context, params *PostRosterImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRosterImportResponse, error)
+
+	PostRosterImportWithResponse(ctx context.Context, params *PostRosterImportParams, body PostRosterImportJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRosterImportResponse, error)
+
+	// GetStatsWithResponse request
+	GetStatsWithResponse(ctx context.Context, params *GetStatsParams, reqEditors ...RequestEditorFn) (*GetStatsResponse, error)
+
+	// GetStatsPairingsWithResponse request
+	GetStatsPairingsWithResponse(ctx context.Context, params *GetStatsPairingsParams, reqEditors ...RequestEditorFn) (*GetStatsPairingsResponse, error)
+
+	// GetStatsSlaBreachesWithResponse request
+	GetStatsSlaBreachesWithResponse(ctx context.Context, params *GetStatsSlaBreachesParams, reqEditors ...RequestEditorFn) (*GetStatsSlaBreachesResponse, error)
+
+	// PostTeamAddWithBodyWithResponse request with any body
+	PostTeamAddWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTeamAddResponse, error)
+
+	PostTeamAddWithResponse(ctx context.Context, body PostTeamAddJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTeamAddResponse, error)
+
+	// GetTeamGetWithResponse request
+	GetTeamGetWithResponse(ctx context.Context, params *GetTeamGetParams, reqEditors ...RequestEditorFn) (*GetTeamGetResponse, error)
+
+	// GetTeamGetCodeownersWithResponse request
+	GetTeamGetCodeownersWithResponse(ctx context.Context, params *GetTeamGetCodeownersParams, reqEditors ...RequestEditorFn) (*GetTeamGetCodeownersResponse, error)
+
+	// PostTeamSetCodeownersWithBodyWithResponse request with any body
+	PostTeamSetCodeownersWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTeamSetCodeownersResponse, error)
+
+	PostTeamSetCodeownersWithResponse(ctx context.Context, body PostTeamSetCodeownersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTeamSetCodeownersResponse, error)
+
+	// PostTeamSetDefaultCapacityWithBodyWithResponse request with any body
+	PostTeamSetDefaultCapacityWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTeamSetDefaultCapacityResponse, error)
+
+	PostTeamSetDefaultCapacityWithResponse(ctx context.Context, body PostTeamSetDefaultCapacityJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTeamSetDefaultCapacityResponse, error)
+
+	// PostTeamSetFallbacksWithBodyWithResponse request with any body
+	PostTeamSetFallbacksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTeamSetFallbacksResponse, error)
+
+	PostTeamSetFallbacksWithResponse(ctx context.Context, body PostTeamSetFallbacksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTeamSetFallbacksResponse, error)
+
+	// PostTeamSetReviewSlaWithBodyWithResponse request with any body
+	PostTeamSetReviewSlaWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTeamSetReviewSlaResponse, error)
+
+	PostTeamSetReviewSlaWithResponse(ctx context.Context, body PostTeamSetReviewSlaJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTeamSetReviewSlaResponse, error)
+
+	// PostUsersAddAbsenceWithBodyWithResponse request with any body
+	PostUsersAddAbsenceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersAddAbsenceResponse, error)
+
+	PostUsersAddAbsenceWithResponse(ctx context.Context, body PostUsersAddAbsenceJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersAddAbsenceResponse, error)
+
+	// GetUsersGetWithResponse request
+	GetUsersGetWithResponse(ctx context.Context, params *GetUsersGetParams, reqEditors ...RequestEditorFn) (*GetUsersGetResponse, error)
+
+	// GetUsersGetAbsencesWithResponse request
+	GetUsersGetAbsencesWithResponse(ctx context.Context, params *GetUsersGetAbsencesParams, reqEditors ...RequestEditorFn) (*GetUsersGetAbsencesResponse, error)
+
+	// GetUsersGetDigestWithResponse request
+	GetUsersGetDigestWithResponse(ctx context.Context, params *GetUsersGetDigestParams, reqEditors ...RequestEditorFn) (*GetUsersGetDigestResponse, error)
+
+	// GetUsersGetReviewWithResponse request
+	GetUsersGetReviewWithResponse(ctx context.Context, params *GetUsersGetReviewParams, reqEditors ...RequestEditorFn) (*GetUsersGetReviewResponse, error)
+
+	// PostUsersRemoveAbsenceWithBodyWithResponse request with any body
+	PostUsersRemoveAbsenceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersRemoveAbsenceResponse, error)
+
+	PostUsersRemoveAbsenceWithResponse(ctx context.Context, body PostUsersRemoveAbsenceJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersRemoveAbsenceResponse, error)
+
+	// PostUsersSetCapacityWithBodyWithResponse request with any body
+	PostUsersSetCapacityWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersSetCapacityResponse, error)
+
+	PostUsersSetCapacityWithResponse(ctx context.Context, body PostUsersSetCapacityJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersSetCapacityResponse, error)
+
+	// PostUsersSetDigestWithBodyWithResponse request with any body
+	PostUsersSetDigestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersSetDigestResponse, error)
+
+	PostUsersSetDigestWithResponse(ctx context.Context, body PostUsersSetDigestJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersSetDigestResponse, error)
+
+	// PostUsersSetIsActiveWithBodyWithResponse request with any body
+	PostUsersSetIsActiveWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersSetIsActiveResponse, error)
+
+	PostUsersSetIsActiveWithResponse(ctx context.Context, body PostUsersSetIsActiveJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersSetIsActiveResponse, error)
+}
+
+type GetAuditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// NextCursor Курсор следующей страницы (null — страниц больше нет)
+		NextCursor *string       `json:"next_cursor"`
+		Records    []AuditRecord `json:"records"`
+	}
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAuditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAuditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEventsStreamResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEventsStreamResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEventsStreamResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostPullRequestAddReviewerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Pr PullRequest `json:"pr"`
+	}
+	JSON404     *ErrorResponse
+	JSON409     *ErrorResponse
+	JSON412     *VersionMismatch
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostPullRequestAddReviewerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostPullRequestAddReviewerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostPullRequestCreateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		// CapacityLimited Назначено меньше ревьюверов, чем могло бы, потому что часть кандидатов достигла лимита открытых ревью
+		CapacityLimited bool `json:"capacity_limited"`
+
+		// Explanation Как выбирались ревьюверы. Кандидаты рассматриваются по пулам — владельцы файлов, команда, команды-партнёры
+		// по приоритету — и внутри пула в случайном порядке с учётом score; следующий пул нужен, только если
+		// не хватило предыдущих. Пользователи из непросмотренных пулов не перечисляются.
+		Explanation *AssignmentExplanation `json:"explanation,omitempty"`
+		Pr          PullRequest            `json:"pr"`
+	}
+	JSON404     *ErrorResponse
+	JSON409     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostPullRequestCreateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostPullRequestCreateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPullRequestGetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// History События в хронологическом порядке, только при include=history
+		History *[]PullRequestEvent `json:"history,omitempty"`
+		Pr      PullRequest         `json:"pr"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPullRequestGetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPullRequestGetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPullRequestListResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// NextCursor Курсор следующей страницы (null — страниц больше нет)
+		NextCursor   *string       `json:"next_cursor"`
+		PullRequests []PullRequest `json:"pull_requests"`
+	}
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPullRequestListResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPullRequestListResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostPullRequestMergeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Pr PullRequest `json:"pr"`
+	}
+	JSON404     *ErrorResponse
+	JSON412     *VersionMismatch
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostPullRequestMergeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostPullRequestMergeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostPullRequestReassignResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Explanation Как выбирались ревьюверы. Кандидаты рассматриваются по пулам — владельцы файлов, команда, команды-партнёры
+		// по приоритету — и внутри пула в случайном порядке с учётом score; следующий пул нужен, только если
+		// не хватило предыдущих. Пользователи из непросмотренных пулов не перечисляются.
+		Explanation *AssignmentExplanation `json:"explanation,omitempty"`
+		Pr          PullRequest            `json:"pr"`
+
+		// ReplacedBy user_id нового ревьювера
+		ReplacedBy string `json:"replaced_by"`
+	}
+	JSON404     *ErrorResponse
+	JSON409     *ErrorResponse
+	JSON412     *VersionMismatch
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostPullRequestReassignResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostPullRequestReassignResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostPullRequestRemoveReviewerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Pr PullRequest `json:"pr"`
+	}
+	JSON404     *ErrorResponse
+	JSON409     *ErrorResponse
+	JSON412     *VersionMismatch
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostPullRequestRemoveReviewerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostPullRequestRemoveReviewerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostPullRequestReviewResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Pr PullRequest `json:"pr"`
+	}
+	JSON404     *ErrorResponse
+	JSON409     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostPullRequestReviewResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostPullRequestReviewResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostPullRequestSimulateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Simulation
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostPullRequestSimulateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostPullRequestSimulateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRosterExportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Roster
+	YAML200      *Roster
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRosterExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRosterExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostRosterImportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Changes []RosterChange `json:"changes"`
+		DryRun  bool           `json:"dry_run"`
+	}
+	JSON400     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostRosterImportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostRosterImportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		PullRequests struct {
+			Merged int `json:"merged"`
+			Open   int `json:"open"`
+		} `json:"pull_requests"`
+
+		// Reviewers От самых загруженных к наименее загруженным
+		Reviewers []ReviewerStats `json:"reviewers"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStatsPairingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Authors Все участники команды
+		Authors  []AuthorPairings `json:"authors"`
+		TeamName string           `json:"team_name"`
+
+		// WindowStart Более ранние ревью не учитываются в весе
+		WindowStart time.Time `json:"window_start"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStatsPairingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStatsPairingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStatsSlaBreachesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Breaches []SlaBreach `json:"breaches"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStatsSlaBreachesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStatsSlaBreachesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTeamAddResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		Team Team `json:"team"`
+	}
+	JSON400     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTeamAddResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTeamAddResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTeamGetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Team
+	JSON404      *ErrorResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTeamGetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTeamGetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTeamGetCodeownersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Codeowners
+	JSON404      *ErrorResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTeamGetCodeownersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTeamGetCodeownersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTeamSetCodeownersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Codeowners
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTeamSetCodeownersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTeamSetCodeownersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTeamSetDefaultCapacityResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Team Team `json:"team"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTeamSetDefaultCapacityResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTeamSetDefaultCapacityResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTeamSetFallbacksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Team Team `json:"team"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTeamSetFallbacksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTeamSetFallbacksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTeamSetReviewSlaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Team Team `json:"team"`
+	}
+	JSON400     *ErrorResponse
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTeamSetReviewSlaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTeamSetReviewSlaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUsersAddAbsenceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		Absence Absence `json:"absence"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUsersAddAbsenceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUsersAddAbsenceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsersGetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		User User `json:"user"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsersGetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsersGetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsersGetAbsencesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Absences []Absence `json:"absences"`
+		UserId   string    `json:"user_id"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsersGetAbsencesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsersGetAbsencesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsersGetDigestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Digest Настройки ежедневного дайджеста открытых ревью пользователя. Дайджест отправляется раз в день, когда
+		// в часовом поясе пользователя наступает time, если у активного пользователя есть открытые ревью.
+		Digest DigestSettings `json:"digest"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsersGetDigestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsersGetDigestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsersGetReviewResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// NextCursor Курсор следующей страницы (null — страниц больше нет)
+		NextCursor   *string            `json:"next_cursor"`
+		PullRequests []PullRequestShort `json:"pull_requests"`
+
+		// Total Сколько всего PR'ов подходит под фильтр
+		Total  int    `json:"total"`
+		UserId string `json:"user_id"`
+	}
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsersGetReviewResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsersGetReviewResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUsersRemoveAbsenceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Absence Absence `json:"absence"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUsersRemoveAbsenceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUsersRemoveAbsenceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUsersSetCapacityResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		User User `json:"user"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUsersSetCapacityResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUsersSetCapacityResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUsersSetDigestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Digest Настройки ежедневного дайджеста открытых ревью пользователя. Дайджест отправляется раз в день, когда
+		// в часовом поясе пользователя наступает time, если у активного пользователя есть открытые ревью.
+		Digest DigestSettings `json:"digest"`
+	}
+	JSON400     *ErrorResponse
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUsersSetDigestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUsersSetDigestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUsersSetIsActiveResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		User User `json:"user"`
+	}
+	JSON404     *ErrorResponse
+	JSONDefault *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUsersSetIsActiveResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUsersSetIsActiveResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAuditWithResponse request returning *GetAuditResponse
+func (c *ClientWithResponses) GetAuditWithResponse(ctx context.Context, params *GetAuditParams, reqEditors ...RequestEditorFn) (*GetAuditResponse, error) {
+	rsp, err := c.GetAudit(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAuditResponse(rsp)
+}
+
+// GetEventsStreamWithResponse request returning *GetEventsStreamResponse
+func (c *ClientWithResponses) GetEventsStreamWithResponse(ctx context.Context, params *GetEventsStreamParams, reqEditors ...RequestEditorFn) (*GetEventsStreamResponse, error) {
+	rsp, err := c.GetEventsStream(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEventsStreamResponse(rsp)
+}
+
+// PostPullRequestAddReviewerWithBodyWithResponse request with arbitrary body returning *PostPullRequestAddReviewerResponse
+func (c *ClientWithResponses) PostPullRequestAddReviewerWithBodyWithResponse(ctx context.Context, params *PostPullRequestAddReviewerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestAddReviewerResponse, error) {
+	rsp, err := c.PostPullRequestAddReviewerWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestAddReviewerResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostPullRequestAddReviewerWithResponse(ctx context.Context, params *PostPullRequestAddReviewerParams, body PostPullRequestAddReviewerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestAddReviewerResponse, error) {
+	rsp, err := c.PostPullRequestAddReviewer(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestAddReviewerResponse(rsp)
+}
+
+// PostPullRequestCreateWithBodyWithResponse request with arbitrary body returning *PostPullRequestCreateResponse
+func (c *ClientWithResponses) PostPullRequestCreateWithBodyWithResponse(ctx context.Context, params *PostPullRequestCreateParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestCreateResponse, error) {
+	rsp, err := c.PostPullRequestCreateWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestCreateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostPullRequestCreateWithResponse(ctx context.Context, params *PostPullRequestCreateParams, body PostPullRequestCreateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestCreateResponse, error) {
+	rsp, err := c.PostPullRequestCreate(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestCreateResponse(rsp)
+}
+
+// GetPullRequestGetWithResponse request returning *GetPullRequestGetResponse
+func (c *ClientWithResponses) GetPullRequestGetWithResponse(ctx context.Context, params *GetPullRequestGetParams, reqEditors ...RequestEditorFn) (*GetPullRequestGetResponse, error) {
+	rsp, err := c.GetPullRequestGet(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPullRequestGetResponse(rsp)
+}
+
+// GetPullRequestListWithResponse request returning *GetPullRequestListResponse
+func (c *ClientWithResponses) GetPullRequestListWithResponse(ctx context.Context, params *GetPullRequestListParams, reqEditors ...RequestEditorFn) (*GetPullRequestListResponse, error) {
+	rsp, err := c.GetPullRequestList(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPullRequestListResponse(rsp)
+}
+
+// PostPullRequestMergeWithBodyWithResponse request with arbitrary body returning *PostPullRequestMergeResponse
+func (c *ClientWithResponses) PostPullRequestMergeWithBodyWithResponse(ctx context.Context, params *PostPullRequestMergeParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestMergeResponse, error) {
+	rsp, err := c.PostPullRequestMergeWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestMergeResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostPullRequestMergeWithResponse(ctx context.Context, params *PostPullRequestMergeParams, body PostPullRequestMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestMergeResponse, error) {
+	rsp, err := c.PostPullRequestMerge(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestMergeResponse(rsp)
+}
+
+// PostPullRequestReassignWithBodyWithResponse request with arbitrary body returning *PostPullRequestReassignResponse
+func (c *ClientWithResponses) PostPullRequestReassignWithBodyWithResponse(ctx context.Context, params *PostPullRequestReassignParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestReassignResponse, error) {
+	rsp, err := c.PostPullRequestReassignWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestReassignResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostPullRequestReassignWithResponse(ctx context.Context, params *PostPullRequestReassignParams, body PostPullRequestReassignJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestReassignResponse, error) {
+	rsp, err := c.PostPullRequestReassign(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestReassignResponse(rsp)
+}
+
+// PostPullRequestRemoveReviewerWithBodyWithResponse request with arbitrary body returning *PostPullRequestRemoveReviewerResponse
+func (c *ClientWithResponses) PostPullRequestRemoveReviewerWithBodyWithResponse(ctx context.Context, params *PostPullRequestRemoveReviewerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestRemoveReviewerResponse, error) {
+	rsp, err := c.PostPullRequestRemoveReviewerWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestRemoveReviewerResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostPullRequestRemoveReviewerWithResponse(ctx context.Context, params *PostPullRequestRemoveReviewerParams, body PostPullRequestRemoveReviewerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestRemoveReviewerResponse, error) {
+	rsp, err := c.PostPullRequestRemoveReviewer(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestRemoveReviewerResponse(rsp)
+}
+
+// PostPullRequestReviewWithBodyWithResponse request with arbitrary body returning *PostPullRequestReviewResponse
+func (c *ClientWithResponses) PostPullRequestReviewWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestReviewResponse, error) {
+	rsp, err := c.PostPullRequestReviewWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestReviewResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostPullRequestReviewWithResponse(ctx context.Context, body PostPullRequestReviewJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestReviewResponse, error) {
+	rsp, err := c.PostPullRequestReview(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestReviewResponse(rsp)
+}
+
+// PostPullRequestSimulateWithBodyWithResponse request with arbitrary body returning *PostPullRequestSimulateResponse
+func (c *ClientWithResponses) PostPullRequestSimulateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPullRequestSimulateResponse, error) {
+	rsp, err := c.PostPullRequestSimulateWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestSimulateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostPullRequestSimulateWithResponse(ctx context.Context, body PostPullRequestSimulateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPullRequestSimulateResponse, error) {
+	rsp, err := c.PostPullRequestSimulate(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPullRequestSimulateResponse(rsp)
+}
+
+// GetRosterExportWithResponse request returning *GetRosterExportResponse
+func (c *ClientWithResponses) GetRosterExportWithResponse(ctx context.Context, params *GetRosterExportParams, reqEditors ...RequestEditorFn) (*GetRosterExportResponse, error) {
+	rsp, err := c.GetRosterExport(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRosterExportResponse(rsp)
+}
+
+// PostRosterImportWithBodyWithResponse request with arbitrary body returning *PostRosterImportResponse
+func (c *ClientWithResponses) PostRosterImportWithBodyWithResponse(ctx context.Context, params *PostRosterImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostRosterImportResponse, error) {
+	rsp, err := c.PostRosterImportWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRosterImportResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostRosterImportWithResponse(ctx context.Context, params *PostRosterImportParams, body PostRosterImportJSONRequestBody, reqEditors ...RequestEditorFn) (*PostRosterImportResponse, error) {
+	rsp, err := c.PostRosterImport(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostRosterImportResponse(rsp)
+}
+
+// GetStatsWithResponse request returning *GetStatsResponse
+func (c *ClientWithResponses) GetStatsWithResponse(ctx context.Context, params *GetStatsParams, reqEditors ...RequestEditorFn) (*GetStatsResponse, error) {
+	rsp, err := c.GetStats(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStatsResponse(rsp)
+}
+
+// GetStatsPairingsWithResponse request returning *GetStatsPairingsResponse
+func (c *ClientWithResponses) GetStatsPairingsWithResponse(ctx context.Context, params *GetStatsPairingsParams, reqEditors ...RequestEditorFn) (*GetStatsPairingsResponse, error) {
+	rsp, err := c.GetStatsPairings(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStatsPairingsResponse(rsp)
+}
+
+// GetStatsSlaBreachesWithResponse request returning *GetStatsSlaBreachesResponse
+func (c *ClientWithResponses) GetStatsSlaBreachesWithResponse(ctx context.Context, params *GetStatsSlaBreachesParams, reqEditors ...RequestEditorFn) (*GetStatsSlaBreachesResponse, error) {
+	rsp, err := c.GetStatsSlaBreaches(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStatsSlaBreachesResponse(rsp)
+}
+
+// PostTeamAddWithBodyWithResponse request with arbitrary body returning *PostTeamAddResponse
+func (c *ClientWithResponses) PostTeamAddWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTeamAddResponse, error) {
+	rsp, err := c.PostTeamAddWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTeamAddResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTeamAddWithResponse(ctx context.Context, body PostTeamAddJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTeamAddResponse, error) {
+	rsp, err := c.PostTeamAdd(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTeamAddResponse(rsp)
+}
+
+// GetTeamGetWithResponse request returning *GetTeamGetResponse
+func (c *ClientWithResponses) GetTeamGetWithResponse(ctx context.Context, params *GetTeamGetParams, reqEditors ...RequestEditorFn) (*GetTeamGetResponse, error) {
+	rsp, err := c.GetTeamGet(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTeamGetResponse(rsp)
+}
+
+// GetTeamGetCodeownersWithResponse request returning *GetTeamGetCodeownersResponse
+func (c *ClientWithResponses) GetTeamGetCodeownersWithResponse(ctx context.Context, params *GetTeamGetCodeownersParams, reqEditors ...RequestEditorFn) (*GetTeamGetCodeownersResponse, error) {
+	rsp, err := c.GetTeamGetCodeowners(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTeamGetCodeownersResponse(rsp)
+}
+
+// PostTeamSetCodeownersWithBodyWithResponse request with arbitrary body returning *PostTeamSetCodeownersResponse
+func (c *ClientWithResponses) PostTeamSetCodeownersWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTeamSetCodeownersResponse, error) {
+	rsp, err := c.PostTeamSetCodeownersWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTeamSetCodeownersResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTeamSetCodeownersWithResponse(ctx context.Context, body PostTeamSetCodeownersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTeamSetCodeownersResponse, error) {
+	rsp, err := c.PostTeamSetCodeowners(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTeamSetCodeownersResponse(rsp)
+}
+
+// PostTeamSetDefaultCapacityWithBodyWithResponse request with arbitrary body returning *PostTeamSetDefaultCapacityResponse
+func (c *ClientWithResponses) PostTeamSetDefaultCapacityWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTeamSetDefaultCapacityResponse, error) {
+	rsp, err := c.PostTeamSetDefaultCapacityWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTeamSetDefaultCapacityResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTeamSetDefaultCapacityWithResponse(ctx context.Context, body PostTeamSetDefaultCapacityJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTeamSetDefaultCapacityResponse, error) {
+	rsp, err := c.PostTeamSetDefaultCapacity(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTeamSetDefaultCapacityResponse(rsp)
+}
+
+// PostTeamSetFallbacksWithBodyWithResponse request with arbitrary body returning *PostTeamSetFallbacksResponse
+func (c *ClientWithResponses) PostTeamSetFallbacksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTeamSetFallbacksResponse, error) {
+	rsp, err := c.PostTeamSetFallbacksWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTeamSetFallbacksResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTeamSetFallbacksWithResponse(ctx context.Context, body PostTeamSetFallbacksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTeamSetFallbacksResponse, error) {
+	rsp, err := c.PostTeamSetFallbacks(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTeamSetFallbacksResponse(rsp)
+}
+
+// PostTeamSetReviewSlaWithBodyWithResponse request with arbitrary body returning *PostTeamSetReviewSlaResponse
+func (c *ClientWithResponses) PostTeamSetReviewSlaWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTeamSetReviewSlaResponse, error) {
+	rsp, err := c.PostTeamSetReviewSlaWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTeamSetReviewSlaResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTeamSetReviewSlaWithResponse(ctx context.Context, body PostTeamSetReviewSlaJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTeamSetReviewSlaResponse, error) {
+	rsp, err := c.PostTeamSetReviewSla(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTeamSetReviewSlaResponse(rsp)
+}
+
+// PostUsersAddAbsenceWithBodyWithResponse request with arbitrary body returning *PostUsersAddAbsenceResponse
+func (c *ClientWithResponses) PostUsersAddAbsenceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersAddAbsenceResponse, error) {
+	rsp, err := c.PostUsersAddAbsenceWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersAddAbsenceResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUsersAddAbsenceWithResponse(ctx context.Context, body PostUsersAddAbsenceJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersAddAbsenceResponse, error) {
+	rsp, err := c.PostUsersAddAbsence(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersAddAbsenceResponse(rsp)
+}
+
+// GetUsersGetWithResponse request returning *GetUsersGetResponse
+func (c *ClientWithResponses) GetUsersGetWithResponse(ctx context.Context, params *GetUsersGetParams, reqEditors ...RequestEditorFn) (*GetUsersGetResponse, error) {
+	rsp, err := c.GetUsersGet(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsersGetResponse(rsp)
+}
+
+// GetUsersGetAbsencesWithResponse request returning *GetUsersGetAbsencesResponse
+func (c *ClientWithResponses) GetUsersGetAbsencesWithResponse(ctx context.Context, params *GetUsersGetAbsencesParams, reqEditors ...RequestEditorFn) (*GetUsersGetAbsencesResponse, error) {
+	rsp, err := c.GetUsersGetAbsences(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsersGetAbsencesResponse(rsp)
+}
+
+// GetUsersGetDigestWithResponse request returning *GetUsersGetDigestResponse
+func (c *ClientWithResponses) GetUsersGetDigestWithResponse(ctx context.Context, params *GetUsersGetDigestParams, reqEditors ...RequestEditorFn) (*GetUsersGetDigestResponse, error) {
+	rsp, err := c.GetUsersGetDigest(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsersGetDigestResponse(rsp)
+}
+
+// GetUsersGetReviewWithResponse request returning *GetUsersGetReviewResponse
+func (c *ClientWithResponses) GetUsersGetReviewWithResponse(ctx context.Context, params *GetUsersGetReviewParams, reqEditors ...RequestEditorFn) (*GetUsersGetReviewResponse, error) {
+	rsp, err := c.GetUsersGetReview(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsersGetReviewResponse(rsp)
+}
+
+// PostUsersRemoveAbsenceWithBodyWithResponse request with arbitrary body returning *PostUsersRemoveAbsenceResponse
+func (c *ClientWithResponses) PostUsersRemoveAbsenceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersRemoveAbsenceResponse, error) {
+	rsp, err := c.PostUsersRemoveAbsenceWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersRemoveAbsenceResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUsersRemoveAbsenceWithResponse(ctx context.Context, body PostUsersRemoveAbsenceJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersRemoveAbsenceResponse, error) {
+	rsp, err := c.PostUsersRemoveAbsence(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersRemoveAbsenceResponse(rsp)
+}
+
+// PostUsersSetCapacityWithBodyWithResponse request with arbitrary body returning *PostUsersSetCapacityResponse
+func (c *ClientWithResponses) PostUsersSetCapacityWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersSetCapacityResponse, error) {
+	rsp, err := c.PostUsersSetCapacityWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersSetCapacityResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUsersSetCapacityWithResponse(ctx context.Context, body PostUsersSetCapacityJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersSetCapacityResponse, error) {
+	rsp, err := c.PostUsersSetCapacity(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersSetCapacityResponse(rsp)
+}
+
+// PostUsersSetDigestWithBodyWithResponse request with arbitrary body returning *PostUsersSetDigestResponse
+func (c *ClientWithResponses) PostUsersSetDigestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersSetDigestResponse, error) {
+	rsp, err := c.PostUsersSetDigestWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersSetDigestResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUsersSetDigestWithResponse(ctx context.Context, body PostUsersSetDigestJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersSetDigestResponse, error) {
+	rsp, err := c.PostUsersSetDigest(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersSetDigestResponse(rsp)
+}
+
+// PostUsersSetIsActiveWithBodyWithResponse request with arbitrary body returning *PostUsersSetIsActiveResponse
+func (c *ClientWithResponses) PostUsersSetIsActiveWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersSetIsActiveResponse, error) {
+	rsp, err := c.PostUsersSetIsActiveWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersSetIsActiveResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUsersSetIsActiveWithResponse(ctx context.Context, body PostUsersSetIsActiveJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersSetIsActiveResponse, error) {
+	rsp, err := c.PostUsersSetIsActive(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersSetIsActiveResponse(rsp)
+}
+
+// ParseGetAuditResponse parses an HTTP response from a GetAuditWithResponse call
+func ParseGetAuditResponse(rsp *http.Response) (*GetAuditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAuditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// NextCursor Курсор следующей страницы (null — страниц больше нет)
+			NextCursor *string       `json:"next_cursor"`
+			Records    []AuditRecord `json:"records"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEventsStreamResponse parses an HTTP response from a GetEventsStreamWithResponse call
+func ParseGetEventsStreamResponse(rsp *http.Response) (*GetEventsStreamResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEventsStreamResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostPullRequestAddReviewerResponse parses an HTTP response from a PostPullRequestAddReviewerWithResponse call
+func ParsePostPullRequestAddReviewerResponse(rsp *http.Response) (*PostPullRequestAddReviewerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostPullRequestAddReviewerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Pr PullRequest `json:"pr"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest VersionMismatch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostPullRequestCreateResponse parses an HTTP response from a PostPullRequestCreateWithResponse call
+func ParsePostPullRequestCreateResponse(rsp *http.Response) (*PostPullRequestCreateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostPullRequestCreateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			// CapacityLimited Назначено меньше ревьюверов, чем могло бы, потому что часть кандидатов достигла лимита открытых ревью
+			CapacityLimited bool `json:"capacity_limited"`
+
+			// Explanation Как выбирались ревьюверы. Кандидаты рассматриваются по пулам — владельцы файлов, команда, команды-партнёры
+			// по приоритету — и внутри пула в случайном порядке с учётом score; следующий пул нужен, только если
+			// не хватило предыдущих. Пользователи из непросмотренных пулов не перечисляются.
+			Explanation *AssignmentExplanation `json:"explanation,omitempty"`
+			Pr          PullRequest            `json:"pr"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPullRequestGetResponse parses an HTTP response from a GetPullRequestGetWithResponse call
+func ParseGetPullRequestGetResponse(rsp *http.Response) (*GetPullRequestGetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPullRequestGetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// History События в хронологическом порядке, только при include=history
+			History *[]PullRequestEvent `json:"history,omitempty"`
+			Pr      PullRequest         `json:"pr"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPullRequestListResponse parses an HTTP response from a GetPullRequestListWithResponse call
+func ParseGetPullRequestListResponse(rsp *http.Response) (*GetPullRequestListResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPullRequestListResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// NextCursor Курсор следующей страницы (null — страниц больше нет)
+			NextCursor   *string       `json:"next_cursor"`
+			PullRequests []PullRequest `json:"pull_requests"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostPullRequestMergeResponse parses an HTTP response from a PostPullRequestMergeWithResponse call
+func ParsePostPullRequestMergeResponse(rsp *http.Response) (*PostPullRequestMergeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostPullRequestMergeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Pr PullRequest `json:"pr"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest VersionMismatch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostPullRequestReassignResponse parses an HTTP response from a PostPullRequestReassignWithResponse call
+func ParsePostPullRequestReassignResponse(rsp *http.Response) (*PostPullRequestReassignResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostPullRequestReassignResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Explanation Как выбирались ревьюверы. Кандидаты рассматриваются по пулам — владельцы файлов, команда, команды-партнёры
+			// по приоритету — и внутри пула в случайном порядке с учётом score; следующий пул нужен, только если
+			// не хватило предыдущих. Пользователи из непросмотренных пулов не перечисляются.
+			Explanation *AssignmentExplanation `json:"explanation,omitempty"`
+			Pr          PullRequest            `json:"pr"`
+
+			// ReplacedBy user_id нового ревьювера
+			ReplacedBy string `json:"replaced_by"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest VersionMismatch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostPullRequestRemoveReviewerResponse parses an HTTP response from a PostPullRequestRemoveReviewerWithResponse call
+func ParsePostPullRequestRemoveReviewerResponse(rsp *http.Response) (*PostPullRequestRemoveReviewerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostPullRequestRemoveReviewerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Pr PullRequest `json:"pr"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest VersionMismatch
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostPullRequestReviewResponse parses an HTTP response from a PostPullRequestReviewWithResponse call
+func ParsePostPullRequestReviewResponse(rsp *http.Response) (*PostPullRequestReviewResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostPullRequestReviewResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Pr PullRequest `json:"pr"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostPullRequestSimulateResponse parses an HTTP response from a PostPullRequestSimulateWithResponse call
+func ParsePostPullRequestSimulateResponse(rsp *http.Response) (*PostPullRequestSimulateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostPullRequestSimulateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Simulation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRosterExportResponse parses an HTTP response from a GetRosterExportWithResponse call
+func ParseGetRosterExportResponse(rsp *http.Response) (*GetRosterExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRosterExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Roster
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "yaml") && rsp.StatusCode == 200:
+		var dest Roster
+		if err := yaml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.YAML200 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/csv) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParsePostRosterImportResponse parses an HTTP response from a PostRosterImportWithResponse call
+func ParsePostRosterImportResponse(rsp *http.Response) (*PostRosterImportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostRosterImportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Changes []RosterChange `json:"changes"`
+			DryRun  bool           `json:"dry_run"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStatsResponse parses an HTTP response from a GetStatsWithResponse call
+func ParseGetStatsResponse(rsp *http.Response) (*GetStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			PullRequests struct {
+				Merged int `json:"merged"`
+				Open   int `json:"open"`
+			} `json:"pull_requests"`
+
+			// Reviewers От самых загруженных к наименее загруженным
+			Reviewers []ReviewerStats `json:"reviewers"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStatsPairingsResponse parses an HTTP response from a GetStatsPairingsWithResponse call
+func ParseGetStatsPairingsResponse(rsp *http.Response) (*GetStatsPairingsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStatsPairingsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Authors Все участники команды
+			Authors  []AuthorPairings `json:"authors"`
+			TeamName string           `json:"team_name"`
+
+			// WindowStart Более ранние ревью не учитываются в весе
+			WindowStart time.Time `json:"window_start"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetStatsSlaBreachesResponse parses an HTTP response from a GetStatsSlaBreachesWithResponse call
+func ParseGetStatsSlaBreachesResponse(rsp *http.Response) (*GetStatsSlaBreachesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStatsSlaBreachesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Breaches []SlaBreach `json:"breaches"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostTeamAddResponse parses an HTTP response from a PostTeamAddWithResponse call
+func ParsePostTeamAddResponse(rsp *http.Response) (*PostTeamAddResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTeamAddResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			Team Team `json:"team"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTeamGetResponse parses an HTTP response from a GetTeamGetWithResponse call
+func ParseGetTeamGetResponse(rsp *http.Response) (*GetTeamGetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTeamGetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Team
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTeamGetCodeownersResponse parses an HTTP response from a GetTeamGetCodeownersWithResponse call
+func ParseGetTeamGetCodeownersResponse(rsp *http.Response) (*GetTeamGetCodeownersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTeamGetCodeownersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Codeowners
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostTeamSetCodeownersResponse parses an HTTP response from a PostTeamSetCodeownersWithResponse call
+func ParsePostTeamSetCodeownersResponse(rsp *http.Response) (*PostTeamSetCodeownersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTeamSetCodeownersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Codeowners
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostTeamSetDefaultCapacityResponse parses an HTTP response from a PostTeamSetDefaultCapacityWithResponse call
+func ParsePostTeamSetDefaultCapacityResponse(rsp *http.Response) (*PostTeamSetDefaultCapacityResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTeamSetDefaultCapacityResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Team Team `json:"team"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostTeamSetFallbacksResponse parses an HTTP response from a PostTeamSetFallbacksWithResponse call
+func ParsePostTeamSetFallbacksResponse(rsp *http.Response) (*PostTeamSetFallbacksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTeamSetFallbacksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Team Team `json:"team"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostTeamSetReviewSlaResponse parses an HTTP response from a PostTeamSetReviewSlaWithResponse call
+func ParsePostTeamSetReviewSlaResponse(rsp *http.Response) (*PostTeamSetReviewSlaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTeamSetReviewSlaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Team Team `json:"team"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUsersAddAbsenceResponse parses an HTTP response from a PostUsersAddAbsenceWithResponse call
+func ParsePostUsersAddAbsenceResponse(rsp *http.Response) (*PostUsersAddAbsenceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUsersAddAbsenceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			Absence Absence `json:"absence"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUsersGetResponse parses an HTTP response from a GetUsersGetWithResponse call
+func ParseGetUsersGetResponse(rsp *http.Response) (*GetUsersGetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUsersGetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			User User `json:"user"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUsersGetAbsencesResponse parses an HTTP response from a GetUsersGetAbsencesWithResponse call
+func ParseGetUsersGetAbsencesResponse(rsp *http.Response) (*GetUsersGetAbsencesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUsersGetAbsencesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Absences []Absence `json:"absences"`
+			UserId   string    `json:"user_id"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUsersGetDigestResponse parses an HTTP response from a GetUsersGetDigestWithResponse call
+func ParseGetUsersGetDigestResponse(rsp *http.Response) (*GetUsersGetDigestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUsersGetDigestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Digest Настройки ежедневного дайджеста открытых ревью пользователя. Дайджест отправляется раз в день, когда
+			// в часовом поясе пользователя наступает time, если у активного пользователя есть открытые ревью.
+			Digest DigestSettings `json:"digest"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUsersGetReviewResponse parses an HTTP response from a GetUsersGetReviewWithResponse call
+func ParseGetUsersGetReviewResponse(rsp *http.Response) (*GetUsersGetReviewResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUsersGetReviewResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// NextCursor Курсор следующей страницы (null — страниц больше нет)
+			NextCursor   *string            `json:"next_cursor"`
+			PullRequests []PullRequestShort `json:"pull_requests"`
+
+			// Total Сколько всего PR'ов подходит под фильтр
+			Total  int    `json:"total"`
+			UserId string `json:"user_id"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUsersRemoveAbsenceResponse parses an HTTP response from a PostUsersRemoveAbsenceWithResponse call
+func ParsePostUsersRemoveAbsenceResponse(rsp *http.Response) (*PostUsersRemoveAbsenceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUsersRemoveAbsenceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Absence Absence `json:"absence"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUsersSetCapacityResponse parses an HTTP response from a PostUsersSetCapacityWithResponse call
+func ParsePostUsersSetCapacityResponse(rsp *http.Response) (*PostUsersSetCapacityResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUsersSetCapacityResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			User User `json:"user"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUsersSetDigestResponse parses an HTTP response from a PostUsersSetDigestWithResponse call
+func ParsePostUsersSetDigestResponse(rsp *http.Response) (*PostUsersSetDigestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUsersSetDigestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Digest Настройки ежедневного дайджеста открытых ревью пользователя. Дайджест отправляется раз в день, когда
+			// в часовом поясе пользователя наступает time, если у активного пользователя есть открытые ревью.
+			Digest DigestSettings `json:"digest"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUsersSetIsActiveResponse parses an HTTP response from a PostUsersSetIsActiveWithResponse call
+func ParsePostUsersSetIsActiveResponse(rsp *http.Response) (*PostUsersSetIsActiveResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUsersSetIsActiveResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			User User `json:"user"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Журнал изменяющих операций (от новых к старым, с постраничной выдачей по курсору)
+	// (GET /audit)
+	GetAudit(w http.ResponseWriter, r *http.Request, params GetAuditParams)
+	// Поток событий назначения ревьюверов (Server-Sent Events)
+	// (GET /events/stream)
+	GetEventsStream(w http.ResponseWriter, r *http.Request, params GetEventsStreamParams)
+	// Вручную назначить ревьювера из команды автора или её команд-партнёров
+	// (POST /pullRequest/addReviewer)
+	PostPullRequestAddReviewer(w http.ResponseWriter, r *http.Request, params PostPullRequestAddReviewerParams)
+	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+	// (POST /pullRequest/create)
+	PostPullRequestCreate(w http.ResponseWriter, r *http.Request, params PostPullRequestCreateParams)
+	// Получить PR, при include=history — вместе с историей изменений
+	// (GET /pullRequest/get)
+	GetPullRequestGet(w http.ResponseWriter, r *http.Request, params GetPullRequestGetParams)
+	// Найти PR'ы по фильтрам (от новых к старым, с постраничной выдачей по курсору)
+	// (GET /pullRequest/list)
+	GetPullRequestList(w http.ResponseWriter, r *http.Request, params GetPullRequestListParams)
+	// Пометить PR как MERGED (идемпотентная операция)
+	// (POST /pullRequest/merge)
+	PostPullRequestMerge(w http.ResponseWriter, r *http.Request, params PostPullRequestMergeParams)
+	// Переназначить конкретного ревьювера на другого из его команды
 	// (POST /pullRequest/reassign)
-	PostPullRequestReassign(w http.ResponseWriter, r *http.Request)
+	PostPullRequestReassign(w http.ResponseWriter, r *http.Request, params PostPullRequestReassignParams)
+	// Снять ревьювера с PR без замены
+	// (POST /pullRequest/removeReviewer)
+	PostPullRequestRemoveReviewer(w http.ResponseWriter, r *http.Request, params PostPullRequestRemoveReviewerParams)
+	// Отметить ревью назначенного ревьювера
+	// (POST /pullRequest/review)
+	PostPullRequestReview(w http.ResponseWriter, r *http.Request)
+	// Смоделировать назначение ревьюверов, ничего не сохраняя
+	// (POST /pullRequest/simulate)
+	PostPullRequestSimulate(w http.ResponseWriter, r *http.Request)
+	// Выгрузить все команды и пользователей в формате /roster/import
+	// (GET /roster/export)
+	GetRosterExport(w http.ResponseWriter, r *http.Request, params GetRosterExportParams)
+	// Привести команды и пользователей к составу из файла
+	// (POST /roster/import)
+	PostRosterImport(w http.ResponseWriter, r *http.Request, params PostRosterImportParams)
+	// Статистика PR и назначений ревьюверов
+	// (GET /stats)
+	GetStats(w http.ResponseWriter, r *http.Request, params GetStatsParams)
+	// Матрица пар автор — ревьювер для участников команды
+	// (GET /stats/pairings)
+	GetStatsPairings(w http.ResponseWriter, r *http.Request, params GetStatsPairingsParams)
+	// Нарушения SLA ревью, от новых к старым
+	// (GET /stats/slaBreaches)
+	GetStatsSlaBreaches(w http.ResponseWriter, r *http.Request, params GetStatsSlaBreachesParams)
 	// Создать команду с участниками (создаёт/обновляет пользователей)
 	// (POST /team/add)
 	PostTeamAdd(w http.ResponseWriter, r *http.Request)
 	// Получить команду с участниками
 	// (GET /team/get)
 	GetTeamGet(w http.ResponseWriter, r *http.Request, params GetTeamGetParams)
-	// Получить PR'ы, где пользователь назначен ревьювером
+	// Получить правила владения кодом команды
+	// (GET /team/getCodeowners)
+	GetTeamGetCodeowners(w http.ResponseWriter, r *http.Request, params GetTeamGetCodeownersParams)
+	// Импортировать правила владения кодом команды в синтаксисе CODEOWNERS (заменяет текущие)
+	// (POST /team/setCodeowners)
+	PostTeamSetCodeowners(w http.ResponseWriter, r *http.Request)
+	// Установить лимит открытых ревью по умолчанию для участников команды
+	// (POST /team/setDefaultCapacity)
+	PostTeamSetDefaultCapacity(w http.ResponseWriter, r *http.Request)
+	// Задать команды-партнёры, из которых назначаются ревьюверы при нехватке кандидатов
+	// (POST /team/setFallbacks)
+	PostTeamSetFallbacks(w http.ResponseWriter, r *http.Request)
+	// Задать SLA ревью для PR авторов команды
+	// (POST /team/setReviewSla)
+	PostTeamSetReviewSla(w http.ResponseWriter, r *http.Request)
+	// Добавить период отсутствия пользователя (в это время он не назначается ревьювером)
+	// (POST /users/addAbsence)
+	PostUsersAddAbsence(w http.ResponseWriter, r *http.Request)
+	// Получить пользователя с текущей нагрузкой
+	// (GET /users/get)
+	GetUsersGet(w http.ResponseWriter, r *http.Request, params GetUsersGetParams)
+	// Получить текущие и будущие периоды отсутствия пользователя
+	// (GET /users/getAbsences)
+	GetUsersGetAbsences(w http.ResponseWriter, r *http.Request, params GetUsersGetAbsencesParams)
+	// Получить настройки ежедневного дайджеста открытых ревью пользователя
+	// (GET /users/getDigest)
+	GetUsersGetDigest(w http.ResponseWriter, r *http.Request, params GetUsersGetDigestParams)
+	// Получить PR'ы, где пользователь назначен ревьювером (с постраничной выдачей по курсору)
 	// (GET /users/getReview)
 	GetUsersGetReview(w http.ResponseWriter, r *http.Request, params GetUsersGetReviewParams)
+	// Удалить период отсутствия
+	// (POST /users/removeAbsence)
+	PostUsersRemoveAbsence(w http.ResponseWriter, r *http.Request)
+	// Установить личный лимит одновременно открытых ревью (null — использовать лимит команды)
+	// (POST /users/setCapacity)
+	PostUsersSetCapacity(w http.ResponseWriter, r *http.Request)
+	// Изменить расписание ежедневного дайджеста пользователя или отписаться от него
+	// (POST /users/setDigest)
+	PostUsersSetDigest(w http.ResponseWriter, r *http.Request)
 	// Установить флаг активности пользователя
 	// (POST /users/setIsActive)
 	PostUsersSetIsActive(w http.ResponseWriter, r *http.Request)
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Журнал изменяющих операций (от новых к старым, с постраничной выдачей по курсору)
+// (GET /audit)
+func (_ Unimplemented) GetAudit(w http.ResponseWriter, r *http.Request, params GetAuditParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Поток событий назначения ревьюверов (Server-Sent Events)
+// (GET /events/stream)
+func (_ Unimplemented) GetEventsStream(w http.ResponseWriter, r *http.Request, params GetEventsStreamParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Вручную назначить ревьювера из команды автора или её команд-партнёров
+// (POST /pullRequest/addReviewer)
+func (_ Unimplemented) PostPullRequestAddReviewer(w http.ResponseWriter, r *http.Request, params PostPullRequestAddReviewerParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+// (POST /pullRequest/create)
+func (_ Unimplemented) PostPullRequestCreate(w http.ResponseWriter, r *http.Request, params PostPullRequestCreateParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить PR, при include=history — вместе с историей изменений
+// (GET /pullRequest/get)
+func (_ Unimplemented) GetPullRequestGet(w http.ResponseWriter, r *http.Request, params GetPullRequestGetParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Найти PR'ы по фильтрам (от новых к старым, с постраничной выдачей по курсору)
+// (GET /pullRequest/list)
+func (_ Unimplemented) GetPullRequestList(w http.ResponseWriter, r *http.Request, params GetPullRequestListParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Пометить PR как MERGED (идемпотентная операция)
+// (POST /pullRequest/merge)
+func (_ Unimplemented) PostPullRequestMerge(w http.ResponseWriter, r *http.Request, params PostPullRequestMergeParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Переназначить конкретного ревьювера на другого из его команды
+// (POST /pullRequest/reassign)
+func (_ Unimplemented) PostPullRequestReassign(w http.ResponseWriter, r *http.Request, params PostPullRequestReassignParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Снять ревьювера с PR без замены
+// (POST /pullRequest/removeReviewer)
+func (_ Unimplemented) PostPullRequestRemoveReviewer(w http.ResponseWriter, r *http.Request, params PostPullRequestRemoveReviewerParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отметить ревью назначенного ревьювера
+// (POST /pullRequest/review)
+func (_ Unimplemented) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Смоделировать назначение ревьюверов, ничего не сохраняя
+// (POST /pullRequest/simulate)
+func (_ Unimplemented) PostPullRequestSimulate(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Выгрузить все команды и пользователей в формате /roster/import
+// (GET /roster/export)
+func (_ Unimplemented) GetRosterExport(w http.ResponseWriter, r *http.Request, params GetRosterExportParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Привести команды и пользователей к составу из файла
+// (POST /roster/import)
+func (_ Unimplemented) PostRosterImport(w http.ResponseWriter, r *http.Request, params PostRosterImportParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Статистика PR и назначений ревьюверов
+// (GET /stats)
+func (_ Unimplemented) GetStats(w http.ResponseWriter, r *http.Request, params GetStatsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Матрица пар автор — ревьювер для участников команды
+// (GET /stats/pairings)
+func (_ Unimplemented) GetStatsPairings(w http.ResponseWriter, r *http.Request, params GetStatsPairingsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Нарушения SLA ревью, от новых к старым
+// (GET /stats/slaBreaches)
+func (_ Unimplemented) GetStatsSlaBreaches(w http.ResponseWriter, r *http.Request, params GetStatsSlaBreachesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать команду с участниками (создаёт/обновляет пользователей)
+// (POST /team/add)
+func (_ Unimplemented) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить команду с участниками
+// (GET /team/get)
+func (_ Unimplemented) GetTeamGet(w http.ResponseWriter, r *http.Request, params GetTeamGetParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить правила владения кодом команды
+// (GET /team/getCodeowners)
+func (_ Unimplemented) GetTeamGetCodeowners(w http.ResponseWriter, r *http.Request, params GetTeamGetCodeownersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Импортировать правила владения кодом команды в синтаксисе CODEOWNERS (заменяет текущие)
+// (POST /team/setCodeowners)
+func (_ Unimplemented) PostTeamSetCodeowners(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Установить лимит открытых ревью по умолчанию для участников команды
+// (POST /team/setDefaultCapacity)
+func (_ Unimplemented) PostTeamSetDefaultCapacity(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Задать команды-партнёры, из которых назначаются ревьюверы при нехватке кандидатов
+// (POST /team/setFallbacks)
+func (_ Unimplemented) PostTeamSetFallbacks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Задать SLA ревью для PR авторов команды
+// (POST /team/setReviewSla)
+func (_ Unimplemented) PostTeamSetReviewSla(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Добавить период отсутствия пользователя (в это время он не назначается ревьювером)
+// (POST /users/addAbsence)
+func (_ Unimplemented) PostUsersAddAbsence(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить пользователя с текущей нагрузкой
+// (GET /users/get)
+func (_ Unimplemented) GetUsersGet(w http.ResponseWriter, r *http.Request, params GetUsersGetParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить текущие и будущие периоды отсутствия пользователя
+// (GET /users/getAbsences)
+func (_ Unimplemented) GetUsersGetAbsences(w http.ResponseWriter, r *http.Request, params GetUsersGetAbsencesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить настройки ежедневного дайджеста открытых ревью пользователя
+// (GET /users/getDigest)
+func (_ Unimplemented) GetUsersGetDigest(w http.ResponseWriter, r *http.Request, params GetUsersGetDigestParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить PR'ы, где пользователь назначен ревьювером (с постраничной выдачей по курсору)
+// (GET /users/getReview)
+func (_ Unimplemented) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params GetUsersGetReviewParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить период отсутствия
+// (POST /users/removeAbsence)
+func (_ Unimplemented) PostUsersRemoveAbsence(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Установить личный лимит одновременно открытых ревью (null — использовать лимит команды)
+// (POST /users/setCapacity)
+func (_ Unimplemented) PostUsersSetCapacity(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Изменить расписание ежедневного дайджеста пользователя или отписаться от него
+// (POST /users/setDigest)
+func (_ Unimplemented) PostUsersSetDigest(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Установить флаг активности пользователя
+// (POST /users/setIsActive)
+func (_ Unimplemented) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetAudit operation middleware
+func (siw *ServerInterfaceWrapper) GetAudit(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuditParams
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", r.URL.Query(), &params.Actor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "operation" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "operation", r.URL.Query(), &params.Operation)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "operation", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "target_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target_type", r.URL.Query(), &params.TargetType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target_type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "target_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target_id", r.URL.Query(), &params.TargetId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAudit(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEventsStream operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsStream(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsStreamParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEventsStream(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestAddReviewer operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestAddReviewer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestAddReviewerParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestAddReviewer(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestCreate operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestCreateParams
+
+	// ------------- Optional query parameter "explain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "explain", r.URL.Query(), &params.Explain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "explain", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestCreate(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPullRequestGet operation middleware
+func (siw *ServerInterfaceWrapper) GetPullRequestGet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestGetParams
+
+	// ------------- Required query parameter "pull_request_id" -------------
+
+	if paramValue := r.URL.Query().Get("pull_request_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pull_request_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "pull_request_id", r.URL.Query(), &params.PullRequestId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pull_request_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include", r.URL.Query(), &params.Include)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPullRequestGet(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPullRequestList operation middleware
+func (siw *ServerInterfaceWrapper) GetPullRequestList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestListParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author_id", r.URL.Query(), &params.AuthorId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "reviewer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reviewer_id", r.URL.Query(), &params.ReviewerId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewer_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merged_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_from", r.URL.Query(), &params.MergedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merged_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merged_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_to", r.URL.Query(), &params.MergedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merged_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPullRequestList(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestMerge operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestMergeParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestMerge(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestReassign operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestReassignParams
+
+	// ------------- Optional query parameter "explain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "explain", r.URL.Query(), &params.Explain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "explain", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestReassign(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestRemoveReviewer operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestRemoveReviewer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestRemoveReviewerParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestRemoveReviewer(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestReview operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestReview(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPullRequestSimulate operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestSimulate(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPullRequestSimulate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRosterExport operation middleware
+func (siw *ServerInterfaceWrapper) GetRosterExport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRosterExportParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRosterExport(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRosterImport operation middleware
+func (siw *ServerInterfaceWrapper) PostRosterImport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostRosterImportParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dry_run", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRosterImport(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStats operation middleware
+func (siw *ServerInterfaceWrapper) GetStats(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsParams
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStats(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStatsPairings operation middleware
+func (siw *ServerInterfaceWrapper) GetStatsPairings(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsPairingsParams
+
+	// ------------- Required query parameter "team_name" -------------
+
+	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStatsPairings(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStatsSlaBreaches operation middleware
+func (siw *ServerInterfaceWrapper) GetStatsSlaBreaches(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsSlaBreachesParams
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStatsSlaBreaches(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostTeamAdd operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTeamAdd(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTeamGet operation middleware
+func (siw *ServerInterfaceWrapper) GetTeamGet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamGetParams
+
+	// ------------- Required query parameter "team_name" -------------
+
+	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTeamGet(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTeamGetCodeowners operation middleware
+func (siw *ServerInterfaceWrapper) GetTeamGetCodeowners(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamGetCodeownersParams
+
+	// ------------- Required query parameter "team_name" -------------
+
+	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTeamGetCodeowners(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostTeamSetCodeowners operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamSetCodeowners(w http.ResponseWriter, r *http.Request) {
 
-type Unimplemented struct{}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTeamSetCodeowners(w, r)
+	}))
 
-// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
-// (POST /pullRequest/create)
-func (_ Unimplemented) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// Пометить PR как MERGED (идемпотентная операция)
-// (POST /pullRequest/merge)
-func (_ Unimplemented) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+	handler.ServeHTTP(w, r)
 }
 
-// Переназначить конкретного ревьювера на другого из его команды
-// (POST /pullRequest/reassign)
-func (_ Unimplemented) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// PostTeamSetDefaultCapacity operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamSetDefaultCapacity(w http.ResponseWriter, r *http.Request) {
 
-// Создать команду с участниками (создаёт/обновляет пользователей)
-// (POST /team/add)
-func (_ Unimplemented) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTeamSetDefaultCapacity(w, r)
+	}))
 
-// Получить команду с участниками
-// (GET /team/get)
-func (_ Unimplemented) GetTeamGet(w http.ResponseWriter, r *http.Request, params GetTeamGetParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// Получить PR'ы, где пользователь назначен ревьювером
-// (GET /users/getReview)
-func (_ Unimplemented) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params GetUsersGetReviewParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	handler.ServeHTTP(w, r)
 }
 
-// Установить флаг активности пользователя
-// (POST /users/setIsActive)
-func (_ Unimplemented) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// PostTeamSetFallbacks operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamSetFallbacks(w http.ResponseWriter, r *http.Request) {
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostTeamSetFallbacks(w, r)
+	}))
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// PostPullRequestCreate operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
+	handler.ServeHTTP(w, r)
+}
+
+// PostTeamSetReviewSla operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamSetReviewSla(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostPullRequestCreate(w, r)
+		siw.Handler.PostTeamSetReviewSla(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -243,11 +7731,11 @@ func (siw *ServerInterfaceWrapper) PostPullRequestCreate(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// PostPullRequestMerge operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
+// PostUsersAddAbsence operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersAddAbsence(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostPullRequestMerge(w, r)
+		siw.Handler.PostUsersAddAbsence(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -257,11 +7745,31 @@ func (siw *ServerInterfaceWrapper) PostPullRequestMerge(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// PostPullRequestReassign operation middleware
-func (siw *ServerInterfaceWrapper) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
+// GetUsersGet operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersGet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersGetParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostPullRequestReassign(w, r)
+		siw.Handler.GetUsersGet(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -271,11 +7779,31 @@ func (siw *ServerInterfaceWrapper) PostPullRequestReassign(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-// PostTeamAdd operation middleware
-func (siw *ServerInterfaceWrapper) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
+// GetUsersGetAbsences operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersGetAbsences(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersGetAbsencesParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostTeamAdd(w, r)
+		siw.Handler.GetUsersGetAbsences(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -285,31 +7813,31 @@ func (siw *ServerInterfaceWrapper) PostTeamAdd(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetTeamGet operation middleware
-func (siw *ServerInterfaceWrapper) GetTeamGet(w http.ResponseWriter, r *http.Request) {
+// GetUsersGetDigest operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersGetDigest(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetTeamGetParams
+	var params GetUsersGetDigestParams
 
-	// ------------- Required query parameter "team_name" -------------
+	// ------------- Required query parameter "user_id" -------------
 
-	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTeamGet(w, r, params)
+		siw.Handler.GetUsersGetDigest(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -342,6 +7870,38 @@ func (siw *ServerInterfaceWrapper) GetUsersGetReview(w http.ResponseWriter, r *h
 		return
 	}
 
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetUsersGetReview(w, r, params)
 	}))
@@ -353,6 +7913,48 @@ func (siw *ServerInterfaceWrapper) GetUsersGetReview(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
+// PostUsersRemoveAbsence operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersRemoveAbsence(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersRemoveAbsence(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostUsersSetCapacity operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersSetCapacity(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersSetCapacity(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostUsersSetDigest operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersSetDigest(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUsersSetDigest(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // PostUsersSetIsActive operation middleware
 func (siw *ServerInterfaceWrapper) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
 
@@ -480,27 +8082,411 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/audit", wrapper.GetAudit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/events/stream", wrapper.GetEventsStream)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/addReviewer", wrapper.PostPullRequestAddReviewer)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/pullRequest/create", wrapper.PostPullRequestCreate)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pullRequest/get", wrapper.GetPullRequestGet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pullRequest/list", wrapper.GetPullRequestList)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/pullRequest/merge", wrapper.PostPullRequestMerge)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/pullRequest/reassign", wrapper.PostPullRequestReassign)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/removeReviewer", wrapper.PostPullRequestRemoveReviewer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/review", wrapper.PostPullRequestReview)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/simulate", wrapper.PostPullRequestSimulate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/roster/export", wrapper.GetRosterExport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/roster/import", wrapper.PostRosterImport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/stats", wrapper.GetStats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/stats/pairings", wrapper.GetStatsPairings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/stats/slaBreaches", wrapper.GetStatsSlaBreaches)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/team/add", wrapper.PostTeamAdd)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/team/get", wrapper.GetTeamGet)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/team/getCodeowners", wrapper.GetTeamGetCodeowners)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/team/setCodeowners", wrapper.PostTeamSetCodeowners)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/team/setDefaultCapacity", wrapper.PostTeamSetDefaultCapacity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/team/setFallbacks", wrapper.PostTeamSetFallbacks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/team/setReviewSla", wrapper.PostTeamSetReviewSla)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/addAbsence", wrapper.PostUsersAddAbsence)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/get", wrapper.GetUsersGet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/getAbsences", wrapper.GetUsersGetAbsences)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/getDigest", wrapper.GetUsersGetDigest)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/getReview", wrapper.GetUsersGetReview)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/removeAbsence", wrapper.PostUsersRemoveAbsence)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/setCapacity", wrapper.PostUsersSetCapacity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/setDigest", wrapper.PostUsersSetDigest)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/users/setIsActive", wrapper.PostUsersSetIsActive)
 	})
 
 	return r
 }
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+y9e3Mbx7Ug/lWm5verirg7IsGH7Gu6UhVYohzmihQN0nZyTRVqBAxFxMAMMzOQxKti",
+	"lSjGURxpxcjru0k5fm7+8P4JUYQE8QF9hZ6vsJ9k65zunume6XngQcl2XLduLAKD6dOnT5/3445ec1pb",
+	"jm3ZvqfP39E3LbNuufjPhTXzBvy3bnk1t7HlNxxbn9fJZ6Qb3A12SS/Y11Yqhka6wWONnJA+eUZOSV8j",
+	"L+EB0iWHpBPcCx5q5EBb3Di/ZPq1TS3YJcfwVbAXPAo+JV3yFH7RI8/JCemSU/z/XrCvG7p122xtNS19",
+	"Xl/XZ9d13dC92qbVMgEif3sLvvB8t2Hf0Hd2dgx9y3TNluVz0G9vNc2GnQY9OQ32KGh98iT4S7Af7PK1",
+	"SVcjB8ED8oT0g7uko+FODoKHwSNygD/tkwPtnAXvt0146YQIq++2LUOHhfU/tC13Wzd022wBrBaDSNxG",
+	"3dow201fn98wm55l8G1dd5ymZdo6bGtxA/GW3AgcDkX/S9Inx8FecB83cBo8IC8A6g7uqPs2nA8gvaet",
+	"VLRgVwvukW7wCf4suCuivkeOg91g39DIc9IhL2Grwa5G+sE9ckSOSZ+cBvukG9yDh+BFc9MzymPC3VMy",
+	"irbPCSDzGA19zTJby2bLeg+Rlzy9fyKcR6RDjoOHQG1wXD1yEuxr5Ij0yQlu+zB4IEF23ax9bNl1XX0y",
+	"vmW2qvhvQ3etP7QbrlXnJ5kF6/ue5S7W0yD9OzkErAb3SC/4I4U5uEdRTg/sIXkOtIQfd8lxjObbMynQ",
+	"tj3LrTbqA8G6Aw97W47tWfR2uK7jwj9qju1btg//NLe2mo0aUvTU7z0Hr04Izh3din5Sx/Nc/qB8ZfFS",
+	"tbLw3vsLq2u6obcszzNvwHdb7WazCtBZnl9t1LWGp4Ww7uyIgP7/rrWhz+v/31TEg6bot94UAllhYNNN",
+	"xFD8FenCoQd38Y4eBfcY8Uv0ey4G6QTQC9wGcsC4wF12b/aBiPrBn0mPPIHjgp+uLVSWy1eqC5XK1cqE",
+	"vmPoH1iu13DspYbX4vdyeCSuVKofLFRWF68uV5cWV5fKaxd/LSFypaJtmp5W2zTtG1bd0Gpt17VsX7tJ",
+	"gQDErutz6/o4sbpSUfAEyi2Qdcf5DUgBcsBFAuC1R56H/F6ADCmvfN2z7BoiY8t1tizXb1CSNOkXQNnz",
+	"d/QNx22Zvj6vN2z/jTk95IwN27duWC4chGXXvarpS0/XTd867zdaVvQLfgfgBpjsRBJfeb7p+oO9jV9D",
+	"JWeIbuZH4sYM4fJGS0ZbCWG8Fi7oXP+9VfNhwbLnNW7YLcv2FyLZo+A7X5AOOeIirIdy4Jj0gt3gYUKU",
+	"BQ8mNXz+lBySHhPXD1B2BLvBLjBTvB495FKPGOeH89fIy2CPHJMOOdH+793PgQDgj0NkZA+DP8Fb/kg6",
+	"5AXKjQNDYs6kY8SY9XnyknSCu3iBHwNc6zZfBVYHQdxDsXUv2KPrSde3F4IDqkawy6gTlgcJccJkXbBP",
+	"DskR6aIQ3AvuB4+BIZMTzas5rvV2XDXpkRfsvRqsRJ4BuRtacI9x7yPSD0Xrug3CVgs+YfwcOAwDH174",
+	"AF/6KekFn0xq5BsV++cXB97DeRfoVAJ/ehB8wgCCH+KToa4V3IczBinCz2ly3daN2CWrmXa9AWTtKQjn",
+	"O3JKOog2RCTc8sPgE/zffURHVyNH7AQjYsFzck37Y6o24GZO2C6Sb9ANveFbLS+PS13kgIq0vhPeCtN1",
+	"zW3427OaVo1fBMtut+DGuaZdd1q6oW+YDVe4SykXNHqHISJIeQfb9YZfsWqOW1dwsJrvuAq8/o3hNdQu",
+	"QTo9JX16jChofnu+DD8+v1g3NG/b862WRg7hMOEW9ZGID+jxP8db1gnuG5rdbjYj3Q4xDnwbbvYu3CTS",
+	"1w0dHjKvR5ppgo+ZG76FUJv1egNANpsrwrborxKU0scl+sE+3xOq0UwIdyRp0Wck2gn+hOLhHIBkCIAL",
+	"P9WCPdgdOQ4ek9OJdPCjExmEZZtwesUFzHVrw3GtsaPmkPQHRcou6ZPniJhiSAEY08TD1+LKqOyfMmWp",
+	"ByI/uKuB9lahytskyCMQOyp0+qZ7w/LVMjD8ln4e3U3QtZkc1A0uG3VDUhnzr2x4kkgABrt64sZlAERg",
+	"w4PltK++6P6m466YDVjeU9x1/D5t7651s2HdYpZoXDwDcciSuEeOwTQjHXJALQQUkH168h1ygjcfGDNQ",
+	"Fv4B3L9PjshpcY7K9pJkognU8p2J+1DhSMmjE5iymo0bjetNS4GKL0mfPNUovatNoocaeRI8YD4EUGio",
+	"WasnLWVD32rUPrbE8xC/c5ym6i6AZUtZDqgTz4P94F5CxhXRb6gUvHj10sLVD5cXKqtxfSc0OaRPY1qP",
+	"boS3xLll4/1gt2XDbDbBhFVcDAOk3cdK5FIx0KfEAvKH+WR6iS2GW+A8+xl7+EnwMHiMMqpHGQWoGfhf",
+	"dABMx7UhKrP4kf8S2JOStUaqeAzqbyik5CTYy6AJVHxOKPWo6CMHLHS2CNimJI/mtlnzGzfhO/OWCTa3",
+	"6Vdr5pZZa/j4V9O1zPp2lfJEq648D1QmU/xOu0nUd5iSKzqdugXwCnok/q6y8MHiwocLlerqwpWFi2uL",
+	"V5d/SRUgDXB4EOyit2faAJUafwBaERL19NS56f9+y2rc2PQnmFYfavzBn6hy2QnualOeb/re1BbjhqhX",
+	"RhLXacPtDhFht1vX6SFHbhUVjyxsP0UmU8hKwsuuZEtO3cL7o2DbbrtJ/1GIZV7Ft2w2tirtpqXSPrN2",
+	"GNuF5GNCKFSwX2rcsDx/1fJ9LnYSHg8qBMAL+QKupUa6aJgcov53gKriU0oywJ0O4ctgl9IZOvLAumJS",
+	"JBJCqS6pSY18Lr+IvuYlXrQDeCTyCMJHz5HdUM/XQ8YGnwIw6zY5YEIMlwjNMnC9dlMBoFwHtrwH1AiL",
+	"aaDfCboSkHkHtaueiIDUF+I2gocxfJCugA+V7WS1zIZKiPyVHAZ36d2m11RC0BETbtrq0toK09lRoLyk",
+	"NjncOMbQYnjldlwRJd6y4et6EjpkdXzBFG6K6wI0qHjvsh0oKEgpdpum51c9y/arSob+OWNy54Y6/QlB",
+	"KjESx7OVUEWt41S6NzTyP+n/nSdfki/Pk8/J50VwilZEulxlrucDJJoTxbEbGvmefD8Pa+opr/9Px1Yt",
+	"8b2AphchmrTF8nJZoa4vtIFMp5Ycr+bc0o3xcFpGTwKY9J+6we5B7OBVzEz2NGY7QpevrlUvX31/+ZLk",
+	"/nQtz2m7NUuzHV/bcNo2dR/H7iV/lfwxfXFkeCT91bJzVzf0tYXyUnXht4ura6u6Ab5Z8d9LC5V3FwA8",
+	"ALW8urr47jL7s3qxvHxp8VJ5bUE3pI2U16oXyyvli4trv9MNPZTT8MjClcV3F9+5Ar8oX6kslC/9Tnzn",
+	"2tWr1aXy8u+q/DcAw+KlhaWVq2sLyxd/V/33Bfju/dWFS4ovFper768u6IbSu6zSWEKE5xEI4jR6Pnno",
+	"sefp0ahoQxaticOL5HdCiYqr4eQJ6ZLn2rr+q3UdGR2j4in4LwjcDElAlXLHvTEViudkFClUFZJXOKYO",
+	"bJm+b7kqLvh/SIc8oSE09OCBrGKuyh6GiEB+7SIP7gpmhJ5nB/MVDY4xFa655ZfAMt5gZuPVmfM74Uxm",
+	"4ltyQsS5MnhK6SaQY61UdKOgS4atnmNJKyghaS8DmN1Mo6FDnkeuOFVc94T6MFFrDh4LzF1pxFDNOV3T",
+	"p8bdA9FY1y6XFyvLC6ur1Q8Xly9d/XCe2gPPwCkkKSAox8A/TWXxtHZOMgiC+/DnBHUmlbRzsC2NPGVB",
+	"X6a80zU7E5PrdrqpgMZAwuSVogdCxJdjKtinEpBqUsEjgxoxfeZnB59WHxHOg7QvJGOjmAURo3WRVCLC",
+	"CI/BSJKz8jJEzi2FV4fZddUMBw5jLwl6Yv55dbZAaXJyZmIgXpLtYKq5lulb9XK68zNXveE+hSJ7VW3K",
+	"SMMAjWIIXDTm5qAYocEBcoLUwXW1A9LXkmcwGOJalntjNMzEotfKVaVnUm1csJvbnqiFXF1ZWNYNnSkT",
+	"uX7OOCiqhQ3JZceWNFS0nHMfFm5atupSpIQ1vmDOJTzV3UQKD+lSs+P5INEP7RwPewjShuUZUTMq2EPH",
+	"z3Fwn331SJADE68+ejJA+MEC/BYPP7jWVtOsWfXq9W2lIwA3RV4kLiffe8jsKwsrV8oXFy4V2U/cXX+x",
+	"slBew5+GrxP0VGGJpasfxD8KV2WfXcoifMlMie31W3mD1KvAJD+z+o7QZBJlFUZQHqBToJu/87jiyo+K",
+	"PRgLM4gpONEh5Vyv1U3H9QeNJIyB0Tv+puVmcXnyNTWUqbBGT4iKtUv6Cc8foLreACryT4C3qs65guhd",
+	"bZpJ9K5eKUuKnWRfGBFPesxJl3vqn2tolkx5lh++flKDoEWwi+F+8FodCERP3Tbxo+sF+8L6TIDzOGSX",
+	"+8rgwwN2m0RoqYmEQlVbqUwmvWJezWymxRq/pyICzTWeCsqc3aeoEuwFf6Ywkt68hpcOhYZ8ezVKu5Ne",
+	"06xedy2ztmnVQamewh94U57vWmbLACdBY2O72rTMOn0LLv6MdNdt+YUGiAzmtKNWAjkNUwkxHgXpGrGT",
+	"imngivUBteRJ8EmwR71+1PGHqnon6ZfCkAtD/ynACAfCLmLo/5swNB6B5U68LksHiQ65h4hNHrFB9foj",
+	"jvMpIbA7xV+Lqji/Qbgh4CkRJllOEjyq5NrwTDWddf8DcJmkeTiM/TBdBD14XGzJa+dyN0915ch35Eg4",
+	"q6SEPGFK5z3B9XqASTRIpQov29y/beZa4wCLId6IGHrSGYflrkKIJSkdGl6VhaSUcU1ny7KrqTYy+Trm",
+	"4F6pjMlOVios2fEe3/HNZgasnwW744QQr+MROQ4e4aGiRnCC5sezSKApt5HuLqXfFYv3RCpC+BtDCgJF",
+	"Jxs7xjiqlFTjeCxrJyX9pAPcRbh1kxr5J88gYBnUcjSamm3BLosEHPEcPapmhUkHp8Fjlt0V3DMoZ49C",
+	"Lz3k6Cxs8bYWgiLoFTJL5YHcLibf8ry1hIwBtBWP2lHcQB55bq4DfXE6gi9i3q3SJIqlnFE9rcri9ewv",
+	"luTS3qoLf7Wcm+G/6xaSQPjttaz8rPw9v+9RPmLJ2UsD/lC6xgp3YCyJkxHIXvBIMNqo54qnWcv6+gvq",
+	"mepQBhxmlKquuj6QmfCN+iUGlyzCGSHHD3YxfRQdD8EeAJPL4U2eJxghKZ16liz0Y2Xz9OQeVCaunEgh",
+	"CIAzY1bp28KbldhUCzc76C1lKBpfaJ2DkQ5+ROuDCtv8fIbi2M4UCCrgVxutdhNMwSuOWU/3XObrQphU",
+	"zFTyHvXGUf0XeTy4WSI99Bj/9TDtfj5Sys+Webuao5d8TrrkBb94Ua71MWpbPZ5jkJKqEE/edm5artuo",
+	"W54YXadBoeiNGLxOUSYF2EW4qyHnzXku4rSD6kRDRGYlYo9jWg1Y5AvUlTvMJLch/OXFvQE8tarabLQa",
+	"flryntpTXXSVOAdPgqxcQQFcBp6UmY/Znp0mu8fx+rZMLS14IHg7Ma0wz9MOti6PzT2jCQu7aAHjz4I9",
+	"+brTMhFUwmPpp9RtC4I+UqCDT4omn8rcK8cvpK5NSOVaaDXwopSozCPYGxg4kdbHVWuQywJsKLb1zY2N",
+	"Asyb7ZOVtzD1iqrPD8GTos0oQzUKNp2R9CtyGLE0Qj6iGOSMnrOvyFXOqYsktsnmh8JOiKVIntK4bZiN",
+	"o6YXWlks31NWhFstILj+UUhC8Tyw9IsLzLthN1pAQyWVIAJqsi3Pq95q2HXnljKP/wiLrcFkY0lHEIw/",
+	"Jr3QjyeWMStcGtNvqHwaAjcM7a50K0BRupXNsA5wcZqYjGrEES+MGsiPzDN10W7yihoDcaqCY2OuVO6e",
+	"PJUsWmAvJ6Q3EGjDliQlL07TfAfdnRmCd5D4E/WdFg9A1S3fqvkDLlJvWwM9L/uPx+iIHEuYd9gYnCHV",
+	"3Cu9tl1y+rYWr/pRPcgI80nwgNYUnrL4DM+t7XNDO/gfwT3+AKsafMIYH1PkC0UAi5r/sfyb4D7UCmgQ",
+	"7Yg59/v6uKJ9iGvZY4ullhqyPEW2Too6Hd0EQxEGUivb4pULyVy+JFm+3/xQodquHkA4fcmzyECvAy9d",
+	"H5OzQsTwPNls2QWURatnBS20kxp8P5ewufpCHhIP+7+YiEm8fFNsVCl0jhxI+iB6lOPVxKQzYUQJK6Kv",
+	"8wnGN/ei1PZY4FO8tbKrlV5PoRaYJq2ram4GTGwZzMMC9JTuX6FkVGXhk0xPTRjaPBu3jADnoB6ZM3TV",
+	"Z3tjwIk0MLRn7Rg5B88F93kPDEXFGftGfZUNrfhVHsyTknJ5KbRR5ldib68zXPXKo0CKZGrUczccZW+Z",
+	"54I59GmUWMUaB4DajfVxwMEU/ZjkHKw+ORJzsGgyV0rzGnS5wT/h9yCbwywwWJfSaCITDDJQv2Zpw8Co",
+	"ySFnigca+hzuMab8CHIM1u11e+Xq6tp51Y5YhykkUPoy7NzE6n2YPhLs05D4bjLbDMBfrFutLce37Nr2",
+	"+X+3tudj/Y5ApUNyk/odoTTtUQLrkOfGui2t+IB3VgLB+4yGumjwkQYjMek7eEz/DaIKPCv4cvqvngQE",
+	"KFRhxyzSoy8+DqtmeH8LMIUx6hJ8Qu8plM3zWx5qSIgHzDRYt5PZd7j2RdrB5vza9pZlaNhZivS0Kw7t",
+	"ZKNx6FIR6Z+vgHazbdXnNVac+AXdPi5+iH6jp8DF5G32ycm6ndFgamZGU5c9xNCvUQYimP/CIUZdauQ0",
+	"FxnhLPFEmyu9pakrKmjuDcVp8EC7cPs2E/TiAYjtN8h/yXr9QbI5EfXMaSwDkX5HNYw/C+244pY7q4U7",
+	"5t3JXsIqYcyW4h13BVt9grfmUEyOizKMZksAPlrhGCaGuyftMtilXcOoNwXD55ggnuQgSDVhjjjqoV0s",
+	"BjCEj1iZILp6MEbI0oGiLPiTJP/oUa6g5HorFQqLwCYopk4RW/SKKKDlt2te7o62hxHwLjkWocAEGzwK",
+	"doEKtUbTFOU3hkZ64ptDyuQ3JExnPCY9xoA7EWkgO74bteKhZYp+w2+yZlE8m0SLmgVpq5Z7s1GztHNr",
+	"ludra6b3saFdNptNbaY0cwGEOWsmpc/r05OlyRKX3uZWQ5/XZydLk7M61rZsoiCfwt4L8K8bFv4n7Lmw",
+	"WNfn9XctH5uk6HJDvo/uKHuZ8YTKjC5rd8SeaODsmfQsf9Er89wJ1WvFNhCZr1b9WO4bEf185A4Wmcs1",
+	"6tJiih8ne5+xJn/M93tOSHjpcf0IRONECpY2XKclLVrEY6OA5FsJCKTVASHxnXHB8ZxzyF0mWbEahtZx",
+	"KRbG0I66I+NMCTV2arFOl0qC/Tqt8qfHwbGt23611nY9x2UWZrwZlFDLSj0ZnRQo6Vsy6eNarMHfTKk0",
+	"WGc6AVx9Ht1Suot9jujlZUUAevtNXUhJEQwe1sAyaePQd8mfzUjat9CdMdS6afvDSNHW33Gu83x7HRjX",
+	"+enS+Zk310pvzc+W5kul/9DF9j5zM2L+iwAltVbOGsiI/ahZVnTl2SukXjn4E33nmtTRTzY0pcNSFGbs",
+	"obDt4z2IdVt9kbgaggtH/kpD1eGY3Wya8zVRxIUYEk5BT4XYVSsvfsvfbUhIUBtP8ViauDkQqyjKadEl",
+	"6GXPAG+0ao10qAOc8QI1+OGFoz0VEVSv3WqZ7jYs97+i1wkin6su4GOSm0C90M7xBO6wXuVIo6FZdH2e",
+	"GBotK+xL53SfFdjBb2hxC+lyK58cRbQQ7E0gqd2AG007menXAGY5j1mQ7IpsUp7bJSZUB/valjvJKhUM",
+	"jcfQJ7nHVPjItSD9Tf6EekUNeAf7lP5BC7mMdRvuwyTe4Ia/DQqUMh+ctS9BxVaj+MXC+bBDQI81k9BA",
+	"MiFpv5C1Z9TkqYGAzRB4pz7S1Rr1+I7hukTp2326srFuC0ns4Jp+aWh10zfxg9+sXl2mcAa7wSfUI6ut",
+	"ItKx9Eo7t+VqYv0TW40p0YAG/rWEEsAWoIXigj2ybiuwNEH1+++k7PqVCpVBoQFPzd0jwb8f7BmK/HJU",
+	"1Rmqpb5GQKnyoSG2jlLziDTSC5Epvqk7qdxGAXjhjXGIgz3tHDUOpFIBND/CrP+J1D1hGa3YBLXLqKdP",
+	"DkN1Jyy4SHE9XDE9/zye9vnFSxq6LsSWiwBc2DDxXlhUy9SFsAxHIkWA61veeWX6gmTPpTdrYbs74UYG",
+	"rExeGKyyGN5Ol+lSr0dUvheaqeipE6NLHFyMQVDTJGEb4NY9SvRJEyHGcP63nLcm7NkIK764IZiVRzpw",
+	"Y+VB9PDBgUw0GMhpST0ANI16SsOUWItwXugTh/clDYLwmpE+WuTH5Fl4zeAXyhbuaW2/JYIfUYX1rds+",
+	"FVbnI1kVHWyjPq/NzTAWPJ8UMes2sOJ57c46rr2uz6/ryYd0Y103ffxSpWfS70Ebxkfab+IHW/DXnfV4",
+	"MBEf2XLPT5dK0/S5eIUZPlGu1zXPMt3aJn07T8ahK9Bf0toz/ATq3OiDiVy2dX3+o3W9PYdft2fX9WvG",
+	"uiK7LXoMvg9rDOVNz61NT8+Xok3zwup1qi7vGOv8xlA4Z/ApIcpJP55b13fW7bzm/Yo2cJQOZUE4ulaW",
+	"9uaCdXNYuw4eFcs9vwoymzI0UbOinzDVSiy5Mut17p9Bld7xFP6TFcfzhTy0svCbBLtUbT96ZIpPDqA3",
+	"C1/3jlPfLm4Xeqz3Kc1ME5D4bbyeKl7ynwjIom1702y2adp1PPWCXZIZ2bqaw8NjTffKQoazfJ7KuBDN",
+	"UxQPEEITK5UhwJhBMCj5y6uvVMJ1pOqiIouUVHu1HX9B6JdZYJ+xhKWCi8d2+AYu7jvOkmlvxxb+pxbt",
+	"khzC2gpVsMia08kNZ5i4RbJzCqdPp+d4qA1HebDCzqi+lS03LVn6I+ZCmANAhHxhvT2tTkue/4g9nHWy",
+	"igJq/XLjttZ0bjRszbXqDRe2GtVQo0jRM48jtwRJSp6N47+ghZ7gKzGerBuq6TQqqNhjU/gMLjVXmitw",
+	"amOdoZDd2JbncWFVMGwOgXxrUPY8CndU4De8xvEObYo2ZVGjNrRNG57GoAmbuGi+o/mbDQ8Y704aF8Ux",
+	"HgglzdulXpJ7rO6E2Vth+7VUAMUebRFkNdOG5nF0gkaoE3qas0GL2ushaMPxXqmWmPeiTclBSAU9rTVc",
+	"EsGwF1Oj6TSwB3/T0ijf+IWnYWWb42oN39M478APPX0nncF/FdN7aBdYnkkmD9o5UqRtpGScp25W2dxO",
+	"GnnCqQhGn8xEZwabGOvwGLkDAYq3Pk+T48MWqOWMOYYKBRHv7PRMvi4anxozsh77GcJ+H0dgPNIK9QNQ",
+	"qWnxchLKs3CeV3ZdiaDwCpxfpfZS86KwxnuRPj6ossvnfY2o7PoXebdn+ZLQ6nTV+ItcnPL6mh55SrEr",
+	"F6WF90QS/2/p6SL+jRQRX3F807e0GkjtDdil5SHVMPsuW4cPHshEJIx7ydlh6h5AhWGTi6obDcTwRzq1",
+	"c6c2Gk04tskbTpYycyFlp5HBrLEXsb6gC7cbrH5IVtJ5ogVgHp25TP6xVsCZW8jSZ7OBy9Zwcxr/yIjL",
+	"yAB7HPkFWUf64MHbqob1sR712VSbQgsHUS4L8h2hrzyi8dV3Ahqxq89wBsD0YAZAss6RhUsteXKCOJ7n",
+	"I3F0Ag208QkH7C+cacDTEegUgOmw/fx0oejljjHMKjMDrTIbW4VtnS/D/uQTAaJm/MIrpgd7hdDGPxe6",
+	"N1BiCDU7vFQH+Ume3TZb3G4bD5sbxGZTVdcmdaCk7heVE6r7PuKzJyzBlNakPMGEeO49hrJSGkeIppY8",
+	"TBk6IUvHjiQds7Kdld0QYtcpM+asnKYWHvoIlm6hymGlySg2PfzRmLrkr1xsTMVnnsQt3ODBkDbu4FpZ",
+	"ltKVQ1bpFrDUzjuyWCCXjt90LWLhmulamulrnBr0nWwdhTsSabAv1pYky+oNu5RLRlSjHtpRFl1xrOZT",
+	"NsRR75fxn9DIptN3/JYhW0JXTaj80AEo3MKl9fdJ2wqAT6m1LqIuFzScWBZGWpql8Ot3LUW+ZRQYi/RV",
+	"VagxqTENMAM3LlE2G57vuCzgf4jZx2g/8zHQQlZ6LPGfJbrcwxRcikiFya2otWTWaLRdBkPKdht2rdmu",
+	"q1M7+S8V2ZsjJ9jxd0vZdNN6PKVNiLrpYsvY2VgxKs1YY1BGXVpDzYaG6IZaai5rKVUb2IRKOcSiF0ZY",
+	"dFZalHYA1rNzBaOF34otDP715MJCN1t5t9dy1MTZdPe+0Ns1DT9ZEYCo0TXF1Qh26iBaZUjHqt5zQoIU",
+	"OdCwKIL2Xz5GXTFiq8mhrPGRVzRRh93WXwp3utCwu3hXa5UB+ipCGhhw/PHELFQxiZHj7bxdCRO3RsrZ",
+	"8jl7vD9ElxaiRUKCpjXGi0NeFBeozYZXVKJeaXiZIpX1GlYJmLADe1K+5HcoTimTEJsQFxfGK5VfBA/y",
+	"Syhp/iu11ArXU6rAlCc0DAqoqCWlKFJDzvQfAJJIOSSnrLprpBoLxuOr46q1EMGLQTZM4QUHbzwFGN+J",
+	"ORDjwB4VcWNEngjfGNDH4BsP9r7BtE/eA+kocsIeSEMQaGl22AGPZWM8pYySGRgqWP8w4EXIqaYJ7+HM",
+	"z5U1A1TW6Nb2b35fa32wWX/3g49/O3O5tPh7p7H0+/L28mrp9tLF0vby5fduL605t5YuObeWLjuNKxd/",
+	"c6v+4W3vt7O/adZ++0GzNlvZMD98r3G18ZtbtUbp9tKl8u1Fu5TozzX/UapKOjOQNjqTo40OoImOkpti",
+	"DOOIHVbDPmPt+kdc2pPo0zeoOp5b4COvMIYyn5XKL9BFMLIqC87yFzi5jekrWMrxR6zTfsgL+V9pEU+O",
+	"vos0XDjwvoRPv/Ik0+yUwZEyBHNCgz+Q3D8164r4T4wbz87NX3jjP/RxcSRmi7z23L+VCpuH2GcG3z4r",
+	"/Q9zun7cVvTryRL6hlWm3Qutbz4ThKJVO8c6vZyw4B2tBqID3uX6xGB/AM4Tduorynwq/AdD8x/jVaUI",
+	"ZSZcfhYNoWd9WBLDRwrkXtrWrark3zR0p1mvxgqPM7jmjpEeNPsvWtHF8ve6IaDJIZBCiCY1aykG13Qp",
+	"AzCa417bdDzLTiQhpo8zEwb7wx9C3lsGzuaGwdmwOf4DLFRief4XeZwwTkCJXnJxTS1q+5gITGUdTBZQ",
+	"swwof8A0Ym7iiyUWtAtlotYiBs5c7lmkp+MWuGPZVRExUnljGFJhWSIDUnFioirv5EResEaZw9DUdHai",
+	"mbRdZSe2U4i1AlScFZymbuRteWaihsOuOlgQemoU2aWS10CpNfqE+e2mAYKofzHr+PkkSk+jA2h6mpDz",
+	"oXEnDvjIoBdUJ/g0gkPVhVRC8p1hesTmpp+JS7x+jfPCgFlL8Itr47V+E/G2C1la/mtI6clp+ysMAO4r",
+	"RuYJJVE5pOLmNoVVVkYqWwnT/PV407P+zwUzI+tvssYxlDp3NqU0P4qsKKRuuJK5iVGvqzIonK/o2MmK",
+	"oDQt7SuawcLwie34WMWIustvKmjLV6sXy8uXFi+V1+SCH9vRaBKrGolaw8bKHn1nBM2N9hQvHPLL2MSa",
+	"mpbDrDheuZRGy4Moe3kXkm4N23LQVhpA32OsvsJTed21SC/TxMDrrEn6JnU4KTmStcxUqck69MTaIWKQ",
+	"J9kfJngwiEcCOg8NXH9fkX/2ekrwz7j0fObsrb8BasIBUYDzvJ4DwS4VBkMsM/Nz6TnLTVPZAa8oj+z1",
+	"VJQLVPMj9yeX3hoDF/nhlF7/mPWX16cIvD5Z/11IKQohHuwKqSroGzph9R8DyGtAvSinY5j6G2/eGA3B",
+	"V7aTjw+TZ9reJezux9uBd6URKBHpK5sChfPyTmlXNdKLAKBjZTE1R5MH/6/brHcfFZEp+s+kRj4TemWH",
+	"t59dTe7LUnVXS2guiMAz1TuO46r1mDSOb5Uqffyawz+H6+gzy+QJ7TYpL/6Oc51TwAke511sBz6UPjNe",
+	"RSNbtOX7m37oTXB++urISuVnvaMAFxnQfwOEVFyjGJq3/HC1hpVKiMaCXtSuUvyMrhVAi2Ax3yA2WEwS",
+	"paeZrvNiWoLHRrdm6Amf8RhSSrkeneUQOoq00IFOXXsPYTASy5lgdQtimS6bThHOmMchJyyVQq4jZMO1",
+	"hUpAkPUaDvI4Cr0iJxTKZ3TUx7p9rua0bV9bb5dKs5Y2PYHvkEabYvpfNEZfasmA8y6j6Ro0oCenDWJF",
+	"HS/PEDSp03Dc73PIP6PzgBOJtrAr1usYEXSAfs9T0odJGRRpdNwH/yWDmsErLQIICWd5S9Ne8UF5Ouyw",
+	"w18nNfKl+jvAtvRSPheUu3qjccPojwJLEk/piM1hDhvj8jkpglqYmPiRnG6L3YepDssHOYYbKqDu8SHG",
+	"Iyl8VPguO/5lp23HGedf5fYgsbpkSW7PsVSP62hryK/5nLbBjYqBeDj5ouk6TRALL8gh6M90cvVeopb2",
+	"SbCHKL4nhC+ye9DAFYJMc0N35AnEaaMVZ5MDZrm7ROwUgbNckR/z2GkK6r7I7IyZh81pPQZ4fDTiR3rL",
+	"ud5oWsAggR837BsJP/rXbHpV2A0nnLxLh0/Fb1iHzb1NA2mcrW0GGBhPj1IoJpg2ckdmU00+fudZvoxY",
+	"iJBTiRA7hQIjxaWZ1+kzt1+J/p0gKTqAXigTwFqOIrMxqmwAyEzsYz5yoyT3XdkytyF45SV8vYa49rRq",
+	"7Vn1wrMpC88UbnqTs276nqdH2fOF2NqlAfactnDBFjzXBioQ4TXLqY2L0guTd4zs5JOU99J6g9QcFPrD",
+	"HRUDTt2/NFm/VFjdju5uqkf5ebBHc/5pnmSqtkENptKrs9rQn0uVW6qqRByrsAE5JoVAZS5dvvr+smwr",
+	"0QXQTNrAJc5EnBaDRWqZqVGRKkM2RkdviDwjNmgitp/B02Jol51RvbspElNTJh6p+0SFY2OfhlPJ5UmJ",
+	"+9mGnut4vuVOWbe3HDezbruCDy7Q5zKKtpHCU2ah0ZpNZb0i/x2v4mZ/bputpm7oNe/mmfQL4brdnWjw",
+	"80eKWVZSlzRpGFW52ahZKHCSY7pi2a/Cry5jizcAX8VVdwwRnNhDTAmNlbdljpbGg6Pp6gI2ELPzdwZ/",
+	"CQ6IgAMRURnBaLBtG3y/RoiZdZtt0WhPG4g5A/ArfPyGgagxEIPrNt2sYRix2QbKYjRazitbl7taCBcb",
+	"jyM3z4rqfKVJ7Kz1au4Ehe/CKIU8nXwcnWcfhEY77+vDM84kQy2NadHKtgMonQPhQTsudTV+3xstdo85",
+	"a2AHLDEF9lC62+cbcQgom4IrouQAxzEZ2u/KS1c4j724+oF2jloPNF2F9KJDMjROPRonH272Y3xpnzyP",
+	"uDKroAnJa2KSjlXFXln3YgO+B8Cc6Hzibh9DnC4WNQMFoFiK6nE0lVYQJ9RHdMCHDYX9PVnuDe3vKQK2",
+	"Z6zbCXqMXoX+kl1yLIYqhZ8biRH7pzxmJ54M6aIvTczel5xcgMjvtLq7XXXb9i/hjsr3SpX9reFQ1x6i",
+	"7kjRQQQxyCSaFg3a5QE3jU9rO2RFmmHdJh2D9CfamiTNSUPJd7Gllk4Z04SQBlhyPb1nKjfSaQJmPOXg",
+	"E7FIn8oKleBjmFRLPiYt4o0WR8tmYuZ/ItBP6ZHtQvTLGTFqNXjqdSfYT7DNXpx6WPGaqA6qRWsRSZo+",
+	"+rHiXLdcP/7QBfmhBUgcLCRa5aVK8ms+NJso8VLlL/D4Nj0F633PchO4Tp0KENZysFkf1G08BuQNvutC",
+	"7xH2/JPUOmYMSljxzy8YCzf5w0wVac+UDEoZ7PMfhl4yZjcW5R7hUFq6HdrnocqaFKvowxCebm9BBnWV",
+	"D3BWDLNlzQ9S3SrxO581bDb3LWxobFHHFd8F5EiOYQ/IkgpvIObWkt+Ru4ML8g7YqQ20B3aeSnaY8WTE",
+	"SCUI6haulAMFl4J5qMQAxqjUEL6koFOPy29+u1KbQfN7U7BlB+VvF/FXKk98uO6dpHog+7kjDYPDUChz",
+	"8++JIfnnJDUn1HSFEBJXC/HEJop730T15MwEZ9wJtbj8QfnK4qVqZeG99xdW15IFB+1pyCCApnVWXWs5",
+	"rqX5mybUqdTGX36AVcXBXVSyjsI6UFExH0N5AJ7eAWvv1xvQXjySoMEJr+R5OPJAShqQDEZIAfKyvEer",
+	"+MAgirkcx09t+quYQMvnhYDp83js3exG9jkluvvw3B0WfNDnZ6K8uTSPVMEp6r7jm00p6pE3Vz13remC",
+	"a83E+KiSAeeuViq4WqmAk+5awSxBL/l1lF6liFduWbbqmxiHxsfCCrwkd5aOXDUWHK5mh5xQs/55mNTx",
+	"THIFUNdxL2TqXeWjtLlkIQnFYKL3d9C2UtGOijaVYo3BkXcBu9dfeaf9L7Lb649jaL1in7xD+gC9uDkj",
+	"pkcj8OGpLbMBzCqfIa/wBzP8+cV5ZvGO5tfGE2dnBko85i6zzqbp+TzSWa9K7apnp9emS1GXOrGzKmOP",
+	"Iue8ZTVubEJnxcm3LuwYeS8uvZX64lnxxTPRi6cn33pzh4Z2xS3NxLZ0Lc3MN/RbDbvu3Kp6vumK4JSm",
+	"hQ6DuRklnjK/b5d0k/ZoTzFnuwhXKeNCIfUpNF9hf4qMFXmfCWgfo3O5G3ZGTQTQeFcNMSNPGr+E+hPp",
+	"6kbBPqMSFxTvhASpEWK4EDv8Ej2Sd1mHPRqx/EnyQ9VGBeWPJj8mKpBZoYfCR5Jev5rklV7TfMe1zNqm",
+	"lc8uV4VnB/Ixn8oFR8E+LVuhNSovXn3/5RzgMotnzmyufxIQg8dXOjH1pZvse5wCltcAQ26YxsFn2293",
+	"ZAl4PSRaMcVInsZQkqQQ/QUKlTfhxvpWzU/8ZAZ/Ms1+Um9byu/5Ky2vZrIsnnndjbrLNS1TaPmT39tV",
+	"NRuiiGcmQ5ZdFy51IZkUXu5cLTd8dSEu/lWcqn+SLPwrNYcLuYeh5XRsTWHSQAYw0z+7l8CaZbbK9fpI",
+	"adnqAZ5fpcTARJ9T4dSRWBbIinXjxrbaCBZ/9WbMH97eQn94TAnccHGrdRYsMVuqCVgxSpAndgpW40nW",
+	"7M7RMmXSQlTFPW1rYUeWMQ+QBLh+mOeZxuQ4xAXQlVRRi7GvOMUIVTmd4Xy/RYlzuFFtawvlJdWwtije",
+	"dpYD2wbYw5gnrMlJJHi145oxVhto56IzhAKQKTmFhaZipHqIxe60axijFth0zig1eF45Qy2nvQv8btls",
+	"We+hLja65jTOTL/Ej1Q+1dSo/MAML+4UJE+Cv9BYQtyG+CnqGPEpR0VJPodmLzp1y7llM79rDvUKD79u",
+	"OnbbTab+c+j52Ikt0/ctF47lv8Hs75EJUNi0slti1CqDdORTecCGWQsjyNCmC/4S7APDEcOOvB8EZoB1",
+	"Y+3Fgv3gU/ahsBpL33qCxZyHNA9t4l+D9l/KSI8GkVN84ikc0gavac6QxGXw4pchW+NejV2HoScT1IRF",
+	"kWS1X7Vn1u2pulPzprRftWcxzSaXhGOxeGkniglPUQXpCW1OEkY5hfntqn62Wb7JDFegANArqUUrzh8M",
+	"6ZFZ+RF6CmfORL4TihaE/FqJzF9PpY8qZi8QyE+R2/ydjSq4i0EqqTJlWL7DUpB7OPoAEkd3MVe4K6BS",
+	"Oyd0MaKaqFRC253IYV6X6KbFjq25HCz+mxHYWHqp84WBuVf6u+5EjsVS6iwhITw9JLdKBeCV8C5u2Gai",
+	"9IwcEa/V3P5aMMaQDap8Tz9FlvPPsN1XPxplHbYeyOqbzEZE7aEYPwYDAHnRo+EiRCq+cpnVLBbTiaKn",
+	"R+Alye4DYVbotYGZSfxlgzQDGJJ9xJZ8pUwjG3c/c42fENf4GyofCg9YvGIZR/Ty/EG5PipKuIlyAFQ5",
+	"hbQvEWwj+IR5xY4ULeBj2TkqfkKTqlabZiF+Ej09SnijYd80m406WzRCIYRp6NEc0qZB0UDWpOufSt+q",
+	"By/R/VuOVje3PT39SkAccKnhQa8UeVXyD0BXcriJuJgUXLQdv7GxXYUX6oYMx8zcZgYI9IdX4HcyBGAC",
+	"gifiU9KJhM1hsFcQBDnEKSYeFQJLnN2V09y7o24Ayqu/u+II1h45CP5MK+meY4tv3ngTSyKhFCV1e0L4",
+	"VtrH3L9l7QM+L9JAQNEvIGcVXlSSxVrFDYi15BaOo4+KyfnfcSpiO76mMPLl872TP7RT3M6d7HY5yVwW",
+	"UF2e8Z5gwomy0zconwoz84O72ty/bb6tAVBMNWITlWkp5uqVcpE5o0PKdmGnPyW5Lh/gIKkNIQmXWiUv",
+	"razkR6MilF6HZBmkbiM6KK3V9nztuqWZ2pbjNeDEtXrbFfrKjCaBBgBKYC1aRDuabVl1T5OIZvxVJawX",
+	"DXJ6xHpPPTqMybjhutSMwOmLdYaBBc6sE0xMF+1Fk3kOz6a5i6iZyqkw3C5VFLgUMUqBjDxIiSlf9yy7",
+	"ljN6GOqrvHL08AgmqWXXPTEbbPr89JtrJSl/2sRf6TdN+hbaG9j1Yz8rzUo/i1Vfpgt7vn6xHL4IHoWV",
+	"K4BV9G2Fh1EImZDhKkYI/XASc8BMFjMiDfZPhHzaeEWHmHGKAmiZGeLssThy+c8HGOPWA480bxofNgah",
+	"3upDjB52eCjy1dvI3wwyh200jvR5uFceQsxFj7odFkRrFf1TIbB7quj0LMyoVIyMEH36yKwkNpeTUoI/",
+	"GCanBH64WB9XJL7tpRRVK93WiUaEBYrSk0klGVeMg5NFmYAAJecqerOUZPuTvj/JEHzK7ZBbv3bpqFex",
+	"B3OfvMijesb/vCLUHz77em+BGYL80WsSO9eKKxOmgN9iJUtcICVd5ENoBuHyg8qx4IGSVRvs03jMVgik",
+	"8woLYMjHwd6/1FWVw8hYaIkNrqNPXuaiOPW+513lS40blldIjLEnX+81rofgWi2z0eQ+DssGV1JY24nF",
+	"j55l+1XH5o+g5jyvY4GITv/8T8eGj95fuziIjhiBkEVxFFurlu/TIsJ4Rwz6ksI1GnLne3LI6PEZS9vt",
+	"0BQ1RZxRaI2R3j+0x3qxRUOGjif+taRlcroAZu3xdL+DcFSFAvV50d9hrmUlnH2Vdy3ZkyNdS+NOSrF7",
+	"sIdzvH5B/fnlK1doP6jj4BEOXkuta6Mzd9StVHEOT+T9Zn+G013KV66omqkaqiw5SZwc0erMSOmnaT+x",
+	"WR3BfgrMjlu33BSQYWUBZBP/wg8LQfot2hsnbPpg2DqwF/yJFiWfaS1fAhzbuu1Xa23Xc1wWdIyP9GDt",
+	"eqPATQfy8qmcwkkBUDZAzxikFWJuImUjdKGz7Vki7EhVz5dSiM9KqspSBeGcUItu6I6/ablSI/KMQfgz",
+	"KdOoLjdua03nRsPWXKvecIHLJwfiDwDfdBZ8PEFyjBOzrrF2JvGmKZkarHQkiQvxRbCH8+1wJI88jKZL",
+	"XiTuiHYOQ0lYaC19Ba3okL1CZR9z7U4UiS0lGqoU0rLFWSubDm37lshIoajKibHRdrl4zRh3TYyrZh9A",
+	"4m8Pt3hPHPAmpNANoeDHO6FQoA3p1Ir1RBGaqkobUYm8sUvtlcovMHPiKagFme3R84eDIoejaf4Chd1n",
+	"7V6hKpQcshe8YGLmKKLiYC/bU0TnGRf3iVek50dwi0uWbr7Fyego9Dk3bP+NOd3I6x4k/PrVDDL52YOM",
+	"WYmo1ByHGekv8r2mr0Orz/Pjjl+9Z4gp6krOvLqeFaVf51/cVWssydpJ1+wAsnf0rOwhRMrrycQewLc9",
+	"U7hV3A/at53IlOD3/l/P5Z2WlB3c51gRM7RZ63bRNjwl/UzjPVI7UclJ4DiWBC7FxyfymErkeEsbX4DL",
+	"7Wc0zce8CTq8cheboIounLBbvka+ATOedfNBt5kW7DHRwUqqO9DyHxwak8r+9Zy1hS7AkTM91xqt+Hi6",
+	"z6JQHe4jMR2CfE++nydfki+lXuT4In3mwrx6wrSwHnX3JWbfQ0d93pGU0g3NyMdT7rPLFewHu/Fl6fv0",
+	"JdP1pq42t1tbba+65NieCgxny7/a9uMD+YJ7TH9GYqL9vfp81KTkZBLXDp2dyUkukW5Sbyfm/2HzVRyW",
+	"fsyp90B7a362xPTZL5GOjtgAhgPaFPxEi4Y6sCGbgI774BuSgKLuWP26c/1X7Lwna05LFx2vszHH60Ib",
+	"uOfUkuPVnFsqrMEHKVk936SEtxT5PenookMqs7I66K4UORrhW5PNj/mW7yRbpjEqw1woKTYNlMBq145w",
+	"ZKtA64m1fYGYY0t8r6RcbbG8XB5L2sgrkeoJJ7+CqjI9/oIWPxoB/mBiACqx31W6rZOsY5S8ybHx6UGy",
+	"FeGEwuTJX/96fmmJ4uSMePmgoMHaIXimjZdLQ5jxG9QVzyyNMmtThsRTWBZhKNqHS6wcDwsulGIJS51Z",
+	"iuUr1EujtvXhBPROOH6IDbQZJLyUlsnBT7ifqkacUh9jniq66JWZ8VTEvg2fHsG+zRxHl2ncCr9USd4h",
+	"LNfoja/LZM2dLvGzNfrjtEaDP2IDgqeaMIyKFRikx+OV7qid8LM7PLJG0653jPAD+rDwgTRQU/j815bZ",
+	"9DfFT8rtesMXP1iAmiTpR7zHevgBH390bef/DQCnZHv5PzIBAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -1,8 +1,3 @@
 package api
 
-//go:generate go tool oapi-codegen -generate chi-server,types -o api.gen.go -package api openapi.yml
-
-const (
-	INVALIDREQUEST ErrorResponseErrorCode = "INVALID_REQUEST"
-	INTERNALERROR  ErrorResponseErrorCode = "INTERNAL_ERROR"
-)
+//go:generate go tool oapi-codegen -generate chi-server,client,types,spec -o api.gen.go -package api openapi.yml
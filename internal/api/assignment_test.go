@@ -0,0 +1,262 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+// createPR creates a PR by the author and returns its reviewers. It may be called from any goroutine.
+func createPR(t *testing.T, s *server, prId, authorId string) ([]string, error) {
+	body := json.RawMessage(fmt.Sprintf(`{"pull_request_id":%q,"pull_request_name":"Test","author_id":%q}`, prId, authorId))
+	status, resp := s.do(t, http.MethodPost, "/pullRequest/create", nil, body)
+	if status != http.StatusCreated {
+		return nil, fmt.Errorf("create %s: expected status 201, got %d: %s", prId, status, resp)
+	}
+
+	var created struct {
+		Pr api.PullRequest `json:"pr"`
+	}
+	if err := json.Unmarshal(resp, &created); err != nil {
+		return nil, fmt.Errorf("create %s: %w", prId, err)
+	}
+
+	return created.Pr.AssignedReviewers, nil
+}
+
+// TestAssignmentIsReproducible checks that the same seed gives the same reviewers to the same requests
+func TestAssignmentIsReproducible(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, open dbtest.Open) {
+		for _, selection := range []handler.Selection{handler.SelectionRandom, handler.SelectionFair} {
+			t.Run(string(selection), func(t *testing.T) {
+				assign := func() [][]string {
+					s := newServer(t, open(t), handler.Options{Selection: selection, Random: handler.NewRandom(42)})
+
+					assigned := make([][]string, 0)
+					for i := range 20 {
+						reviewers, err := createPR(t, s, fmt.Sprintf("pr-%d", i), "u1")
+						if err != nil {
+							t.Fatal(err)
+						}
+						assigned = append(assigned, reviewers)
+					}
+					return assigned
+				}
+
+				first, second := assign(), assign()
+				for i := range first {
+					if !slices.Equal(first[i], second[i]) {
+						t.Fatalf("pr-%d got %v and %v with the same seed", i, first[i], second[i])
+					}
+				}
+			})
+		}
+	})
+}
+
+// TestConcurrentAssignment creates and reassigns PRs from many goroutines at once. Run it with -race:
+// all of them share the Random of the handler.
+func TestConcurrentAssignment(t *testing.T) {
+	dbtest.Run(t, testConcurrentAssignment)
+}
+
+func testConcurrentAssignment(t *testing.T, open dbtest.Open) {
+	s := newServer(t, open(t), handler.Options{Selection: handler.SelectionFair, Random: handler.NewRandom(1)})
+
+	const workers, perWorker = 8, 5
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker)
+	for w := range workers {
+		wg.Go(func() {
+			for i := range perWorker {
+				prId := fmt.Sprintf("pr-%d-%d", w, i)
+				reviewers, err := createPR(t, s, prId, "u1")
+				if err != nil {
+					errs <- err
+					continue
+				}
+				if len(reviewers) == 0 || slices.Contains(reviewers, "u1") {
+					errs <- fmt.Errorf("%s got unexpected reviewers %v", prId, reviewers)
+					continue
+				}
+
+				// Reassignment may run out of candidates as PRs pile up, but it must never fail otherwise
+				body := json.RawMessage(fmt.Sprintf(`{"pull_request_id":%q,"old_user_id":%q}`, prId, reviewers[0]))
+				status, resp := s.do(t, http.MethodPost, "/pullRequest/reassign", nil, body)
+				if status != http.StatusOK && status != http.StatusConflict {
+					errs <- fmt.Errorf("reassign %s: unexpected status %d: %s", prId, status, resp)
+				}
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// TestConcurrentAssignmentAtCapacity creates PRs of Kate at once while Leo and Mia have room for one more review
+// each. Only one PR may get them, the rest must be refused instead of pushing them past the limit.
+func TestConcurrentAssignmentAtCapacity(t *testing.T) {
+	dbtest.Run(t, testConcurrentAssignmentAtCapacity)
+}
+
+func testConcurrentAssignmentAtCapacity(t *testing.T, open dbtest.Open) {
+	s := newServer(t, open(t), handler.Options{})
+
+	capacity := json.RawMessage(`{"team_name":"data","default_max_open_reviews":2}`)
+	if status, body := s.do(t, http.MethodPost, "/team/setDefaultCapacity", nil, capacity); status != http.StatusOK {
+		t.Fatalf("set capacity: expected status 200, got %d: %s", status, body)
+	}
+
+	const creates = 8
+
+	var (
+		wg      sync.WaitGroup
+		created atomic.Int64
+	)
+	for i := range creates {
+		wg.Go(func() {
+			prId := fmt.Sprintf("pr-data-%d", i)
+			body := json.RawMessage(fmt.Sprintf(`{"pull_request_id":%q,"pull_request_name":"Test","author_id":"u11"}`, prId))
+			status, resp := s.do(t, http.MethodPost, "/pullRequest/create", nil, body)
+			switch status {
+			case http.StatusCreated:
+				created.Add(1)
+			case http.StatusConflict:
+			default:
+				t.Errorf("create %s: unexpected status %d: %s", prId, status, resp)
+			}
+		})
+	}
+	wg.Wait()
+
+	if created.Load() != 1 {
+		t.Errorf("expected one PR to get the last free reviews, %d got them", created.Load())
+	}
+
+	for _, userId := range []string{"u12", "u13"} {
+		status, body := s.do(t, http.MethodGet, "/users/get", url.Values{"user_id": {userId}}, nil)
+		if status != http.StatusOK {
+			t.Fatalf("get %s: expected status 200, got %d: %s", userId, status, body)
+		}
+		var resp struct {
+			User api.User `json:"user"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.User.OpenReviews == nil || *resp.User.OpenReviews != 2 {
+			t.Errorf("%s has %v open reviews, expected the limit of 2", userId, resp.User.OpenReviews)
+		}
+	}
+}
+
+// TestConcurrentDuplicateCreate creates the same PR from many goroutines at once. One of them creates it,
+// the rest are told it exists, none of them fails.
+func TestConcurrentDuplicateCreate(t *testing.T) {
+	dbtest.Run(t, testConcurrentDuplicateCreate)
+}
+
+func testConcurrentDuplicateCreate(t *testing.T, open dbtest.Open) {
+	s := newServer(t, open(t), handler.Options{})
+
+	const creates = 8
+
+	var (
+		wg      sync.WaitGroup
+		created atomic.Int64
+	)
+	for range creates {
+		wg.Go(func() {
+			body := json.RawMessage(`{"pull_request_id":"pr-twin","pull_request_name":"Twin","author_id":"u1"}`)
+			status, resp := s.do(t, http.MethodPost, "/pullRequest/create", nil, body)
+			if status == http.StatusCreated {
+				created.Add(1)
+				return
+			}
+
+			var errResp api.ErrorResponse
+			if err := json.Unmarshal(resp, &errResp); err != nil || status != http.StatusConflict || errResp.Error.Code != api.PREXISTS {
+				t.Errorf("expected 201 or 409 %s, got %d: %s", api.PREXISTS, status, resp)
+			}
+		})
+	}
+	wg.Wait()
+
+	if created.Load() != 1 {
+		t.Errorf("expected the PR created once, got %d", created.Load())
+	}
+}
+
+// TestReassignmentPools replaces a reviewer who came from payments, the fallback of backend. The replacement
+// comes from payments, then from backend and its fallbacks, but never from the fallbacks of payments itself.
+// Picked and chosen by hand, it is the same.
+func TestReassignmentPools(t *testing.T) {
+	dbtest.Run(t, testReassignmentPools)
+}
+
+func testReassignmentPools(t *testing.T, open dbtest.Open) {
+	s := newServer(t, open(t), handler.Options{})
+
+	post := func(path, body string) (int, string) {
+		t.Helper()
+		status, resp := s.do(t, http.MethodPost, path, nil, json.RawMessage(body))
+		return status, string(resp)
+	}
+	mustPost := func(path, body string) string {
+		t.Helper()
+		status, resp := post(path, body)
+		if status >= 300 {
+			t.Fatalf("%s: unexpected status %d: %s", path, status, resp)
+		}
+		return resp
+	}
+
+	mustPost("/team/setFallbacks", `{"team_name":"payments","fallback_teams":["data"]}`)
+	mustPost("/users/setIsActive", `{"user_id":"u2","is_active":false}`)
+	mustPost("/users/setIsActive", `{"user_id":"u3","is_active":false}`)
+
+	reviewers, err := createPR(t, s, "pr-pools", "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(reviewers)
+	if !slices.Equal(reviewers, []string{"u4", "u5"}) {
+		t.Fatalf("expected reviewers from payments, got %v", reviewers)
+	}
+
+	// Payments has nobody else and backend nobody active, data is not a fallback of backend
+	status, resp := post("/pullRequest/reassign", `{"pull_request_id":"pr-pools","old_user_id":"u4"}`)
+	if status != http.StatusConflict {
+		t.Errorf("picked replacement: expected status 409, got %d: %s", status, resp)
+	}
+	status, resp = post("/pullRequest/reassign", `{"pull_request_id":"pr-pools","old_user_id":"u4","new_user_id":"u12"}`)
+	if status != http.StatusConflict {
+		t.Errorf("replacement by hand: expected status 409, got %d: %s", status, resp)
+	}
+
+	mustPost("/users/setIsActive", `{"user_id":"u3","is_active":true}`)
+	resp = mustPost("/pullRequest/reassign", `{"pull_request_id":"pr-pools","old_user_id":"u4"}`)
+	var reassigned struct {
+		ReplacedBy string `json:"replaced_by"`
+	}
+	if err := json.Unmarshal([]byte(resp), &reassigned); err != nil {
+		t.Fatal(err)
+	}
+	if reassigned.ReplacedBy != "u3" {
+		t.Errorf("expected Carol of the author's team to replace Dave, got %s", reassigned.ReplacedBy)
+	}
+}
@@ -0,0 +1,294 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+func auditRecords(t *testing.T, s *server, query url.Values) []api.AuditRecord {
+	t.Helper()
+
+	status, body := s.do(t, http.MethodGet, "/audit", query, nil)
+	if status != http.StatusOK {
+		t.Fatalf("audit %v: expected status 200, got %d: %s", query, status, body)
+	}
+
+	var resp struct {
+		Records []api.AuditRecord `json:"records"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp.Records
+}
+
+// expectSnapshot checks that the snapshot has the fields of want, given as JSON. Empty want means no snapshot.
+func expectSnapshot(t *testing.T, name string, got *map[string]interface{}, want string) {
+	t.Helper()
+
+	if want == "" {
+		if got != nil {
+			t.Errorf("expected no %s snapshot, got %v", name, *got)
+		}
+		return
+	}
+	if got == nil {
+		t.Errorf("expected %s snapshot with %s, got none", name, want)
+		return
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(want), &fields); err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range fields {
+		if !reflect.DeepEqual((*got)[key], value) {
+			t.Errorf("%s snapshot: expected %s %v, got %v", name, key, value, (*got)[key])
+		}
+	}
+}
+
+// TestAuditSnapshots makes every audited change once and checks its record: the actor of the request,
+// the target and the state of the target before and after the change
+func TestAuditSnapshots(t *testing.T) {
+	dbtest.Run(t, testAuditSnapshots)
+}
+
+func testAuditSnapshots(t *testing.T, open dbtest.Open) {
+	for _, tc := range []struct {
+		operation  string
+		path       string
+		body       string
+		targetType api.AuditRecordTargetType
+		targetId   string
+		before     string
+		after      string
+	}{
+		{
+			operation: "team.add", path: "/team/add",
+			body:       `{"team_name":"mobile","members":[{"user_id":"u20","username":"Walter","is_active":true}]}`,
+			targetType: api.AuditRecordTargetTypeTeam, targetId: "mobile",
+			after: `{"team_name":"mobile"}`,
+		},
+		{
+			operation: "team.setFallbacks", path: "/team/setFallbacks",
+			body:       `{"team_name":"backend","fallback_teams":["data"]}`,
+			targetType: api.AuditRecordTargetTypeTeam, targetId: "backend",
+			before: `{"fallback_teams":["payments"]}`, after: `{"fallback_teams":["data"]}`,
+		},
+		{
+			operation: "team.setDefaultCapacity", path: "/team/setDefaultCapacity",
+			body:       `{"team_name":"backend","default_max_open_reviews":5}`,
+			targetType: api.AuditRecordTargetTypeTeam, targetId: "backend",
+			before: `{"default_max_open_reviews":null}`, after: `{"default_max_open_reviews":5}`,
+		},
+		{
+			operation: "team.setReviewSla", path: "/team/setReviewSla",
+			body:       `{"team_name":"backend","review_sla":"24h","escalation":"event"}`,
+			targetType: api.AuditRecordTargetTypeTeam, targetId: "backend",
+			before: `{"team_name":"backend"}`, after: `{"review_sla":{"sla":"24h0m0s","escalation":"event","lead_user_id":null}}`,
+		},
+		{
+			operation: "team.setCodeowners", path: "/team/setCodeowners",
+			body:       `{"team_name":"backend","codeowners":"*.go @u2\n"}`,
+			targetType: api.AuditRecordTargetTypeTeam, targetId: "backend",
+			before: `{"rules":[]}`, after: `{"rules":[{"pattern":"*.go","owners":["u2"]}]}`,
+		},
+		{
+			operation: "users.setIsActive", path: "/users/setIsActive",
+			body:       `{"user_id":"u2","is_active":false}`,
+			targetType: api.AuditRecordTargetTypeUser, targetId: "u2",
+			before: `{"is_active":true}`, after: `{"is_active":false}`,
+		},
+		{
+			operation: "users.setCapacity", path: "/users/setCapacity",
+			body:       `{"user_id":"u2","max_open_reviews":3}`,
+			targetType: api.AuditRecordTargetTypeUser, targetId: "u2",
+			before: `{"max_open_reviews":null}`, after: `{"max_open_reviews":3}`,
+		},
+		{
+			operation: "users.setDigest", path: "/users/setDigest",
+			body:       `{"user_id":"u2","enabled":false}`,
+			targetType: api.AuditRecordTargetTypeUser, targetId: "u2",
+			before: `{"enabled":true}`, after: `{"enabled":false}`,
+		},
+		{
+			operation: "users.addAbsence", path: "/users/addAbsence",
+			body:       `{"user_id":"u2","starts_at":"2099-02-01T00:00:00Z","ends_at":"2099-02-02T00:00:00Z"}`,
+			targetType: api.AuditRecordTargetTypeAbsence, targetId: "2",
+			after: `{"user_id":"u2","starts_at":"2099-02-01T00:00:00Z"}`,
+		},
+		{
+			operation: "users.removeAbsence", path: "/users/removeAbsence",
+			body:       `{"absence_id":1}`,
+			targetType: api.AuditRecordTargetTypeAbsence, targetId: "1",
+			before: `{"user_id":"u4","starts_at":"2099-01-01T00:00:00Z"}`,
+		},
+		{
+			operation: "roster.import", path: "/roster/import",
+			body:       `{"teams":[{"team_name":"backend","members":[{"user_id":"u1","username":"Alice"},{"user_id":"u2","username":"Robert"},{"user_id":"u3","username":"Carol"}]}]}`,
+			targetType: api.AuditRecordTargetTypeUser, targetId: "u2",
+			before: `{"username":"Bob"}`, after: `{"username":"Robert"}`,
+		},
+		{
+			operation: "pullRequest.create", path: "/pullRequest/create",
+			body:       `{"pull_request_id":"pr-audit","pull_request_name":"Audit","author_id":"u11"}`,
+			targetType: api.AuditRecordTargetTypePullRequest, targetId: "pr-audit",
+			after: `{"status":"OPEN","author_id":"u11"}`,
+		},
+		{
+			operation: "pullRequest.merge", path: "/pullRequest/merge",
+			body:       `{"pull_request_id":"pr-1001"}`,
+			targetType: api.AuditRecordTargetTypePullRequest, targetId: "pr-1001",
+			before: `{"status":"OPEN","mergedAt":null}`, after: `{"status":"MERGED"}`,
+		},
+		{
+			operation: "pullRequest.reassign", path: "/pullRequest/reassign",
+			body:       `{"pull_request_id":"pr-1002","old_user_id":"u2","new_user_id":"u5"}`,
+			targetType: api.AuditRecordTargetTypePullRequest, targetId: "pr-1002",
+			before: `{"assigned_reviewers":["u2"]}`, after: `{"assigned_reviewers":["u5"]}`,
+		},
+		{
+			operation: "pullRequest.addReviewer", path: "/pullRequest/addReviewer",
+			body:       `{"pull_request_id":"pr-1002","user_id":"u3"}`,
+			targetType: api.AuditRecordTargetTypePullRequest, targetId: "pr-1002",
+			before: `{"assigned_reviewers":["u2"]}`, after: `{"assigned_reviewers":["u2","u3"]}`,
+		},
+		{
+			operation: "pullRequest.removeReviewer", path: "/pullRequest/removeReviewer",
+			body:       `{"pull_request_id":"pr-1002","user_id":"u2"}`,
+			targetType: api.AuditRecordTargetTypePullRequest, targetId: "pr-1002",
+			before: `{"assigned_reviewers":["u2"]}`, after: `{"assigned_reviewers":[]}`,
+		},
+	} {
+		t.Run(tc.operation, func(t *testing.T) {
+			s := newServer(t, open(t), handler.Options{})
+
+			rec := s.send(t, http.MethodPost, tc.path, http.Header{"X-Actor-Id": {"auditor"}}, json.RawMessage(tc.body))
+			if rec.Code >= 300 {
+				t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+			}
+
+			records := auditRecords(t, s, url.Values{
+				"operation":   {tc.operation},
+				"target_type": {string(tc.targetType)},
+				"target_id":   {tc.targetId},
+			})
+			if len(records) == 0 {
+				t.Fatal("expected a record, got none")
+			}
+
+			// Records come newest first, the seed may have touched the target before
+			r := records[0]
+			if r.Actor == nil || *r.Actor != "auditor" {
+				t.Errorf("expected the actor of the request, got %v", r.Actor)
+			}
+			if since := time.Since(r.At); since < 0 || since > time.Minute {
+				t.Errorf("expected the record made just now, got %v", r.At)
+			}
+			expectSnapshot(t, "before", r.Before, tc.before)
+			expectSnapshot(t, "after", r.After, tc.after)
+		})
+	}
+
+	t.Run("no actor", func(t *testing.T) {
+		s := newServer(t, open(t), handler.Options{})
+
+		if status, body := s.do(t, http.MethodPost, "/users/setIsActive", nil, json.RawMessage(`{"user_id":"u2","is_active":false}`)); status != http.StatusOK {
+			t.Fatalf("unexpected status %d: %s", status, body)
+		}
+
+		records := auditRecords(t, s, url.Values{"operation": {"users.setIsActive"}, "target_id": {"u2"}})
+		if len(records) != 1 || records[0].Actor != nil {
+			t.Errorf("expected 1 record without actor, got %+v", records)
+		}
+	})
+}
+
+// TestAuditRetention puts records of two and three days ago next to fresh ones and keeps a day of them
+func TestAuditRetention(t *testing.T) {
+	dbtest.Run(t, testAuditRetention)
+}
+
+func testAuditRetention(t *testing.T, open dbtest.Open) {
+	store := open(t)
+	s := newServer(t, store, handler.Options{})
+
+	fresh := len(auditRecords(t, s, url.Values{"limit": {"100"}}))
+	for _, age := range []time.Duration{48 * time.Hour, 72 * time.Hour} {
+		_, err := store.Exec(t.Context(), `
+			INSERT INTO audit_log(at, operation, target_type, target_id) VALUES($1, 'team.add', 'team', 'old')
+		`, time.Now().Add(-age).UTC())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.h.PruneAuditLog(t.Context(), 24*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	if old := auditRecords(t, s, url.Values{"target_id": {"old"}}); len(old) != 0 {
+		t.Errorf("expected old records deleted, got %+v", old)
+	}
+	if left := len(auditRecords(t, s, url.Values{"limit": {"100"}})); left != fresh {
+		t.Errorf("expected %d fresh records kept, got %d", fresh, left)
+	}
+}
+
+// TestAuditAppendOnly checks that the database itself rejects changing audit records and deleting them
+// other than by retention
+func TestAuditAppendOnly(t *testing.T) {
+	dbtest.Run(t, testAuditAppendOnly)
+}
+
+func testAuditAppendOnly(t *testing.T, open dbtest.Open) {
+	store := open(t)
+	s := newServer(t, store, handler.Options{})
+
+	records := auditRecords(t, s, url.Values{"limit": {"100"}})
+
+	for _, tc := range []struct {
+		name  string
+		query string
+	}{
+		{"update", `UPDATE audit_log SET actor='intruder'`},
+		{"delete", `DELETE FROM audit_log`},
+		{"delete one", `DELETE FROM audit_log WHERE audit_id=1`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := store.Exec(t.Context(), tc.query); err == nil {
+				t.Errorf("expected %q to be rejected", tc.query)
+			}
+		})
+	}
+
+	// A cutoff lets retention delete records older than it and nothing else
+	t.Run("delete newer than cutoff", func(t *testing.T) {
+		tx, err := store.Begin(t.Context())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tx.Rollback(t.Context())
+
+		if _, err := tx.Exec(t.Context(), `INSERT INTO audit_log_pruning(cutoff) VALUES($1)`, time.Now().Add(-time.Hour).UTC()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tx.Exec(t.Context(), `DELETE FROM audit_log`); err == nil {
+			t.Error("expected deleting fresh records to be rejected")
+		}
+	})
+
+	after := auditRecords(t, s, url.Values{"limit": {"100"}})
+	if !reflect.DeepEqual(after, records) {
+		t.Errorf("audit log has changed:\nbefore %+v\nafter  %+v", records, after)
+	}
+}
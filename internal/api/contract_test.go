@@ -0,0 +1,326 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/events"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/validator"
+)
+
+// seed is the world every example in openapi.yml is written against:
+//
+//   - backend (u1 Alice, u2 Bob, u3 Carol, inactive u6 Frank) falls back to payments (u4 Dave, u5 Eve)
+//   - data (u11 Kate, u12 Leo, u13 Mia) has nobody left to reassign to
+//   - infra (u9 Ivan, u10 Judy, u15 Oscar) has everyone at capacity
+//   - pr-1000 is merged, pr-1001 is reviewed by u2 and u3, pr-1002 only by u2
+//   - absence 1 belongs to u4 and is far in the future
+var seed = []struct {
+	path string
+	body string
+}{
+	{"/team/add", `{"team_name":"payments","members":[
+		{"user_id":"u4","username":"Dave","is_active":true},
+		{"user_id":"u5","username":"Eve","is_active":true}]}`},
+	{"/team/add", `{"team_name":"backend","fallback_teams":["payments"],"members":[
+		{"user_id":"u1","username":"Alice","is_active":true},
+		{"user_id":"u2","username":"Bob","is_active":true},
+		{"user_id":"u3","username":"Carol","is_active":true},
+		{"user_id":"u6","username":"Frank","is_active":false}]}`},
+	{"/team/add", `{"team_name":"data","members":[
+		{"user_id":"u11","username":"Kate","is_active":true},
+		{"user_id":"u12","username":"Leo","is_active":true},
+		{"user_id":"u13","username":"Mia","is_active":true}]}`},
+	{"/team/add", `{"team_name":"infra","members":[
+		{"user_id":"u9","username":"Ivan","is_active":true},
+		{"user_id":"u10","username":"Judy","is_active":true},
+		{"user_id":"u15","username":"Oscar","is_active":true}]}`},
+	{"/pullRequest/create", `{"pull_request_id":"pr-1000","pull_request_name":"Init repository","author_id":"u1"}`},
+	{"/pullRequest/merge", `{"pull_request_id":"pr-1000"}`},
+	{"/pullRequest/create", `{"pull_request_id":"pr-1001","pull_request_name":"Add search","author_id":"u1"}`},
+	{"/pullRequest/create", `{"pull_request_id":"pr-1002","pull_request_name":"Fix login redirect","author_id":"u1"}`},
+	{"/pullRequest/removeReviewer", `{"pull_request_id":"pr-1002","user_id":"u3"}`},
+	{"/pullRequest/create", `{"pull_request_id":"pr-1003","pull_request_name":"Load events","author_id":"u11"}`},
+	{"/pullRequest/create", `{"pull_request_id":"pr-1004","pull_request_name":"Bump base image","author_id":"u9"}`},
+	{"/pullRequest/removeReviewer", `{"pull_request_id":"pr-1004","user_id":"u15"}`},
+	{"/team/setDefaultCapacity", `{"team_name":"infra","default_max_open_reviews":0}`},
+	{"/users/addAbsence", `{"user_id":"u4","starts_at":"2099-01-01T00:00:00Z","ends_at":"2099-01-15T00:00:00Z"}`},
+}
+
+// contractCase is an example request together with the response the spec promises for it
+type contractCase struct {
+	name   string
+	method string
+	path   string
+	query  url.Values
+	body   any
+	status int
+	code   string // error code, empty for successful responses
+}
+
+// TestContract replays every example request from openapi.yml against a freshly seeded store. A named
+// request example expects the error response example with the same name, any other example expects
+// the successful response. Response schemas are checked by the validator middleware.
+//
+// The suite runs against every backend of dbtest.
+func TestContract(t *testing.T) {
+	dbtest.Run(t, testContract)
+}
+
+func testContract(t *testing.T, open dbtest.Open) {
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases, err := contractCases(swagger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := newServer(t, open(t), handler.Options{})
+
+			status, body := server.do(t, c.method, c.path, c.query, c.body)
+			if status != c.status {
+				t.Fatalf("expected status %d, got %d: %s", c.status, status, body)
+			}
+
+			if c.code != "" {
+				var resp api.ErrorResponse
+				if err := json.Unmarshal(body, &resp); err != nil {
+					t.Fatalf("failed to decode error response: %v", err)
+				}
+				if string(resp.Error.Code) != c.code {
+					t.Fatalf("expected error code %s, got %s: %s", c.code, resp.Error.Code, resp.Error.Message)
+				}
+			}
+		})
+	}
+}
+
+// contractCases collects example requests of all operations in a stable order
+func contractCases(swagger *openapi3.T) ([]contractCase, error) {
+	paths := swagger.Paths.Map()
+	names := make([]string, 0, len(paths))
+	for path := range paths {
+		names = append(names, path)
+	}
+	sort.Strings(names)
+
+	cases := make([]contractCase, 0)
+	for _, path := range names {
+		for method, op := range paths[path].Operations() {
+			opCases, err := operationCases(method, path, op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			cases = append(cases, opCases...)
+		}
+	}
+	return cases, nil
+}
+
+func operationCases(method, path string, op *openapi3.Operation) ([]contractCase, error) {
+	success, errorExamples, err := documentedResponses(op)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	for _, param := range op.Parameters {
+		if param.Value.In != openapi3.ParameterInQuery || param.Value.Example == nil {
+			continue
+		}
+		query.Set(param.Value.Name, fmt.Sprint(param.Value.Example))
+	}
+
+	// Streams don't end, they can't be replayed as a single request
+	if op.Responses.Status(success).Value.Content.Get("application/json") == nil {
+		return nil, nil
+	}
+
+	base := contractCase{method: method, path: path, query: query, status: success}
+
+	if op.RequestBody == nil {
+		base.name = method + " " + path
+		return []contractCase{base}, nil
+	}
+
+	media := op.RequestBody.Value.Content.Get("application/json")
+	if media == nil {
+		return nil, fmt.Errorf("request body is not JSON")
+	}
+
+	if media.Example != nil {
+		base.name = method + " " + path
+		base.body = media.Example
+		return []contractCase{base}, nil
+	}
+
+	if len(media.Examples) == 0 {
+		return nil, fmt.Errorf("request body has no examples")
+	}
+
+	cases := make([]contractCase, 0, len(media.Examples))
+	for name, example := range media.Examples {
+		c := base
+		c.name = method + " " + path + "/" + name
+		c.body = example.Value.Value
+		if e, ok := errorExamples[name]; ok {
+			c.status = e.status
+			c.code = e.code
+			delete(errorExamples, name)
+		}
+		cases = append(cases, c)
+	}
+
+	// Error examples are documentation too, each of them must be reproducible by a request
+	for name := range errorExamples {
+		return nil, fmt.Errorf("response example %s has no request example with the same name", name)
+	}
+
+	sort.Slice(cases, func(i, j int) bool { return cases[i].name < cases[j].name })
+
+	return cases, nil
+}
+
+type errorExample struct {
+	status int
+	code   string
+}
+
+// documentedResponses returns the successful status and named error examples of the operation
+func documentedResponses(op *openapi3.Operation) (int, map[string]errorExample, error) {
+	success := 0
+	errorExamples := make(map[string]errorExample)
+
+	for code, resp := range op.Responses.Map() {
+		if code == "default" {
+			continue
+		}
+		status, err := strconv.Atoi(code)
+		if err != nil {
+			return 0, nil, fmt.Errorf("unexpected response code %s", code)
+		}
+
+		if status < 300 {
+			if success != 0 {
+				return 0, nil, fmt.Errorf("more than one successful response")
+			}
+			success = status
+			continue
+		}
+
+		media := resp.Value.Content.Get("application/json")
+		if media == nil {
+			continue
+		}
+		for name, example := range media.Examples {
+			var value api.ErrorResponse
+			raw, err := json.Marshal(example.Value.Value)
+			if err != nil {
+				return 0, nil, err
+			}
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return 0, nil, fmt.Errorf("response example %s is not an error: %w", name, err)
+			}
+			errorExamples[name] = errorExample{status: status, code: string(value.Error.Code)}
+		}
+	}
+
+	if success == 0 {
+		return 0, nil, fmt.Errorf("no successful response")
+	}
+
+	return success, errorExamples, nil
+}
+
+type server struct {
+	handler http.Handler
+	// h runs background jobs of the service
+	h *handler.Handler
+}
+
+// newServer boots the API over the store and fills it with seed
+func newServer(t *testing.T, store *db.DB, opts handler.Options) *server {
+	t.Helper()
+
+	// Every call gets its own copy of the spec, the validator adjusts it in place
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	validate, err := validator.Middleware(swagger, validator.Options{ValidateResponses: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := handler.NewHandler(store, events.NewBroker(store), opts)
+
+	router := chi.NewRouter()
+	router.Use(validate)
+	router.Mount("/", api.Handler(h))
+
+	s := &server{handler: router, h: h}
+	for _, step := range seed {
+		status, body := s.do(t, http.MethodPost, step.path, nil, json.RawMessage(step.body))
+		if status >= 300 {
+			t.Fatalf("seed %s failed with %d: %s", step.path, status, body)
+		}
+	}
+
+	return s
+}
+
+func (s *server) do(t *testing.T, method, path string, query url.Values, body any) (int, []byte) {
+	t.Helper()
+
+	target := path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	rec := s.send(t, method, target, nil, body)
+	return rec.Code, rec.Body.Bytes()
+}
+
+// send is do with request headers, it returns the whole response
+func (s *server) send(t *testing.T, method, target string, header http.Header, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest(method, target, &reqBody)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+
+	return rec
+}
@@ -0,0 +1,289 @@
+package api_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/notify"
+)
+
+// inbox collects what the stand-ins of notification services receive
+type inbox struct {
+	mu    sync.Mutex
+	items []string
+	fail  bool
+}
+
+func (i *inbox) add(item string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.fail {
+		return false
+	}
+	i.items = append(i.items, item)
+	return true
+}
+
+func (i *inbox) setFail(fail bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.fail = fail
+}
+
+func (i *inbox) received() []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]string(nil), i.items...)
+}
+
+// webhookStandIn stores request bodies, or responds with 503 while the inbox fails
+func webhookStandIn(t *testing.T) (string, *inbox) {
+	t.Helper()
+
+	in := &inbox{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !in.add(string(body)) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL, in
+}
+
+// smtpStandIn speaks just enough SMTP for net/smtp and stores "recipient\nmessage" of every mail
+func smtpStandIn(t *testing.T) (string, *inbox) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	in := &inbox{}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				fmt.Fprint(conn, "220 localhost\r\n")
+				var rcpt string
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+					case strings.HasPrefix(cmd, "RCPT TO:"):
+						rcpt = strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>")
+						fmt.Fprint(conn, "250 OK\r\n")
+					case cmd == "DATA":
+						fmt.Fprint(conn, "354 go ahead\r\n")
+						var msg strings.Builder
+						for {
+							line, err := r.ReadString('\n')
+							if err != nil || line == ".\r\n" {
+								break
+							}
+							msg.WriteString(line)
+						}
+						in.add(rcpt + "\n" + msg.String())
+						fmt.Fprint(conn, "250 OK\r\n")
+					case cmd == "QUIT":
+						fmt.Fprint(conn, "221 bye\r\n")
+						return
+					default:
+						fmt.Fprint(conn, "250 OK\r\n")
+					}
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String(), in
+}
+
+func setDigest(t *testing.T, s *server, body string) {
+	t.Helper()
+
+	if status, resp := s.do(t, http.MethodPost, "/users/setDigest", nil, json.RawMessage(body)); status != http.StatusOK {
+		t.Fatalf("set digest: expected status 200, got %d: %s", status, resp)
+	}
+}
+
+func getDigest(t *testing.T, s *server, userId string) api.DigestSettings {
+	t.Helper()
+
+	status, body := s.do(t, http.MethodGet, "/users/getDigest", url.Values{"user_id": {userId}}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("get digest: expected status 200, got %d: %s", status, body)
+	}
+
+	var resp struct {
+		Digest api.DigestSettings `json:"digest"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp.Digest
+}
+
+// noonZone returns a fixed-offset zone where it is noon or early afternoon right now
+func noonZone(now time.Time) string {
+	offset := 12 - now.UTC().Hour()
+	switch {
+	case offset == 0:
+		return "Etc/GMT"
+	case offset > 0:
+		// Signs of Etc/GMT zones are inverted
+		return fmt.Sprintf("Etc/GMT-%d", offset)
+	default:
+		return fmt.Sprintf("Etc/GMT+%d", -offset)
+	}
+}
+
+// TestDigests sends digests of seed reviewers with open reviews. Bob has two of them and an email, Carol has
+// opted out, Leo's digest is past its time in his zone and Mia's is not yet.
+func TestDigests(t *testing.T) {
+	dbtest.Run(t, testDigests)
+}
+
+func testDigests(t *testing.T, open dbtest.Open) {
+	webhookURL, webhook := webhookStandIn(t)
+	slackURL, slack := webhookStandIn(t)
+	smtpAddr, mailbox := smtpStandIn(t)
+
+	s := newServer(t, open(t), handler.Options{Notifiers: []notify.Notifier{
+		notify.NewWebhook(webhookURL),
+		notify.NewSlack(slackURL),
+		notify.NewSMTP(smtpAddr, "reviews@example.com", "", ""),
+	}})
+
+	zone := noonZone(time.Now())
+	setDigest(t, s, `{"user_id":"u2","timezone":"UTC","time":"00:00","email":"bob@example.com"}`)
+	setDigest(t, s, `{"user_id":"u3","time":"00:00","enabled":false}`)
+	setDigest(t, s, `{"user_id":"u10","time":"0:00"}`)
+	setDigest(t, s, fmt.Sprintf(`{"user_id":"u12","timezone":%q,"time":"11:30"}`, zone))
+	setDigest(t, s, fmt.Sprintf(`{"user_id":"u13","timezone":%q,"time":"13:30"}`, zone))
+
+	// The second run must not send the same digests again
+	for range 2 {
+		if err := s.h.SendDigests(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sent := make(map[string]notify.Digest)
+	for _, body := range webhook.received() {
+		var d notify.Digest
+		if err := json.Unmarshal([]byte(body), &d); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := sent[d.UserId]; ok {
+			t.Errorf("digest of %s is sent twice", d.UserId)
+		}
+		sent[d.UserId] = d
+	}
+	if len(sent) != 3 || sent["u2"].UserId == "" || sent["u10"].UserId == "" || sent["u12"].UserId == "" {
+		t.Fatalf("expected digests of u2, u10 and u12, got %+v", sent)
+	}
+
+	bob := sent["u2"]
+	if len(bob.Reviews) != 2 || bob.Reviews[0].PullRequestId != "pr-1001" || bob.Reviews[1].PullRequestId != "pr-1002" {
+		t.Errorf("unexpected reviews of u2: %+v", bob.Reviews)
+	}
+	if want := time.Now().UTC().Format(time.DateOnly); bob.Date != want || bob.Email != "bob@example.com" {
+		t.Errorf("unexpected digest of u2: %+v", bob)
+	}
+
+	if messages := slack.received(); len(messages) != 3 || !strings.Contains(strings.Join(messages, ""), "pr-1002") {
+		t.Errorf("unexpected Slack messages %v", messages)
+	}
+
+	mails := mailbox.received()
+	if len(mails) != 1 || !strings.HasPrefix(mails[0], "bob@example.com\n") || !strings.Contains(mails[0], "pr-1001") {
+		t.Errorf("expected one mail to Bob, got %q", mails)
+	}
+
+	if got := getDigest(t, s, "u2").LastSentOn; got == nil || *got != bob.Date {
+		t.Errorf("unexpected last_sent_on of u2: %v", got)
+	}
+	if got := getDigest(t, s, "u13").LastSentOn; got != nil {
+		t.Errorf("u13 got a digest before its time on %s", *got)
+	}
+}
+
+// TestDigestWithoutEmail doesn't count a digest skipped by SMTP as delivered to a user without an address
+func TestDigestWithoutEmail(t *testing.T) {
+	dbtest.Run(t, testDigestWithoutEmail)
+}
+
+func testDigestWithoutEmail(t *testing.T, open dbtest.Open) {
+	smtpAddr, mailbox := smtpStandIn(t)
+	s := newServer(t, open(t), handler.Options{Notifiers: []notify.Notifier{
+		notify.NewSMTP(smtpAddr, "reviews@example.com", "", ""),
+	}})
+	setDigest(t, s, `{"user_id":"u2","time":"00:00"}`)
+
+	if err := s.h.SendDigests(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if got := getDigest(t, s, "u2").LastSentOn; got != nil || len(mailbox.received()) != 0 {
+		t.Fatalf("digest of a user without an email is marked sent on %v", got)
+	}
+
+	// Once the address is set, the digest of the day goes out
+	setDigest(t, s, `{"user_id":"u2","email":"bob@example.com"}`)
+	if err := s.h.SendDigests(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if getDigest(t, s, "u2").LastSentOn == nil || len(mailbox.received()) != 1 {
+		t.Fatalf("expected one mail after the email is set, got %q", mailbox.received())
+	}
+}
+
+// TestDigestRetry keeps the digest until some notifier delivers it
+func TestDigestRetry(t *testing.T) {
+	dbtest.Run(t, testDigestRetry)
+}
+
+func testDigestRetry(t *testing.T, open dbtest.Open) {
+	webhookURL, webhook := webhookStandIn(t)
+	s := newServer(t, open(t), handler.Options{Notifiers: []notify.Notifier{notify.NewWebhook(webhookURL)}})
+	setDigest(t, s, `{"user_id":"u2","time":"00:00"}`)
+
+	webhook.setFail(true)
+	if err := s.h.SendDigests(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if got := getDigest(t, s, "u2").LastSentOn; got != nil {
+		t.Fatalf("undelivered digest is marked sent on %s", *got)
+	}
+
+	webhook.setFail(false)
+	if err := s.h.SendDigests(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if getDigest(t, s, "u2").LastSentOn == nil {
+		t.Fatal("digest is not sent after the webhook recovered")
+	}
+}
@@ -0,0 +1,128 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+// etagOf returns the current ETag of the PR
+func etagOf(t *testing.T, s *server, prId string) string {
+	t.Helper()
+
+	rec := s.send(t, http.MethodGet, "/pullRequest/get?pull_request_id="+prId, nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("get %s: expected 200, got %d: %s", prId, rec.Code, rec.Body)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatalf("get %s: no ETag", prId)
+	}
+	return etag
+}
+
+// TestETagChanges checks that every change of the PR gives it a new ETag, the one the change responds with
+func TestETagChanges(t *testing.T) {
+	dbtest.Run(t, testETagChanges)
+}
+
+func testETagChanges(t *testing.T, open dbtest.Open) {
+	s := newServer(t, open(t), handler.Options{})
+
+	etag := etagOf(t, s, "pr-1001")
+	for _, step := range []struct {
+		name string
+		path string
+		body string
+	}{
+		{"reassign", "/pullRequest/reassign", `{"pull_request_id":"pr-1001","old_user_id":"u2"}`},
+		{"remove", "/pullRequest/removeReviewer", `{"pull_request_id":"pr-1001","user_id":"u3"}`},
+		{"add", "/pullRequest/addReviewer", `{"pull_request_id":"pr-1001","user_id":"u3"}`},
+		{"merge", "/pullRequest/merge", `{"pull_request_id":"pr-1001"}`},
+	} {
+		rec := s.send(t, http.MethodPost, step.path, http.Header{"If-Match": {etag}}, json.RawMessage(step.body))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", step.name, rec.Code, rec.Body)
+		}
+
+		next := rec.Header().Get("ETag")
+		if next == "" || next == etag {
+			t.Errorf("%s: expected a new ETag after %s, got %q", step.name, etag, next)
+		}
+		if current := etagOf(t, s, "pr-1001"); current != next {
+			t.Errorf("%s: responded with ETag %s, but the PR has %s", step.name, next, current)
+		}
+		etag = next
+	}
+}
+
+// TestIfMatch checks which If-Match headers let a change through and that the rejected ones leave the PR as it was
+func TestIfMatch(t *testing.T) {
+	dbtest.Run(t, testIfMatch)
+}
+
+func testIfMatch(t *testing.T, open dbtest.Open) {
+	const stale = `"999"`
+
+	for _, tc := range []struct {
+		name    string
+		ifMatch func(current string) string
+		ok      bool
+	}{
+		{"current", func(current string) string { return current }, true},
+		{"any", func(string) string { return "*" }, true},
+		{"weak current", func(current string) string { return "W/" + current }, true},
+		{"list with current", func(current string) string { return stale + ", " + current }, true},
+		{"stale", func(string) string { return stale }, false},
+		{"weak stale", func(string) string { return "W/" + stale }, false},
+		{"unquoted current", func(current string) string { return current[1 : len(current)-1] }, false},
+	} {
+		for _, op := range []struct {
+			name string
+			path string
+			body string
+		}{
+			{"merge", "/pullRequest/merge", `{"pull_request_id":"pr-1001"}`},
+			{"reassign", "/pullRequest/reassign", `{"pull_request_id":"pr-1001","old_user_id":"u2"}`},
+			{"add", "/pullRequest/addReviewer", `{"pull_request_id":"pr-1002","user_id":"u3"}`},
+			{"remove", "/pullRequest/removeReviewer", `{"pull_request_id":"pr-1001","user_id":"u3"}`},
+		} {
+			t.Run(tc.name+"/"+op.name, func(t *testing.T) {
+				s := newServer(t, open(t), handler.Options{})
+
+				var target struct {
+					PullRequestId string `json:"pull_request_id"`
+				}
+				if err := json.Unmarshal([]byte(op.body), &target); err != nil {
+					t.Fatal(err)
+				}
+				before := etagOf(t, s, target.PullRequestId)
+
+				header := http.Header{"If-Match": {tc.ifMatch(before)}}
+				rec := s.send(t, http.MethodPost, op.path, header, json.RawMessage(op.body))
+
+				if tc.ok {
+					if rec.Code != http.StatusOK {
+						t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+					}
+					return
+				}
+
+				var resp api.ErrorResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				if rec.Code != http.StatusPreconditionFailed || resp.Error.Code != api.PRVERSIONMISMATCH {
+					t.Fatalf("expected 412 %s, got %d %s", api.PRVERSIONMISMATCH, rec.Code, resp.Error.Code)
+				}
+				if after := etagOf(t, s, target.PullRequestId); after != before {
+					t.Errorf("rejected change moved the PR from %s to %s", before, after)
+				}
+			})
+		}
+	}
+}
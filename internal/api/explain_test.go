@@ -0,0 +1,185 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+// explained sends a create or reassign request with explain=true
+func explained(t *testing.T, s *server, path, body string) (api.PullRequest, api.AssignmentExplanation) {
+	t.Helper()
+
+	status, resp := s.do(t, http.MethodPost, path, url.Values{"explain": {"true"}}, json.RawMessage(body))
+	if status >= 300 {
+		t.Fatalf("%s: unexpected status %d: %s", path, status, resp)
+	}
+
+	var result struct {
+		Pr          api.PullRequest           `json:"pr"`
+		Explanation api.AssignmentExplanation `json:"explanation"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		t.Fatal(err)
+	}
+	return result.Pr, result.Explanation
+}
+
+// checkRanks checks that eligible candidates go first with ranks from 1, the picked ones ahead of the rest,
+// and returns every candidate by user id
+func checkRanks(t *testing.T, e api.AssignmentExplanation, picked []string) map[string]api.CandidateExplanation {
+	t.Helper()
+
+	byUser := make(map[string]api.CandidateExplanation, len(e.Candidates))
+	var pickedIds []string
+	for i, c := range e.Candidates {
+		byUser[c.UserId] = c
+		if !c.Eligible {
+			if c.Reason == nil || c.Rank != nil || c.Picked {
+				t.Errorf("%s is not eligible, expected a reason only: %+v", c.UserId, c)
+			}
+			continue
+		}
+		if i > 0 && !e.Candidates[i-1].Eligible {
+			t.Errorf("eligible %s goes after ineligible %s", c.UserId, e.Candidates[i-1].UserId)
+		}
+		if c.Rank == nil || *c.Rank != i+1 || c.Reason != nil || c.Score == nil {
+			t.Errorf("expected %s to have rank %d and a score, got %+v", c.UserId, i+1, c)
+		}
+		if c.Picked {
+			if len(pickedIds) != i {
+				t.Errorf("picked %s is ranked after a candidate who was not picked", c.UserId)
+			}
+			pickedIds = append(pickedIds, c.UserId)
+		}
+	}
+
+	slices.Sort(pickedIds)
+	picked = slices.Sorted(slices.Values(picked))
+	if !slices.Equal(pickedIds, picked) {
+		t.Errorf("expected %v to be picked, explanation picks %v", picked, pickedIds)
+	}
+
+	return byUser
+}
+
+func expectReasons(t *testing.T, byUser map[string]api.CandidateExplanation, reasons map[string]api.CandidateExplanationReason) {
+	t.Helper()
+
+	for userId, reason := range reasons {
+		c, ok := byUser[userId]
+		if !ok || c.Reason == nil || *c.Reason != reason {
+			t.Errorf("expected %s to be left out as %s, got %+v", userId, reason, c)
+		}
+	}
+}
+
+// TestExplanation explains assignments over the seed, where Frank of backend is inactive
+func TestExplanation(t *testing.T) {
+	dbtest.Run(t, testExplanation)
+}
+
+func testExplanation(t *testing.T, open dbtest.Open) {
+	mustPost := func(t *testing.T, s *server, path, body string) {
+		t.Helper()
+		if status, resp := s.do(t, http.MethodPost, path, nil, json.RawMessage(body)); status >= 300 {
+			t.Fatalf("%s: unexpected status %d: %s", path, status, resp)
+		}
+	}
+
+	t.Run("gives the reason of everyone left out", func(t *testing.T) {
+		s := newServer(t, open(t), handler.Options{})
+
+		// Carol has her one review of pr-1001 and Eve is away, Dave of payments is the only one to fall back to
+		mustPost(t, s, "/users/setCapacity", `{"user_id":"u3","max_open_reviews":1}`)
+		absence, err := json.Marshal(api.PostUsersAddAbsenceJSONBody{
+			UserId:   "u5",
+			StartsAt: time.Now().Add(-time.Hour).UTC(),
+			EndsAt:   time.Now().Add(24 * time.Hour).UTC(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		mustPost(t, s, "/users/addAbsence", string(absence))
+
+		pr, explanation := explained(t, s, "/pullRequest/create",
+			`{"pull_request_id":"pr-explain","pull_request_name":"Explain","author_id":"u1"}`)
+
+		if explanation.Selection != api.AssignmentExplanationSelection(handler.SelectionRandom) {
+			t.Errorf("expected random selection, got %s", explanation.Selection)
+		}
+		byUser := checkRanks(t, explanation, pr.AssignedReviewers)
+		if bob, dave := byUser["u2"], byUser["u4"]; *bob.Pool != api.CandidateExplanationPoolTeam || *dave.Pool != api.CandidateExplanationPoolFallback {
+			t.Errorf("expected Bob from the team and Dave from payments, got %+v and %+v", bob, dave)
+		}
+		expectReasons(t, byUser, map[string]api.CandidateExplanationReason{
+			"u1": api.Author,
+			"u3": api.AtCapacity,
+			"u5": api.Away,
+			"u6": api.Inactive,
+		})
+		if len(byUser) != 6 {
+			t.Errorf("expected members of backend and payments only, got %+v", explanation.Candidates)
+		}
+	})
+
+	t.Run("ranks candidates left after the reviewers were picked", func(t *testing.T) {
+		s := newServer(t, open(t), handler.Options{})
+
+		// Grace joins backend, three active teammates compete for two places and payments isn't needed
+		mustPost(t, s, "/roster/import", `{"teams":[{"team_name":"backend","members":[
+			{"user_id":"u1","username":"Alice"},
+			{"user_id":"u2","username":"Bob"},
+			{"user_id":"u3","username":"Carol"},
+			{"user_id":"u6","username":"Frank","is_active":false},
+			{"user_id":"u7","username":"Grace"}]}]}`)
+
+		pr, explanation := explained(t, s, "/pullRequest/create",
+			`{"pull_request_id":"pr-explain","pull_request_name":"Explain","author_id":"u1"}`)
+
+		byUser := checkRanks(t, explanation, pr.AssignedReviewers)
+		eligible := 0
+		for _, userId := range []string{"u2", "u3", "u7"} {
+			if c := byUser[userId]; c.Eligible {
+				eligible++
+				if *c.Score != 1 || *c.Pool != api.CandidateExplanationPoolTeam {
+					t.Errorf("expected %s from the team with score 1 under random selection, got %+v", userId, c)
+				}
+			}
+		}
+		if eligible != 3 {
+			t.Errorf("expected Bob, Carol and Grace to be ranked, got %+v", explanation.Candidates)
+		}
+		expectReasons(t, byUser, map[string]api.CandidateExplanationReason{"u1": api.Author, "u6": api.Inactive})
+
+		// The one left is the only replacement, the other reviewer is assigned already
+		var left string
+		for _, c := range explanation.Candidates {
+			if c.Eligible && !c.Picked {
+				left = c.UserId
+			}
+		}
+		old, other := pr.AssignedReviewers[0], pr.AssignedReviewers[1]
+
+		pr, explanation = explained(t, s, "/pullRequest/reassign",
+			`{"pull_request_id":"pr-explain","old_user_id":"`+old+`"}`)
+
+		byUser = checkRanks(t, explanation, []string{left})
+		if !slices.Contains(pr.AssignedReviewers, left) {
+			t.Errorf("expected %s to replace %s, got %v", left, old, pr.AssignedReviewers)
+		}
+		expectReasons(t, byUser, map[string]api.CandidateExplanationReason{
+			"u1":  api.Author,
+			old:   api.AlreadyAssigned,
+			other: api.AlreadyAssigned,
+			"u6":  api.Inactive,
+		})
+	})
+}
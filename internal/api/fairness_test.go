@@ -0,0 +1,149 @@
+package api_test
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+type pairingsResponse struct {
+	TeamName    string               `json:"team_name"`
+	WindowStart time.Time            `json:"window_start"`
+	Authors     []api.AuthorPairings `json:"authors"`
+}
+
+func pairings(t *testing.T, s *server, teamName string) pairingsResponse {
+	t.Helper()
+
+	status, body := s.do(t, http.MethodGet, "/stats/pairings", url.Values{"team_name": {teamName}}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("pairings: expected status 200, got %d: %s", status, body)
+	}
+
+	var resp pairingsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// TestStatsPairings looks at reviews of Alice from the seed: Bob reviewed all her three PRs and Carol two,
+// being removed from pr-1002. Their weights fade over the ten days window while the counts stay.
+func TestStatsPairings(t *testing.T) {
+	dbtest.Run(t, testStatsPairings)
+}
+
+func testStatsPairings(t *testing.T, open dbtest.Open) {
+	window := 10 * 24 * time.Hour
+	store := open(t)
+	s := newServer(t, store, handler.Options{FairnessWindow: window})
+
+	for _, tc := range []struct {
+		age     time.Duration
+		weights map[string]float64
+	}{
+		{0, map[string]float64{"u2": 3, "u3": 2}},
+		{window / 4, map[string]float64{"u2": 2.25, "u3": 1.5}},
+		{window, map[string]float64{"u2": 0, "u3": 0}},
+	} {
+		// The PRs are aged by moving their creation back
+		if _, err := store.Exec(t.Context(), `UPDATE prs SET created_at=$1`, time.Now().UTC().Add(-tc.age)); err != nil {
+			t.Fatal(err)
+		}
+		resp := pairings(t, s, "backend")
+
+		if d := time.Now().Add(-window).Sub(resp.WindowStart); d < 0 || d > time.Minute {
+			t.Errorf("age %v: expected the window to start %v ago, got %v", tc.age, window, resp.WindowStart)
+		}
+
+		authors := make([]string, 0, len(resp.Authors))
+		for _, a := range resp.Authors {
+			authors = append(authors, a.AuthorId)
+			if a.AuthorId != "u1" && len(a.Reviewers) != 0 {
+				t.Errorf("age %v: %s authored nothing, got %+v", tc.age, a.AuthorId, a.Reviewers)
+			}
+		}
+		if len(authors) != 4 || authors[0] != "u1" || authors[3] != "u6" {
+			t.Errorf("age %v: expected every member of backend by user_id, got %v", tc.age, authors)
+		}
+
+		reviewers := resp.Authors[0].Reviewers
+		if len(reviewers) != 2 || reviewers[0].ReviewerId != "u2" || reviewers[1].ReviewerId != "u3" {
+			t.Fatalf("age %v: expected Bob before Carol, got %+v", tc.age, reviewers)
+		}
+		for _, p := range reviewers {
+			reviews := map[string]int{"u2": 3, "u3": 2}[p.ReviewerId]
+			if p.Reviews != reviews || math.Abs(p.Weight-tc.weights[p.ReviewerId]) > 0.001 {
+				t.Errorf("age %v: expected %d reviews weighing %v by %s, got %+v",
+					tc.age, reviews, tc.weights[p.ReviewerId], p.ReviewerId, p)
+			}
+		}
+	}
+
+	status, _ := s.do(t, http.MethodGet, "/stats/pairings", url.Values{"team_name": {"mobile"}}, nil)
+	if status != http.StatusNotFound {
+		t.Errorf("unknown team: expected status 404, got %d", status)
+	}
+}
+
+// TestFairSelectionSkipsRecentReviewers lets Kate of data author PRs with fair selection. Leo and Mia
+// reviewed her pr-1003, a new teammate is rather picked than them while the review is recent.
+func TestFairSelectionSkipsRecentReviewers(t *testing.T) {
+	dbtest.Run(t, testFairSelectionSkipsRecentReviewers)
+}
+
+func testFairSelectionSkipsRecentReviewers(t *testing.T, open dbtest.Open) {
+	clock := newClock()
+	s := newServer(t, open(t), handler.Options{
+		Clock:          clock.Now,
+		Selection:      handler.SelectionFair,
+		FairnessWindow: 10 * 24 * time.Hour,
+		Random:         handler.NewRandom(7),
+	})
+
+	// Nina joins data, pr-1003 weighs 1 for Leo and Mia, so Nina is twice as likely to be drawn as either
+	status, body := s.do(t, http.MethodPost, "/roster/import", nil, json.RawMessage(`{"teams":[{"team_name":"data","members":[
+		{"user_id":"u11","username":"Kate"},
+		{"user_id":"u12","username":"Leo"},
+		{"user_id":"u13","username":"Mia"},
+		{"user_id":"u14","username":"Nina"}]}]}`))
+	if status != http.StatusOK {
+		t.Fatalf("roster import failed with %d: %s", status, body)
+	}
+
+	const draws = 200
+	picked := 0
+	for range draws {
+		for _, userId := range simulate(t, s, `{"author_id":"u11"}`).PullRequests[0].AssignedReviewers {
+			if userId == "u14" {
+				picked++
+			}
+		}
+	}
+
+	// Nina is left out only if Leo and Mia are drawn first, that is in 1/6 of draws against 1/3 without pairings
+	if share := float64(picked) / draws; math.Abs(share-5.0/6) > 0.1 {
+		t.Errorf("expected Nina to be picked in 5/6 of dry runs, got %v", share)
+	}
+
+	// Once pr-1003 is out of the window, nobody is preferred
+	clock.Advance(10 * 24 * time.Hour)
+	picked = 0
+	for range draws {
+		for _, userId := range simulate(t, s, `{"author_id":"u11"}`).PullRequests[0].AssignedReviewers {
+			if userId == "u14" {
+				picked++
+			}
+		}
+	}
+	if share := float64(picked) / draws; math.Abs(share-2.0/3) > 0.1 {
+		t.Errorf("expected Nina to be picked in 2/3 of dry runs, got %v", share)
+	}
+}
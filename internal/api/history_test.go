@@ -0,0 +1,155 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+// TestHistory takes a PR through every change a minute apart and checks after each of them that the history
+// has grown by the right events, oldest first, with the actor of the request and the time of the change
+func TestHistory(t *testing.T) {
+	dbtest.Run(t, testHistory)
+}
+
+func testHistory(t *testing.T, open dbtest.Open) {
+	// Whole seconds survive the round trip through either database
+	clock := &clock{now: time.Now().Truncate(time.Second)}
+	s := newServer(t, open(t), handler.Options{Clock: clock.Now})
+
+	const actor = "u7"
+	post := func(path, body string) []byte {
+		t.Helper()
+		rec := s.send(t, http.MethodPost, path, http.Header{"X-Actor-Id": {actor}}, json.RawMessage(body))
+		if rec.Code >= 300 {
+			t.Fatalf("%s: unexpected status %d: %s", path, rec.Code, rec.Body)
+		}
+		return rec.Body.Bytes()
+	}
+	event := func(eventType api.PullRequestEventType, userId, replacedBy string) api.PullRequestEvent {
+		e := api.PullRequestEvent{Type: eventType, At: clock.Now(), Actor: ptr(actor)}
+		if userId != "" {
+			e.UserId = &userId
+		}
+		if replacedBy != "" {
+			e.ReplacedBy = &replacedBy
+		}
+		return e
+	}
+
+	var expected []api.PullRequestEvent
+	check := func(step string) {
+		t.Helper()
+
+		_, history := withHistory(t, s, "pr-history")
+		if len(history) != len(expected) {
+			t.Fatalf("%s: expected %d events, got %+v", step, len(expected), history)
+		}
+		for i, got := range history {
+			if i > 0 && got.EventId <= history[i-1].EventId {
+				t.Errorf("%s: event %d is out of order: %d after %d", step, i, got.EventId, history[i-1].EventId)
+			}
+			want := expected[i]
+			want.EventId = got.EventId
+			if !sameEvent(got, want) {
+				t.Errorf("%s: event %d: expected %s, got %s", step, i, describeEvent(want), describeEvent(got))
+			}
+		}
+	}
+
+	var created struct {
+		Pr api.PullRequest `json:"pr"`
+	}
+	if err := json.Unmarshal(post("/pullRequest/create", `{"pull_request_id":"pr-history","pull_request_name":"History","author_id":"u1"}`), &created); err != nil {
+		t.Fatal(err)
+	}
+	reviewers := created.Pr.AssignedReviewers
+	if len(reviewers) != 2 {
+		t.Fatalf("expected 2 reviewers, got %v", reviewers)
+	}
+	expected = append(expected,
+		event(api.PullRequestEventTypeCREATED, "", ""),
+		event(api.PullRequestEventTypeREVIEWERASSIGNED, reviewers[0], ""),
+		event(api.PullRequestEventTypeREVIEWERASSIGNED, reviewers[1], ""),
+	)
+	check("create")
+
+	clock.Advance(time.Minute)
+	var reassigned struct {
+		ReplacedBy string `json:"replaced_by"`
+	}
+	if err := json.Unmarshal(post("/pullRequest/reassign", `{"pull_request_id":"pr-history","old_user_id":"`+reviewers[0]+`"}`), &reassigned); err != nil {
+		t.Fatal(err)
+	}
+	expected = append(expected, event(api.PullRequestEventTypeREVIEWERREPLACED, reviewers[0], reassigned.ReplacedBy))
+	check("reassign")
+
+	clock.Advance(time.Minute)
+	post("/pullRequest/removeReviewer", `{"pull_request_id":"pr-history","user_id":"`+reviewers[1]+`"}`)
+	expected = append(expected, event(api.PullRequestEventTypeREVIEWERREMOVED, reviewers[1], ""))
+	check("remove")
+
+	clock.Advance(time.Minute)
+	post("/pullRequest/addReviewer", `{"pull_request_id":"pr-history","user_id":"`+reviewers[1]+`"}`)
+	expected = append(expected, event(api.PullRequestEventTypeREVIEWERASSIGNED, reviewers[1], ""))
+	check("add")
+
+	clock.Advance(time.Minute)
+	post("/pullRequest/merge", `{"pull_request_id":"pr-history"}`)
+	expected = append(expected, event(api.PullRequestEventTypeMERGED, "", ""))
+	check("merge")
+
+	t.Run("without include", func(t *testing.T) {
+		status, body := s.do(t, http.MethodGet, "/pullRequest/get", url.Values{"pull_request_id": {"pr-history"}}, nil)
+		if status != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", status, body)
+		}
+		var resp map[string]json.RawMessage
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := resp["history"]; ok {
+			t.Errorf("expected no history without include, got %s", resp["history"])
+		}
+	})
+
+	t.Run("unknown PR", func(t *testing.T) {
+		status, body := s.do(t, http.MethodGet, "/pullRequest/get", url.Values{"pull_request_id": {"pr-unknown"}, "include": {"history"}}, nil)
+		var resp api.ErrorResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatal(err)
+		}
+		if status != http.StatusNotFound || resp.Error.Code != api.NOTFOUND {
+			t.Errorf("expected 404 %s, got %d %s", api.NOTFOUND, status, resp.Error.Code)
+		}
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func sameEvent(a, b api.PullRequestEvent) bool {
+	same := func(x, y *string) bool {
+		return (x == nil) == (y == nil) && (x == nil || *x == *y)
+	}
+	return a.EventId == b.EventId && a.Type == b.Type && a.At.Equal(b.At) &&
+		same(a.Actor, b.Actor) && same(a.UserId, b.UserId) && same(a.ReplacedBy, b.ReplacedBy)
+}
+
+func describeEvent(e api.PullRequestEvent) string {
+	deref := func(s *string) string {
+		if s == nil {
+			return "<nil>"
+		}
+		return *s
+	}
+	return string(e.Type) + " of " + deref(e.UserId) + " by " + deref(e.Actor) + " at " + e.At.Format(time.RFC3339Nano) +
+		", replaced by " + deref(e.ReplacedBy)
+}
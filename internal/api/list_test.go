@@ -0,0 +1,182 @@
+package api_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+type listPage struct {
+	PullRequests []api.PullRequest `json:"pull_requests"`
+	NextCursor   *string           `json:"next_cursor"`
+}
+
+func listPRs(t *testing.T, s *server, query url.Values) listPage {
+	t.Helper()
+
+	status, body := s.do(t, http.MethodGet, "/pullRequest/list", query, nil)
+	if status != http.StatusOK {
+		t.Fatalf("list %v: expected status 200, got %d: %s", query, status, body)
+	}
+
+	var page listPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		t.Fatal(err)
+	}
+	return page
+}
+
+func prIds(prs []api.PullRequest) []string {
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.PullRequestId)
+	}
+	return ids
+}
+
+// listServer seeds the store at a fixed time and adds five PRs of data after it, three of them created
+// at the same minute. Seeded PRs share their creation time too, so ties are broken by id.
+func listServer(t *testing.T, open dbtest.Open) *server {
+	t.Helper()
+
+	clock := &clock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+	s := newServer(t, open(t), handler.Options{Clock: clock.Now})
+
+	for i, advance := range []time.Duration{time.Minute, 0, 0, time.Minute, time.Minute} {
+		clock.Advance(advance)
+		if _, err := createPR(t, s, fmt.Sprintf("pr-200%d", i), "u11"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return s
+}
+
+// everyPR is the order of all PRs of listServer, newest first
+var everyPR = []string{"pr-2004", "pr-2003", "pr-2002", "pr-2001", "pr-2000", "pr-1004", "pr-1003", "pr-1002", "pr-1001", "pr-1000"}
+
+// TestListFilters checks that each filter, alone and together with others, picks the right PRs
+func TestListFilters(t *testing.T) {
+	dbtest.Run(t, testListFilters)
+}
+
+func testListFilters(t *testing.T, open dbtest.Open) {
+	s := listServer(t, open)
+
+	for _, tc := range []struct {
+		name  string
+		query url.Values
+		want  []string
+	}{
+		{"no filters", url.Values{}, everyPR},
+		{"open", url.Values{"status": {"OPEN"}}, everyPR[:len(everyPR)-1]},
+		{"merged", url.Values{"status": {"MERGED"}}, []string{"pr-1000"}},
+		{"author", url.Values{"author_id": {"u1"}}, []string{"pr-1002", "pr-1001", "pr-1000"}},
+		{"author without PRs", url.Values{"author_id": {"u2"}}, []string{}},
+		{"reviewer", url.Values{"reviewer_id": {"u3"}}, []string{"pr-1001", "pr-1000"}},
+		{"team", url.Values{"team_name": {"data"}}, []string{"pr-2004", "pr-2003", "pr-2002", "pr-2001", "pr-2000", "pr-1003"}},
+		{"team and status", url.Values{"team_name": {"backend"}, "status": {"OPEN"}}, []string{"pr-1002", "pr-1001"}},
+		{"reviewer and author", url.Values{"reviewer_id": {"u2"}, "author_id": {"u1"}, "status": {"MERGED"}}, []string{"pr-1000"}},
+		{"unknown team", url.Values{"team_name": {"nobody"}}, []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			page := listPRs(t, s, tc.query)
+			if got := prIds(page.PullRequests); !slices.Equal(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+			if page.NextCursor != nil {
+				t.Errorf("expected no next page, got cursor %s", *page.NextCursor)
+			}
+		})
+	}
+}
+
+// TestListPages walks all PRs page by page with different limits. The pages must add up to the same order
+// without duplicates or gaps, even when a PR is created in the middle of the walk.
+func TestListPages(t *testing.T) {
+	dbtest.Run(t, testListPages)
+}
+
+func testListPages(t *testing.T, open dbtest.Open) {
+	for _, limit := range []int{1, 2, 3, 4, 10, 100} {
+		t.Run(strconv.Itoa(limit), func(t *testing.T) {
+			s := listServer(t, open)
+
+			query := url.Values{"limit": {strconv.Itoa(limit)}}
+			walked := make([]string, 0)
+			for pages := 0; ; pages++ {
+				if pages > len(everyPR) {
+					t.Fatalf("too many pages, walked %v", walked)
+				}
+
+				page := listPRs(t, s, query)
+				if len(page.PullRequests) > limit {
+					t.Fatalf("expected at most %d PRs, got %d", limit, len(page.PullRequests))
+				}
+				walked = append(walked, prIds(page.PullRequests)...)
+
+				if pages == 0 {
+					if _, err := createPR(t, s, "pr-3000", "u11"); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				if page.NextCursor == nil {
+					break
+				}
+				query.Set("cursor", *page.NextCursor)
+			}
+
+			if !slices.Equal(walked, everyPR) {
+				t.Errorf("expected %v, walked %v", everyPR, walked)
+			}
+		})
+	}
+}
+
+// TestListInvalidCursor checks that a cursor which isn't one of ours is a bad request
+func TestListInvalidCursor(t *testing.T) {
+	dbtest.Run(t, testListInvalidCursor)
+}
+
+func testListInvalidCursor(t *testing.T, open dbtest.Open) {
+	s := newServer(t, open(t), handler.Options{})
+
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	for _, tc := range []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "not a cursor!"},
+		{"not JSON", encode("pr-1001")},
+		{"wrong JSON", encode(`[1, 2]`)},
+		{"no position", encode(`{}`)},
+		{"no id", encode(`{"created_at":"2025-10-24T11:00:00Z"}`)},
+		{"no time", encode(`{"pull_request_id":"pr-1001"}`)},
+		{"bad time", encode(`{"created_at":"yesterday","pull_request_id":"pr-1001"}`)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := s.do(t, http.MethodGet, "/pullRequest/list", url.Values{"cursor": {tc.cursor}}, nil)
+			var resp api.ErrorResponse
+			if err := json.Unmarshal(body, &resp); err != nil {
+				t.Fatal(err)
+			}
+			if status != http.StatusBadRequest || resp.Error.Code != api.INVALIDREQUEST {
+				t.Errorf("expected 400 %s, got %d %s", api.INVALIDREQUEST, status, resp.Error.Code)
+			}
+		})
+	}
+}
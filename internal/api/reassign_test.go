@@ -0,0 +1,41 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+// TestReassignConflicts checks that reassigning on a merged PR or from a reviewer who isn't assigned
+// is a conflict with the state of the PR, as the spec says, and not a bad request
+func TestReassignConflicts(t *testing.T) {
+	dbtest.Run(t, testReassignConflicts)
+}
+
+func testReassignConflicts(t *testing.T, open dbtest.Open) {
+	for _, tc := range []struct {
+		name string
+		body string
+		code api.ErrorResponseErrorCode
+	}{
+		{"merged PR", `{"pull_request_id":"pr-1000","old_user_id":"u2"}`, api.PRMERGED},
+		{"reviewer not assigned", `{"pull_request_id":"pr-1001","old_user_id":"u1"}`, api.NOTASSIGNED},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newServer(t, open(t), handler.Options{})
+
+			status, body := s.do(t, http.MethodPost, "/pullRequest/reassign", nil, json.RawMessage(tc.body))
+			var resp api.ErrorResponse
+			if err := json.Unmarshal(body, &resp); err != nil {
+				t.Fatal(err)
+			}
+			if status != http.StatusConflict || resp.Error.Code != tc.code {
+				t.Errorf("expected 409 %s, got %d %s", tc.code, status, resp.Error.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+func reviewInbox(t *testing.T, s *server, query url.Values) handler.ReviewInbox {
+	t.Helper()
+
+	status, body := s.do(t, http.MethodGet, "/users/getReview", query, nil)
+	if status != http.StatusOK {
+		t.Fatalf("getReview %v: expected status 200, got %d: %s", query, status, body)
+	}
+
+	var inbox handler.ReviewInbox
+	if err := json.Unmarshal(body, &inbox); err != nil {
+		t.Fatal(err)
+	}
+	return inbox
+}
+
+// TestReviewPages walks reviews of Leo page by page. Kate's PRs are all reviewed by Leo and Mia: pr-1003
+// from the seed and seven more, some created at the same minute, two of them merged.
+func TestReviewPages(t *testing.T) {
+	dbtest.Run(t, testReviewPages)
+}
+
+func testReviewPages(t *testing.T, open dbtest.Open) {
+	clock := &clock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+	s := newServer(t, open(t), handler.Options{Clock: clock.Now})
+
+	for i, advance := range []time.Duration{time.Minute, 0, time.Minute, 0, 0, time.Minute, time.Minute} {
+		clock.Advance(advance)
+		if _, err := createPR(t, s, fmt.Sprintf("pr-300%d", i), "u11"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, prId := range []string{"pr-3001", "pr-3004"} {
+		if status, body := s.do(t, http.MethodPost, "/pullRequest/merge", nil, json.RawMessage(`{"pull_request_id":"`+prId+`"}`)); status != http.StatusOK {
+			t.Fatalf("merge %s: unexpected status %d: %s", prId, status, body)
+		}
+	}
+
+	newestFirst := map[api.GetUsersGetReviewParamsStatus][]string{
+		api.ALL:    {"pr-3006", "pr-3005", "pr-3004", "pr-3003", "pr-3002", "pr-3001", "pr-3000", "pr-1003"},
+		api.OPEN:   {"pr-3006", "pr-3005", "pr-3003", "pr-3002", "pr-3000", "pr-1003"},
+		api.MERGED: {"pr-3004", "pr-3001"},
+	}
+
+	for _, status := range []api.GetUsersGetReviewParamsStatus{api.ALL, api.OPEN, api.MERGED} {
+		newest := newestFirst[status]
+		for _, order := range []api.GetUsersGetReviewParamsOrder{api.Desc, api.Asc} {
+			expected := slices.Clone(newest)
+			if order == api.Asc {
+				slices.Reverse(expected)
+			}
+
+			for _, limit := range []int{1, 2, 3, len(expected), 100} {
+				t.Run(fmt.Sprintf("%s/%s/%d", status, order, limit), func(t *testing.T) {
+					query := url.Values{
+						"user_id": {"u12"},
+						"status":  {string(status)},
+						"order":   {string(order)},
+						"limit":   {strconv.Itoa(limit)},
+					}
+
+					walked := make([]string, 0)
+					for page := 0; ; page++ {
+						if page > len(expected) {
+							t.Fatalf("too many pages, walked %v", walked)
+						}
+
+						inbox := reviewInbox(t, s, query)
+						if inbox.Total != len(expected) {
+							t.Errorf("page %d: expected total %d, got %d", page, len(expected), inbox.Total)
+						}
+
+						// Every page but the last one is full, and the last one is never empty
+						left := len(expected) - len(walked)
+						if want := min(limit, left); len(inbox.PullRequests) != want {
+							t.Fatalf("page %d: expected %d PRs, got %d", page, want, len(inbox.PullRequests))
+						}
+						if last := left <= limit; last != (inbox.NextCursor == nil) {
+							t.Fatalf("page %d: %d PRs left, got cursor %v", page, left, inbox.NextCursor)
+						}
+
+						for _, pr := range inbox.PullRequests {
+							walked = append(walked, pr.PullRequestId)
+							if pr.OtherReviewers == nil || !slices.Equal(*pr.OtherReviewers, []string{"u13"}) {
+								t.Errorf("%s: expected other reviewers [u13], got %v", pr.PullRequestId, pr.OtherReviewers)
+							}
+						}
+
+						if inbox.NextCursor == nil {
+							break
+						}
+						query.Set("cursor", *inbox.NextCursor)
+					}
+
+					if !slices.Equal(walked, expected) {
+						t.Errorf("expected %v, walked %v", expected, walked)
+					}
+				})
+			}
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		inbox := reviewInbox(t, s, url.Values{"user_id": {"u12"}})
+		walked := make([]string, 0, len(inbox.PullRequests))
+		for _, pr := range inbox.PullRequests {
+			walked = append(walked, pr.PullRequestId)
+		}
+		if !slices.Equal(walked, newestFirst[api.OPEN]) || inbox.Total != len(newestFirst[api.OPEN]) {
+			t.Errorf("expected open PRs newest first, got %v of %d", walked, inbox.Total)
+		}
+	})
+
+	t.Run("no reviews", func(t *testing.T) {
+		inbox := reviewInbox(t, s, url.Values{"user_id": {"u11"}, "status": {"ALL"}})
+		if len(inbox.PullRequests) != 0 || inbox.Total != 0 || inbox.NextCursor != nil {
+			t.Errorf("expected an empty inbox, got %+v", inbox)
+		}
+	})
+
+	for _, tc := range []struct {
+		name   string
+		query  url.Values
+		status int
+		code   api.ErrorResponseErrorCode
+	}{
+		{"unknown user", url.Values{"user_id": {"u404"}}, http.StatusNotFound, api.NOTFOUND},
+		{"invalid cursor", url.Values{"user_id": {"u12"}, "cursor": {"not a cursor!"}}, http.StatusBadRequest, api.INVALIDREQUEST},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := s.do(t, http.MethodGet, "/users/getReview", tc.query, nil)
+			var resp api.ErrorResponse
+			if err := json.Unmarshal(body, &resp); err != nil {
+				t.Fatal(err)
+			}
+			if status != tc.status || resp.Error.Code != tc.code {
+				t.Errorf("expected %d %s, got %d %s", tc.status, tc.code, status, resp.Error.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+func simulate(t *testing.T, s *server, body string) api.Simulation {
+	t.Helper()
+
+	status, resp := s.do(t, http.MethodPost, "/pullRequest/simulate", nil, json.RawMessage(body))
+	if status != http.StatusOK {
+		t.Fatalf("simulate: expected status 200, got %d: %s", status, resp)
+	}
+
+	var simulation api.Simulation
+	if err := json.Unmarshal(resp, &simulation); err != nil {
+		t.Fatal(err)
+	}
+	return simulation
+}
+
+func loadOf(simulation api.Simulation, userId string) *api.SimulatedLoad {
+	for i := range simulation.Load {
+		if simulation.Load[i].UserId == userId {
+			return &simulation.Load[i]
+		}
+	}
+	return nil
+}
+
+// TestSimulate runs dry runs for Alice of backend. Bob has two open reviews and Carol one; under a limit
+// of two Carol has room for one more, the rest goes to payments, which has no limit.
+func TestSimulate(t *testing.T) {
+	dbtest.Run(t, testSimulate)
+}
+
+func testSimulate(t *testing.T, open dbtest.Open) {
+	t.Run("applies overrides and load of earlier PRs", func(t *testing.T) {
+		s := newServer(t, open(t), handler.Options{})
+
+		simulation := simulate(t, s, `{"author_id":"u1","count":3,"overrides":{"default_max_open_reviews":2}}`)
+
+		if len(simulation.PullRequests) != 3 || simulation.Understaffed != 0 {
+			t.Fatalf("expected 3 fully staffed PRs, got %+v", simulation)
+		}
+		for i, pr := range simulation.PullRequests {
+			if slices.Contains(pr.AssignedReviewers, "u2") {
+				t.Errorf("PR %d went to Bob at capacity: %v", i, pr.AssignedReviewers)
+			}
+		}
+
+		carol := loadOf(simulation, "u3")
+		if carol == nil || carol.Assigned != 1 || carol.OpenReviewsBefore != 1 || carol.OpenReviewsAfter != 2 {
+			t.Errorf("expected Carol to get one PR up to the limit, got %+v", carol)
+		}
+		if carol != nil && (carol.MaxOpenReviews == nil || *carol.MaxOpenReviews != 2) {
+			t.Errorf("expected the overridden limit of Carol, got %v", carol.MaxOpenReviews)
+		}
+		dave, eve := loadOf(simulation, "u4"), loadOf(simulation, "u5")
+		if dave == nil || eve == nil || dave.Assigned+eve.Assigned != 5 {
+			t.Errorf("expected payments to get the other 5 reviews, got %+v and %+v", dave, eve)
+		}
+	})
+
+	t.Run("leaves out inactive users and overridden fallbacks", func(t *testing.T) {
+		s := newServer(t, open(t), handler.Options{})
+
+		simulation := simulate(t, s, `{"author_id":"u1","count":2,"overrides":{"inactive_users":["u3"],"fallback_teams":["data"]}}`)
+
+		for i, pr := range simulation.PullRequests {
+			for _, userId := range pr.AssignedReviewers {
+				if userId != "u2" && !slices.Contains([]string{"u11", "u12", "u13"}, userId) {
+					t.Errorf("PR %d went to %s, who is neither Bob nor from data", i, userId)
+				}
+			}
+			if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u2" {
+				t.Errorf("PR %d: expected Bob and somebody from data, got %v", i, pr.AssignedReviewers)
+			}
+		}
+		if loadOf(simulation, "u4") != nil {
+			t.Error("payments is reported though fallbacks are overridden")
+		}
+	})
+
+	t.Run("writes nothing", func(t *testing.T) {
+		s := newServer(t, open(t), handler.Options{})
+
+		simulate(t, s, `{"author_id":"u1","count":5,"overrides":{"default_max_open_reviews":1,"inactive_users":["u3"],"fallback_teams":["data"]}}`)
+
+		status, body := s.do(t, http.MethodGet, "/team/get", url.Values{"team_name": {"backend"}}, nil)
+		if status != http.StatusOK {
+			t.Fatalf("get team: expected status 200, got %d: %s", status, body)
+		}
+		var team api.Team
+		if err := json.Unmarshal(body, &team); err != nil {
+			t.Fatal(err)
+		}
+		if team.DefaultMaxOpenReviews != nil || team.FallbackTeams == nil || !slices.Equal(*team.FallbackTeams, []string{"payments"}) {
+			t.Errorf("team settings changed: %+v", team)
+		}
+		for _, m := range team.Members {
+			if m.UserId == "u3" && !m.IsActive {
+				t.Error("Carol is left inactive")
+			}
+		}
+
+		status, body = s.do(t, http.MethodGet, "/users/getReview", url.Values{"user_id": {"u2"}}, nil)
+		if status != http.StatusOK {
+			t.Fatalf("get reviews: expected status 200, got %d: %s", status, body)
+		}
+		var reviews struct {
+			PullRequests []api.PullRequestShort `json:"pull_requests"`
+		}
+		if err := json.Unmarshal(body, &reviews); err != nil {
+			t.Fatal(err)
+		}
+		if len(reviews.PullRequests) != 2 {
+			t.Errorf("expected Bob to keep his 2 reviews, got %+v", reviews.PullRequests)
+		}
+	})
+
+	t.Run("runs while another transaction writes", func(t *testing.T) {
+		store := open(t)
+		s := newServer(t, store, handler.Options{})
+
+		tx, err := store.Begin(t.Context())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tx.Rollback(t.Context())
+		if _, err := tx.Exec(t.Context(), `UPDATE users SET max_open_reviews=0 WHERE user_id='u3'`); err != nil {
+			t.Fatal(err)
+		}
+
+		// The uncommitted limit of Carol is not seen
+		simulation := simulate(t, s, `{"author_id":"u1"}`)
+		if len(simulation.PullRequests[0].AssignedReviewers) != 2 {
+			t.Errorf("expected two reviewers, got %v", simulation.PullRequests[0].AssignedReviewers)
+		}
+	})
+}
+
+// TestSimulationKeepsSeededAssignment creates the same PRs with the same seed twice, with dry runs in between
+// the second time. The dry runs must not draw from the handler's Random, or the real PRs would get other reviewers.
+func TestSimulationKeepsSeededAssignment(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, open dbtest.Open) {
+		assign := func(simulated bool) [][]string {
+			s := newServer(t, open(t), handler.Options{Random: handler.NewRandom(42)})
+
+			// Grace and Heidi join backend, so that there are four teammates to pick two of
+			status, body := s.do(t, http.MethodPost, "/roster/import", nil, json.RawMessage(`{"teams":[{"team_name":"backend","members":[
+				{"user_id":"u1","username":"Alice"},
+				{"user_id":"u2","username":"Bob"},
+				{"user_id":"u3","username":"Carol"},
+				{"user_id":"u6","username":"Frank","is_active":false},
+				{"user_id":"u7","username":"Grace"},
+				{"user_id":"u8","username":"Heidi"}]}]}`))
+			if status != http.StatusOK {
+				t.Fatalf("roster import failed with %d: %s", status, body)
+			}
+
+			assigned := make([][]string, 0)
+			for i := range 10 {
+				if simulated {
+					simulate(t, s, `{"author_id":"u1","count":3}`)
+				}
+				reviewers, err := createPR(t, s, fmt.Sprintf("pr-%d", i), "u1")
+				if err != nil {
+					t.Fatal(err)
+				}
+				assigned = append(assigned, reviewers)
+			}
+			return assigned
+		}
+
+		plain, simulated := assign(false), assign(true)
+		for i := range plain {
+			if !slices.Equal(plain[i], simulated[i]) {
+				t.Fatalf("pr-%d got %v without dry runs and %v with them", i, plain[i], simulated[i])
+			}
+		}
+	})
+}
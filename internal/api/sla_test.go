@@ -0,0 +1,170 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/events"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+// slaBreaches returns breaches reported by /stats/slaBreaches
+func slaBreaches(t *testing.T, s *server, query url.Values) []api.SlaBreach {
+	t.Helper()
+
+	status, body := s.do(t, http.MethodGet, "/stats/slaBreaches", query, nil)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", status, body)
+	}
+
+	var resp struct {
+		Breaches []api.SlaBreach `json:"breaches"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp.Breaches
+}
+
+// clock is the handler's time, which tests move forward instead of waiting
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock() *clock {
+	return &clock{now: time.Now()}
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// TestReviewSLAEscalation lets reviews of backend go past a one second SLA. Bob reviews pr-1001 in time,
+// Carol on pr-1001 and Bob on pr-1002 don't. Each escalation publishes a review.sla_breached event; notify_lead
+// addresses it to the lead too, which is all it does, and only reassign changes reviewers.
+func TestReviewSLAEscalation(t *testing.T) {
+	dbtest.Run(t, testReviewSLAEscalation)
+}
+
+func testReviewSLAEscalation(t *testing.T, open dbtest.Open) {
+	for _, tc := range []struct {
+		name       string
+		sla        string
+		reassigned bool
+		notified   bool
+	}{
+		{"event", `{"team_name":"backend","review_sla":"1s","escalation":"event"}`, false, false},
+		{"notify_lead", `{"team_name":"backend","review_sla":"1s","escalation":"notify_lead","lead_user_id":"u5"}`, false, true},
+		{"reassign", `{"team_name":"backend","review_sla":"1s","escalation":"reassign"}`, true, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := newClock()
+			store := open(t)
+			s := newServer(t, store, handler.Options{Clock: clock.Now})
+
+			if status, body := s.do(t, http.MethodPost, "/team/setReviewSla", nil, json.RawMessage(tc.sla)); status != http.StatusOK {
+				t.Fatalf("set SLA: expected status 200, got %d: %s", status, body)
+			}
+			review := json.RawMessage(`{"pull_request_id":"pr-1001","user_id":"u2"}`)
+			if status, body := s.do(t, http.MethodPost, "/pullRequest/review", nil, review); status != http.StatusOK {
+				t.Fatalf("review: expected status 200, got %d: %s", status, body)
+			}
+
+			clock.Advance(2 * time.Second)
+
+			// The second run must not escalate the same assignments again, nor the new reviewers
+			for range 2 {
+				if err := s.h.EscalateReviewSLA(t.Context()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			breaches := slaBreaches(t, s, url.Values{"team_name": {"backend"}})
+			if len(breaches) != 2 {
+				t.Fatalf("expected 2 breaches, got %+v", breaches)
+			}
+
+			breached := make(map[string]string)
+			for _, b := range breaches {
+				breached[b.PullRequestId] = b.UserId
+				if (b.ReplacedBy != nil) != tc.reassigned {
+					t.Errorf("%s on %s: unexpected replaced_by %v", b.UserId, b.PullRequestId, b.ReplacedBy)
+				}
+				if (b.LeadUserId != nil) != tc.notified {
+					t.Errorf("%s on %s: unexpected lead_user_id %v", b.UserId, b.PullRequestId, b.LeadUserId)
+				}
+				if b.DueAt.Sub(b.AssignedAt) != time.Second {
+					t.Errorf("%s on %s: due %v after assignment", b.UserId, b.PullRequestId, b.DueAt.Sub(b.AssignedAt))
+				}
+			}
+			if breached["pr-1001"] != "u3" || breached["pr-1002"] != "u2" {
+				t.Fatalf("unexpected breaches %v", breached)
+			}
+
+			if got := slaBreaches(t, s, url.Values{"user_id": {"u3"}}); len(got) != 1 {
+				t.Fatalf("expected 1 breach of u3, got %+v", got)
+			}
+
+			// Every breach is on the stream once, addressed to the reviewer, the replacement and the lead
+			stream, err := events.NewBroker(store).Since(t.Context(), 0, events.Filter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			published := 0
+			for _, e := range stream {
+				if e.Type != events.SLABreached {
+					continue
+				}
+				published++
+
+				var data struct {
+					Breach api.SlaBreach `json:"breach"`
+				}
+				if err := json.Unmarshal(e.Data, &data); err != nil {
+					t.Fatal(err)
+				}
+				addressed := []string{data.Breach.UserId}
+				if data.Breach.ReplacedBy != nil {
+					addressed = append(addressed, *data.Breach.ReplacedBy)
+				}
+				if tc.notified {
+					addressed = append(addressed, "u5")
+				}
+				for _, userId := range addressed {
+					if !slices.Contains(e.UserIds, userId) {
+						t.Errorf("breach of %s on %s: expected the event addressed to %s, got %v",
+							data.Breach.UserId, data.Breach.PullRequestId, userId, e.UserIds)
+					}
+				}
+				if tc.name == "event" && slices.Contains(e.UserIds, "u5") {
+					t.Errorf("breach of %s on %s: expected nobody else addressed, got %v",
+						data.Breach.UserId, data.Breach.PullRequestId, e.UserIds)
+				}
+			}
+			if published != 2 {
+				t.Errorf("expected 2 breaches published, got %d", published)
+			}
+
+			pr, _ := withHistory(t, s, "pr-1001")
+			if slices.Contains(pr.AssignedReviewers, "u3") == tc.reassigned {
+				t.Errorf("expected Carol reassigned only by reassign, pr-1001 has %v", pr.AssignedReviewers)
+			}
+		})
+	}
+}
@@ -0,0 +1,468 @@
+// Package backup exports the whole dataset to JSON lines and restores it into an empty database.
+//
+// A backup is a header line, one line per row and a footer line. Rows go in the order they can be inserted
+// in: teams, users, fallback teams, ownership rules, review SLAs, absences, digest settings, PRs, PR history,
+// review SLA breaches and the audit log. The footer
+// holds the number of rows of every kind and SHA-256 of all lines before it, so a truncated or edited file is
+// rejected before anything is written. Idempotency keys, stream events and sent digests are short-lived
+// and not exported.
+package backup
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+const (
+	// Format identifies backup files
+	Format = "pr-reviewers-backup"
+	// Version is increased whenever rows change incompatibly
+	Version = 1
+)
+
+// Kinds of lines
+const (
+	kindHeader        = "header"
+	kindFooter        = "footer"
+	kindTeam          = "team"
+	kindUser          = "user"
+	kindTeamFallback  = "team_fallback"
+	kindOwnershipRule = "ownership_rule"
+	kindReviewSLA     = "review_sla"
+	kindAbsence       = "absence"
+	kindDigestSetting = "digest_setting"
+	kindPullRequest   = "pull_request"
+	kindPREvent       = "pr_event"
+	kindSLABreach     = "sla_breach"
+	kindAuditRecord   = "audit_record"
+)
+
+// kinds is the order rows are written and inserted in
+var kinds = []string{
+	kindTeam, kindUser, kindTeamFallback, kindOwnershipRule, kindReviewSLA, kindAbsence, kindDigestSetting,
+	kindPullRequest, kindPREvent, kindSLABreach, kindAuditRecord,
+}
+
+// line is a single line of the file, Data is one of the row types below or header/footer
+type line struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+type header struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	CreatedAt  time.Time `json:"created_at"`
+	Anonymized bool      `json:"anonymized"`
+}
+
+type footer struct {
+	Counts map[string]int `json:"counts"`
+	SHA256 string         `json:"sha256"`
+}
+
+type team struct {
+	TeamName              string `json:"team_name"`
+	DefaultMaxOpenReviews *int   `json:"default_max_open_reviews"`
+}
+
+type user struct {
+	UserId         string `json:"user_id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	IsActive       bool   `json:"is_active"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+type teamFallback struct {
+	TeamName         string `json:"team_name"`
+	FallbackTeamName string `json:"fallback_team_name"`
+	Priority         int    `json:"priority"`
+}
+
+type ownershipRule struct {
+	TeamName string   `json:"team_name"`
+	Position int      `json:"position"`
+	Pattern  string   `json:"pattern"`
+	Owners   []string `json:"owners"`
+}
+
+type digestSetting struct {
+	UserId   string  `json:"user_id"`
+	Enabled  bool    `json:"enabled"`
+	Timezone string  `json:"timezone"`
+	SendAt   string  `json:"send_at"`
+	Email    *string `json:"email"`
+}
+
+type reviewSLA struct {
+	TeamName   string  `json:"team_name"`
+	SLASeconds int64   `json:"sla_seconds"`
+	Escalation string  `json:"escalation"`
+	LeadUserId *string `json:"lead_user_id"`
+}
+
+type absence struct {
+	AbsenceId    int64      `json:"absence_id"`
+	UserId       string     `json:"user_id"`
+	StartsAt     time.Time  `json:"starts_at"`
+	EndsAt       time.Time  `json:"ends_at"`
+	Reason       string     `json:"reason"`
+	ReassignedAt *time.Time `json:"reassigned_at"`
+}
+
+type pullRequest struct {
+	PullRequestId     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+	AuthorId          string     `json:"author_id"`
+	Status            string     `json:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	FallbackReviewers []string   `json:"fallback_reviewers"`
+	ChangedFiles      []string   `json:"changed_files"`
+	CreatedAt         time.Time  `json:"created_at"`
+	MergedAt          *time.Time `json:"merged_at"`
+	Version           int64      `json:"version"`
+}
+
+type prEvent struct {
+	EventId       int64     `json:"event_id"`
+	PullRequestId string    `json:"pull_request_id"`
+	Type          string    `json:"type"`
+	At            time.Time `json:"at"`
+	Actor         *string   `json:"actor"`
+	UserId        *string   `json:"user_id"`
+	ReplacedBy    *string   `json:"replaced_by"`
+}
+
+type slaBreach struct {
+	BreachId      int64     `json:"breach_id"`
+	PullRequestId string    `json:"pull_request_id"`
+	UserId        string    `json:"user_id"`
+	TeamName      string    `json:"team_name"`
+	AssignedAt    time.Time `json:"assigned_at"`
+	DueAt         time.Time `json:"due_at"`
+	DetectedAt    time.Time `json:"detected_at"`
+	Escalation    string    `json:"escalation"`
+	LeadUserId    *string   `json:"lead_user_id"`
+	ReplacedBy    *string   `json:"replaced_by"`
+}
+
+type auditRecord struct {
+	AuditId    int64           `json:"audit_id"`
+	At         time.Time       `json:"at"`
+	Actor      *string         `json:"actor"`
+	Operation  string          `json:"operation"`
+	TargetType string          `json:"target_type"`
+	TargetId   string          `json:"target_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+}
+
+type ExportOptions struct {
+	// Anonymize replaces usernames and digest emails, including the ones in audit snapshots, with names
+	// derived from user ids
+	Anonymize bool
+}
+
+// writer writes lines and keeps the footer up to date
+type writer struct {
+	w      *bufio.Writer
+	sum    hash.Hash
+	counts map[string]int
+}
+
+func (w *writer) write(kind string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(line{Kind: kind, Data: raw})
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	// The footer covers every line before it
+	if kind != kindFooter {
+		w.sum.Write(b)
+	}
+	if kind != kindHeader && kind != kindFooter {
+		w.counts[kind]++
+	}
+
+	_, err = w.w.Write(b)
+	return err
+}
+
+// Export writes all rows to w. Rows are read in a single read-only transaction, so the backup is consistent
+// even if the service keeps running.
+func Export(ctx context.Context, db *db.DB, w io.Writer, opts ExportOptions) error {
+	tx, err := db.BeginSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	out := &writer{w: bufio.NewWriter(w), sum: sha256.New(), counts: make(map[string]int)}
+
+	err = out.write(kindHeader, header{
+		Format:     Format,
+		Version:    Version,
+		CreatedAt:  time.Now().UTC(),
+		Anonymized: opts.Anonymize,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, kind := range kinds {
+		if err := exportRows(ctx, tx, out, kind, opts); err != nil {
+			return fmt.Errorf("failed to export %s rows: %w", kind, err)
+		}
+	}
+
+	err = out.write(kindFooter, footer{Counts: out.counts, SHA256: hex.EncodeToString(out.sum.Sum(nil))})
+	if err != nil {
+		return err
+	}
+
+	return out.w.Flush()
+}
+
+// exportRows writes rows of the kind, ordered by their keys so that equal datasets give equal files
+func exportRows(ctx context.Context, tx db.Tx, out *writer, kind string, opts ExportOptions) error {
+	var (
+		query string
+		scan  func(db.Rows) (any, error)
+	)
+
+	switch kind {
+	case kindTeam:
+		query = `SELECT team_name, default_max_open_reviews FROM teams ORDER BY team_name`
+		scan = func(rows db.Rows) (any, error) {
+			var t team
+			err := rows.Scan(&t.TeamName, &t.DefaultMaxOpenReviews)
+			return t, err
+		}
+	case kindUser:
+		query = `SELECT user_id, username, team_name, is_active, max_open_reviews FROM users ORDER BY user_id`
+		scan = func(rows db.Rows) (any, error) {
+			var u user
+			err := rows.Scan(&u.UserId, &u.Username, &u.TeamName, &u.IsActive, &u.MaxOpenReviews)
+			if opts.Anonymize {
+				u.Username = anonymousName(u.UserId)
+			}
+			return u, err
+		}
+	case kindTeamFallback:
+		query = `SELECT team_name, fallback_team_name, priority FROM team_fallbacks ORDER BY team_name, priority`
+		scan = func(rows db.Rows) (any, error) {
+			var f teamFallback
+			err := rows.Scan(&f.TeamName, &f.FallbackTeamName, &f.Priority)
+			return f, err
+		}
+	case kindOwnershipRule:
+		query = `SELECT team_name, position, pattern, owners FROM ownership_rules ORDER BY team_name, position`
+		scan = func(rows db.Rows) (any, error) {
+			var r ownershipRule
+			err := rows.Scan(&r.TeamName, &r.Position, &r.Pattern, (*db.Strings)(&r.Owners))
+			return r, err
+		}
+	case kindReviewSLA:
+		query = `SELECT team_name, sla_seconds, escalation, lead_user_id FROM review_slas ORDER BY team_name`
+		scan = func(rows db.Rows) (any, error) {
+			var r reviewSLA
+			err := rows.Scan(&r.TeamName, &r.SLASeconds, &r.Escalation, &r.LeadUserId)
+			return r, err
+		}
+	case kindAbsence:
+		query = `SELECT absence_id, user_id, starts_at, ends_at, reason, reassigned_at FROM absences ORDER BY absence_id`
+		scan = func(rows db.Rows) (any, error) {
+			var a absence
+			err := rows.Scan(&a.AbsenceId, &a.UserId, &a.StartsAt, &a.EndsAt, &a.Reason, &a.ReassignedAt)
+			return a, err
+		}
+	case kindDigestSetting:
+		query = `SELECT user_id, enabled, timezone, send_at, email FROM digest_settings ORDER BY user_id`
+		scan = func(rows db.Rows) (any, error) {
+			var d digestSetting
+			err := rows.Scan(&d.UserId, &d.Enabled, &d.Timezone, &d.SendAt, &d.Email)
+			if opts.Anonymize && d.Email != nil {
+				email := anonymousEmail(d.UserId)
+				d.Email = &email
+			}
+			return d, err
+		}
+	case kindPullRequest:
+		// Reviewers are read beforehand: a connection can't run another query while rows are being read
+		reviewers, err := exportReviewers(ctx, tx)
+		if err != nil {
+			return err
+		}
+		query = `
+			SELECT pull_request_id, pull_request_name, author_id, status, changed_files, created_at, merged_at, version
+			FROM prs ORDER BY pull_request_id`
+		scan = func(rows db.Rows) (any, error) {
+			var p pullRequest
+			err := rows.Scan(&p.PullRequestId, &p.PullRequestName, &p.AuthorId, &p.Status,
+				(*db.Strings)(&p.ChangedFiles), &p.CreatedAt, &p.MergedAt, &p.Version)
+			r := reviewers[p.PullRequestId]
+			p.AssignedReviewers, p.FallbackReviewers = nonNil(r.assigned), nonNil(r.fallback)
+			return p, err
+		}
+	case kindPREvent:
+		query = `SELECT event_id, pull_request_id, type, at, actor, user_id, replaced_by FROM pr_events ORDER BY event_id`
+		scan = func(rows db.Rows) (any, error) {
+			var e prEvent
+			err := rows.Scan(&e.EventId, &e.PullRequestId, &e.Type, &e.At, &e.Actor, &e.UserId, &e.ReplacedBy)
+			return e, err
+		}
+	case kindSLABreach:
+		query = `
+			SELECT breach_id, pull_request_id, user_id, team_name, assigned_at, due_at, detected_at, escalation,
+				lead_user_id, replaced_by
+			FROM sla_breaches ORDER BY breach_id`
+		scan = func(rows db.Rows) (any, error) {
+			var b slaBreach
+			err := rows.Scan(&b.BreachId, &b.PullRequestId, &b.UserId, &b.TeamName, &b.AssignedAt, &b.DueAt, &b.DetectedAt,
+				&b.Escalation, &b.LeadUserId, &b.ReplacedBy)
+			return b, err
+		}
+	case kindAuditRecord:
+		query = `
+			SELECT audit_id, at, actor, operation, target_type, target_id, before, after
+			FROM audit_log ORDER BY audit_id`
+		scan = func(rows db.Rows) (any, error) {
+			var (
+				a             auditRecord
+				before, after []byte
+			)
+			if err := rows.Scan(&a.AuditId, &a.At, &a.Actor, &a.Operation, &a.TargetType, &a.TargetId, &before, &after); err != nil {
+				return nil, err
+			}
+			a.Before, a.After = before, after
+			if opts.Anonymize {
+				a.Before, a.After = anonymizeSnapshot(before), anonymizeSnapshot(after)
+			}
+			return a, nil
+		}
+	}
+
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row, err := scan(rows)
+		if err != nil {
+			return err
+		}
+		if err := out.write(kind, row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+type prReviewers struct {
+	assigned, fallback []string
+}
+
+// exportReviewers returns reviewers of every PR in the order they were assigned
+func exportReviewers(ctx context.Context, tx db.Tx) (map[string]prReviewers, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT pull_request_id, user_id, is_fallback FROM pr_reviewers ORDER BY pull_request_id, position
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviewers := make(map[string]prReviewers)
+	for rows.Next() {
+		var (
+			prId, userId string
+			isFallback   bool
+		)
+		if err := rows.Scan(&prId, &userId, &isFallback); err != nil {
+			return nil, err
+		}
+		r := reviewers[prId]
+		r.assigned = append(r.assigned, userId)
+		if isFallback {
+			r.fallback = append(r.fallback, userId)
+		}
+		reviewers[prId] = r
+	}
+
+	return reviewers, rows.Err()
+}
+
+// anonymousName is the same for the same user in every backup, so anonymized files can still be compared
+func anonymousName(userId string) string {
+	sum := sha256.Sum256([]byte(userId))
+	return "user-" + hex.EncodeToString(sum[:4])
+}
+
+// anonymousEmail is an address of the reserved .invalid domain, so digests never reach anyone after restore
+func anonymousEmail(userId string) string {
+	return anonymousName(userId) + "@example.invalid"
+}
+
+// anonymizeSnapshot replaces usernames and emails in audit snapshots. Users, team members and digest settings
+// are objects with user_id and username or email, that's how they are found regardless of the snapshot type.
+func anonymizeSnapshot(data []byte) json.RawMessage {
+	if data == nil {
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return data
+	}
+
+	anonymized, err := json.Marshal(anonymizeValue(v))
+	if err != nil {
+		return data
+	}
+
+	return anonymized
+}
+
+func anonymizeValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		if userId, ok := v["user_id"].(string); ok {
+			if _, ok := v["username"]; ok {
+				v["username"] = anonymousName(userId)
+			}
+			if email, ok := v["email"].(string); ok && email != "" {
+				v["email"] = anonymousEmail(userId)
+			}
+		}
+		for k, child := range v {
+			v[k] = anonymizeValue(child)
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = anonymizeValue(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
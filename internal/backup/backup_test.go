@@ -0,0 +1,284 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+)
+
+// sample has a row of every kind, with names and emails both in rows and in audit snapshots
+func sample() *Dataset {
+	at := time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC)
+	id := func(s string) *string { return &s }
+	limit := 3
+
+	return &Dataset{
+		header: header{Format: Format, Version: Version, CreatedAt: at},
+		teams: []team{
+			{TeamName: "backend"},
+			{TeamName: "payments", DefaultMaxOpenReviews: &limit},
+		},
+		users: []user{
+			{UserId: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+			{UserId: "u2", Username: "Bob", TeamName: "backend", IsActive: true, MaxOpenReviews: &limit},
+			{UserId: "u4", Username: "Dave", TeamName: "payments", IsActive: false},
+		},
+		teamFallbacks:  []teamFallback{{TeamName: "backend", FallbackTeamName: "payments", Priority: 0}},
+		ownershipRules: []ownershipRule{{TeamName: "backend", Position: 0, Pattern: "/internal/db/", Owners: []string{"u2"}}},
+		reviewSLAs:     []reviewSLA{{TeamName: "backend", SLASeconds: 3600, Escalation: "reassign", LeadUserId: id("u1")}},
+		absences: []absence{
+			{AbsenceId: 7, UserId: "u4", StartsAt: at, EndsAt: at.Add(72 * time.Hour), Reason: "vacation"},
+		},
+		digestSettings: []digestSetting{
+			{UserId: "u1", Enabled: true, Timezone: "Europe/Moscow", SendAt: "09:00", Email: id("alice@example.com")},
+		},
+		pullRequests: []pullRequest{
+			{
+				PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1", Status: "OPEN",
+				AssignedReviewers: []string{"u2", "u4"}, FallbackReviewers: []string{"u4"},
+				ChangedFiles: []string{"internal/db/db.go"}, CreatedAt: at, Version: 2,
+			},
+		},
+		prEvents: []prEvent{
+			{EventId: 3, PullRequestId: "pr-1", Type: "created", At: at, Actor: id("u1")},
+			{EventId: 5, PullRequestId: "pr-1", Type: "reviewer_assigned", At: at, UserId: id("u4")},
+		},
+		slaBreaches: []slaBreach{
+			{
+				BreachId: 2, PullRequestId: "pr-1", UserId: "u2", TeamName: "backend", AssignedAt: at,
+				DueAt: at.Add(time.Hour), DetectedAt: at.Add(2 * time.Hour), Escalation: "reassign", LeadUserId: id("u1"),
+			},
+		},
+		auditRecords: []auditRecord{
+			{
+				AuditId: 11, At: at, Actor: id("u1"), Operation: "team.add", TargetType: "team", TargetId: "backend",
+				After: json.RawMessage(`{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`),
+			},
+			{
+				AuditId: 12, At: at, Operation: "user.setIsActive", TargetType: "user", TargetId: "u4",
+				Before: json.RawMessage(`{"user_id":"u4","username":"Dave","is_active":true}`),
+				After:  json.RawMessage(`{"user_id":"u4","username":"Dave","is_active":false}`),
+			},
+			{
+				AuditId: 13, At: at, Operation: "digest.settings", TargetType: "user", TargetId: "u1",
+				Before: json.RawMessage(`{"user_id":"u1","enabled":false,"email":""}`),
+				After:  json.RawMessage(`{"user_id":"u1","enabled":true,"email":"alice@example.com"}`),
+			},
+		},
+	}
+}
+
+// export returns the backup of the store
+func export(t *testing.T, store *db.DB, opts ExportOptions) []byte {
+	t.Helper()
+
+	var b bytes.Buffer
+	if err := Export(t.Context(), store, &b, opts); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+// rows returns the lines of the backup between its header and footer
+func rows(t *testing.T, backup []byte) []string {
+	t.Helper()
+
+	lines := strings.Split(strings.TrimSuffix(string(backup), "\n"), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected a header and a footer, got %q", backup)
+	}
+	return lines[1 : len(lines)-1]
+}
+
+// encode writes lines of kinds and rows with a valid header and footer, whatever the rows are
+func encode(t *testing.T, lines ...any) []byte {
+	t.Helper()
+
+	var b bytes.Buffer
+	w := &writer{w: bufio.NewWriter(&b), sum: sha256.New(), counts: make(map[string]int)}
+	if err := w.write(kindHeader, header{Format: Format, Version: Version, CreatedAt: time.Now().UTC()}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(lines); i += 2 {
+		if err := w.write(lines[i].(string), lines[i+1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.write(kindFooter, footer{Counts: w.counts, SHA256: hex.EncodeToString(w.sum.Sum(nil))}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+// TestRoundTrip restores an export into an empty store and exports the same rows from it
+func TestRoundTrip(t *testing.T) {
+	dbtest.Run(t, testRoundTrip)
+}
+
+func testRoundTrip(t *testing.T, open dbtest.Open) {
+	source := open(t)
+	if err := Restore(t.Context(), source, sample()); err != nil {
+		t.Fatal(err)
+	}
+	exported := export(t, source, ExportOptions{})
+
+	d, err := Read(bytes.NewReader(exported))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for kind, count := range sample().Counts() {
+		if d.Counts()[kind] != count {
+			t.Errorf("expected %d %s rows, got %d", count, kind, d.Counts()[kind])
+		}
+	}
+
+	target := open(t)
+	if err := Restore(t.Context(), target, d); err != nil {
+		t.Fatal(err)
+	}
+	restored := rows(t, export(t, target, ExportOptions{}))
+	original := rows(t, exported)
+	if len(restored) != len(original) {
+		t.Fatalf("expected %d rows, got %d", len(original), len(restored))
+	}
+	for i := range original {
+		if restored[i] != original[i] {
+			t.Errorf("row %d changed:\n%s\n%s", i, original[i], restored[i])
+		}
+	}
+
+	// New rows continue after the restored ids
+	var auditId int64
+	err = target.QueryRow(t.Context(), `
+			INSERT INTO audit_log(operation, target_type, target_id) VALUES('team.add', 'team', 'mobile') RETURNING audit_id
+		`).Scan(&auditId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auditId <= 13 {
+		t.Errorf("expected a new audit id after 13, got %d", auditId)
+	}
+
+	if err := Restore(t.Context(), target, d); err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Errorf("expected restore into a store with data to fail, got %v", err)
+	}
+}
+
+func TestReadRejectsTamperedBackup(t *testing.T) {
+	store := dbtest.SQLite(t)
+	if err := Restore(t.Context(), store, sample()); err != nil {
+		t.Fatal(err)
+	}
+	exported := export(t, store, ExportOptions{})
+
+	for _, tc := range []struct {
+		name   string
+		backup []byte
+		err    string
+	}{
+		{"edited row", bytes.Replace(exported, []byte(`"Bob"`), []byte(`"Rob"`), 1), "checksum mismatch"},
+		{"truncated", exported[:bytes.LastIndex(exported[:len(exported)-1], []byte("\n"))+1], "footer is missing"},
+		{"empty", nil, "backup is empty"},
+		{"not a backup", []byte(`{"kind":"header","data":{"format":"other","version":1,"created_at":"2025-01-01T00:00:00Z","anonymized":false}}` + "\n"), "not a backup"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Read(bytes.NewReader(tc.backup)); err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected an error with %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestReadRejectsDanglingReferences(t *testing.T) {
+	backup := encode(t,
+		kindTeam, team{TeamName: "backend"},
+		kindUser, user{UserId: "u1", Username: "Alice", TeamName: "mobile", IsActive: true},
+		kindTeamFallback, teamFallback{TeamName: "backend", FallbackTeamName: "payments"},
+		kindPullRequest, pullRequest{
+			PullRequestId: "pr-1", AuthorId: "u1", Status: "OPEN",
+			AssignedReviewers: []string{"u9"}, FallbackReviewers: []string{"u2"},
+		},
+		kindPREvent, prEvent{EventId: 1, PullRequestId: "pr-2", Type: "created"},
+	)
+
+	_, err := Read(bytes.NewReader(backup))
+	if err == nil {
+		t.Fatal("expected the backup to be rejected")
+	}
+	for _, problem := range []string{
+		"user u1 refers to unknown team mobile",
+		"fallback payments of team backend refers to unknown team",
+		"PR pr-1 refers to unknown reviewer u9",
+		"fallback reviewer u2 of PR pr-1 is not assigned",
+		"PR event 1 refers to unknown PR pr-2",
+	} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("expected %q to be reported in:\n%v", problem, err)
+		}
+	}
+}
+
+func TestExportAnonymized(t *testing.T) {
+	store := dbtest.SQLite(t)
+	if err := Restore(t.Context(), store, sample()); err != nil {
+		t.Fatal(err)
+	}
+	exported := export(t, store, ExportOptions{Anonymize: true})
+
+	for _, s := range []string{"Alice", "Bob", "Dave", "alice@example.com"} {
+		if bytes.Contains(exported, []byte(s)) {
+			t.Errorf("%s is left in the anonymized backup", s)
+		}
+	}
+
+	d, err := Read(bytes.NewReader(exported))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Anonymized() {
+		t.Error("expected the header to say the backup is anonymized")
+	}
+	if d.users[0].Username != anonymousName("u1") || d.users[2].Username != anonymousName("u4") {
+		t.Errorf("expected names derived from user ids, got %+v", d.users)
+	}
+	if *d.digestSettings[0].Email != anonymousEmail("u1") {
+		t.Errorf("expected an email derived from the user id, got %+v", d.digestSettings[0])
+	}
+
+	var team struct {
+		Members []struct{ UserId, Username string } `json:"members"`
+	}
+	if err := json.Unmarshal(d.auditRecords[0].After, &team); err != nil {
+		t.Fatal(err)
+	}
+	if len(team.Members) != 1 || team.Members[0].Username != anonymousName("u1") {
+		t.Errorf("expected team members in the snapshot to be anonymized, got %s", d.auditRecords[0].After)
+	}
+
+	var user struct{ Username string }
+	if err := json.Unmarshal(d.auditRecords[1].Before, &user); err != nil {
+		t.Fatal(err)
+	}
+	if user.Username != anonymousName("u4") {
+		t.Errorf("expected the user in the snapshot to be anonymized, got %s", d.auditRecords[1].Before)
+	}
+
+	var settings struct{ Email string }
+	if err := json.Unmarshal(d.auditRecords[2].Before, &settings); err != nil {
+		t.Fatal(err)
+	}
+	if settings.Email != "" {
+		t.Errorf("expected an empty email to stay empty, got %q", settings.Email)
+	}
+}
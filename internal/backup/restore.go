@@ -0,0 +1,537 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+// maxProblems is how many consistency problems are reported at once
+const maxProblems = 20
+
+// maxLineSize bounds a single row, audit snapshots of big teams are the longest lines
+const maxLineSize = 16 << 20
+
+// Dataset is a backup read into memory and checked against its footer
+type Dataset struct {
+	header header
+
+	teams          []team
+	users          []user
+	teamFallbacks  []teamFallback
+	ownershipRules []ownershipRule
+	reviewSLAs     []reviewSLA
+	absences       []absence
+	digestSettings []digestSetting
+	pullRequests   []pullRequest
+	prEvents       []prEvent
+	slaBreaches    []slaBreach
+	auditRecords   []auditRecord
+}
+
+// CreatedAt is when the backup was made
+func (d *Dataset) CreatedAt() time.Time {
+	return d.header.CreatedAt
+}
+
+// Anonymized reports whether usernames were replaced on export
+func (d *Dataset) Anonymized() bool {
+	return d.header.Anonymized
+}
+
+// Counts returns the number of rows of every kind
+func (d *Dataset) Counts() map[string]int {
+	return map[string]int{
+		kindTeam:          len(d.teams),
+		kindUser:          len(d.users),
+		kindTeamFallback:  len(d.teamFallbacks),
+		kindOwnershipRule: len(d.ownershipRules),
+		kindReviewSLA:     len(d.reviewSLAs),
+		kindAbsence:       len(d.absences),
+		kindDigestSetting: len(d.digestSettings),
+		kindPullRequest:   len(d.pullRequests),
+		kindPREvent:       len(d.prEvents),
+		kindSLABreach:     len(d.slaBreaches),
+		kindAuditRecord:   len(d.auditRecords),
+	}
+}
+
+// Read reads the backup and checks its header, checksum, row counts and consistency
+func Read(r io.Reader) (*Dataset, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	d := &Dataset{}
+	sum := sha256.New()
+	var foot *footer
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		raw := scanner.Bytes()
+
+		if foot != nil {
+			return nil, fmt.Errorf("line %d: data after footer", lineNo)
+		}
+
+		var l line
+		if err := json.Unmarshal(raw, &l); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		if l.Kind != kindFooter {
+			sum.Write(raw)
+			sum.Write([]byte{'\n'})
+		}
+
+		if lineNo == 1 {
+			if l.Kind != kindHeader {
+				return nil, errors.New("header is missing")
+			}
+			if err := strictUnmarshal(l.Data, &d.header); err != nil {
+				return nil, fmt.Errorf("line 1: %w", err)
+			}
+			if d.header.Format != Format {
+				return nil, fmt.Errorf("not a backup, format is %q", d.header.Format)
+			}
+			if d.header.Version != Version {
+				return nil, fmt.Errorf("backup version %d is not supported, expected %d", d.header.Version, Version)
+			}
+			continue
+		}
+
+		if l.Kind == kindFooter {
+			foot = &footer{}
+			if err := strictUnmarshal(l.Data, foot); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			continue
+		}
+
+		if err := d.add(l); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	if d.header.Format == "" {
+		return nil, errors.New("backup is empty")
+	}
+
+	if foot == nil {
+		return nil, errors.New("footer is missing, the backup is incomplete")
+	}
+
+	if hex.EncodeToString(sum.Sum(nil)) != foot.SHA256 {
+		return nil, errors.New("checksum mismatch, the backup is damaged or was edited")
+	}
+
+	for kind, count := range d.Counts() {
+		if foot.Counts[kind] != count {
+			return nil, fmt.Errorf("footer expects %d %s rows, found %d", foot.Counts[kind], kind, count)
+		}
+	}
+
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// add decodes a row line
+func (d *Dataset) add(l line) error {
+	var err error
+	switch l.Kind {
+	case kindTeam:
+		d.teams, err = appendRow(d.teams, l.Data)
+	case kindUser:
+		d.users, err = appendRow(d.users, l.Data)
+	case kindTeamFallback:
+		d.teamFallbacks, err = appendRow(d.teamFallbacks, l.Data)
+	case kindOwnershipRule:
+		d.ownershipRules, err = appendRow(d.ownershipRules, l.Data)
+	case kindReviewSLA:
+		d.reviewSLAs, err = appendRow(d.reviewSLAs, l.Data)
+	case kindAbsence:
+		d.absences, err = appendRow(d.absences, l.Data)
+	case kindDigestSetting:
+		d.digestSettings, err = appendRow(d.digestSettings, l.Data)
+	case kindPullRequest:
+		d.pullRequests, err = appendRow(d.pullRequests, l.Data)
+	case kindPREvent:
+		d.prEvents, err = appendRow(d.prEvents, l.Data)
+	case kindSLABreach:
+		d.slaBreaches, err = appendRow(d.slaBreaches, l.Data)
+	case kindAuditRecord:
+		d.auditRecords, err = appendRow(d.auditRecords, l.Data)
+	default:
+		err = fmt.Errorf("unknown kind %q", l.Kind)
+	}
+	return err
+}
+
+func appendRow[T any](rows []T, data json.RawMessage) ([]T, error) {
+	var row T
+	if err := strictUnmarshal(data, &row); err != nil {
+		return rows, err
+	}
+	return append(rows, row), nil
+}
+
+func strictUnmarshal(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// problems collects consistency problems, the first maxProblems of them are reported
+type problems []string
+
+func (p *problems) addf(format string, args ...any) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+func (p problems) err() error {
+	if len(p) == 0 {
+		return nil
+	}
+
+	shown := p
+	if len(shown) > maxProblems {
+		shown = shown[:maxProblems]
+	}
+
+	msg := fmt.Sprintf("backup is inconsistent, %d problems:\n  %s", len(p), strings.Join(shown, "\n  "))
+	if len(p) > maxProblems {
+		msg += "\n  ..."
+	}
+	return errors.New(msg)
+}
+
+// validate checks primary keys and references the way the database would, so that a bad backup fails
+// with all its problems listed instead of the first constraint violation
+func (d *Dataset) validate() error {
+	var p problems
+
+	teams := make(map[string]bool)
+	for _, t := range d.teams {
+		if teams[t.TeamName] {
+			p.addf("team %s is duplicated", t.TeamName)
+		}
+		teams[t.TeamName] = true
+	}
+
+	users := make(map[string]bool)
+	for _, u := range d.users {
+		if users[u.UserId] {
+			p.addf("user %s is duplicated", u.UserId)
+		}
+		users[u.UserId] = true
+		if !teams[u.TeamName] {
+			p.addf("user %s refers to unknown team %s", u.UserId, u.TeamName)
+		}
+	}
+
+	fallbacks := make(map[[2]string]bool)
+	for _, f := range d.teamFallbacks {
+		key := [2]string{f.TeamName, f.FallbackTeamName}
+		if fallbacks[key] {
+			p.addf("fallback %s of team %s is duplicated", f.FallbackTeamName, f.TeamName)
+		}
+		fallbacks[key] = true
+		if !teams[f.TeamName] || !teams[f.FallbackTeamName] {
+			p.addf("fallback %s of team %s refers to unknown team", f.FallbackTeamName, f.TeamName)
+		}
+		if f.TeamName == f.FallbackTeamName {
+			p.addf("team %s falls back to itself", f.TeamName)
+		}
+	}
+
+	rules := make(map[string]map[int]bool)
+	for _, r := range d.ownershipRules {
+		if !teams[r.TeamName] {
+			p.addf("ownership rule %d refers to unknown team %s", r.Position, r.TeamName)
+		}
+		if rules[r.TeamName] == nil {
+			rules[r.TeamName] = make(map[int]bool)
+		}
+		if rules[r.TeamName][r.Position] {
+			p.addf("ownership rule %d of team %s is duplicated", r.Position, r.TeamName)
+		}
+		rules[r.TeamName][r.Position] = true
+	}
+
+	slas := make(map[string]bool)
+	for _, r := range d.reviewSLAs {
+		if slas[r.TeamName] {
+			p.addf("review SLA of team %s is duplicated", r.TeamName)
+		}
+		slas[r.TeamName] = true
+		if !teams[r.TeamName] {
+			p.addf("review SLA refers to unknown team %s", r.TeamName)
+		}
+		if r.LeadUserId != nil && !users[*r.LeadUserId] {
+			p.addf("review SLA of team %s refers to unknown lead %s", r.TeamName, *r.LeadUserId)
+		}
+		if r.SLASeconds <= 0 {
+			p.addf("review SLA of team %s is not positive", r.TeamName)
+		}
+		if r.Escalation != "event" && r.Escalation != "notify_lead" && r.Escalation != "reassign" {
+			p.addf("review SLA of team %s has unknown escalation %s", r.TeamName, r.Escalation)
+		}
+	}
+
+	absences := make(map[int64]bool)
+	for _, a := range d.absences {
+		if absences[a.AbsenceId] {
+			p.addf("absence %d is duplicated", a.AbsenceId)
+		}
+		absences[a.AbsenceId] = true
+		if !users[a.UserId] {
+			p.addf("absence %d refers to unknown user %s", a.AbsenceId, a.UserId)
+		}
+		if !a.EndsAt.After(a.StartsAt) {
+			p.addf("absence %d ends before it starts", a.AbsenceId)
+		}
+	}
+
+	digests := make(map[string]bool)
+	for _, ds := range d.digestSettings {
+		if digests[ds.UserId] {
+			p.addf("digest settings of user %s are duplicated", ds.UserId)
+		}
+		digests[ds.UserId] = true
+		if !users[ds.UserId] {
+			p.addf("digest settings refer to unknown user %s", ds.UserId)
+		}
+		if _, err := time.LoadLocation(ds.Timezone); err != nil {
+			p.addf("digest settings of user %s have unknown time zone %s", ds.UserId, ds.Timezone)
+		}
+		if _, err := time.Parse("15:04", ds.SendAt); err != nil {
+			p.addf("digest settings of user %s have invalid time %s", ds.UserId, ds.SendAt)
+		}
+	}
+
+	prs := make(map[string]bool)
+	for _, pr := range d.pullRequests {
+		if prs[pr.PullRequestId] {
+			p.addf("PR %s is duplicated", pr.PullRequestId)
+		}
+		prs[pr.PullRequestId] = true
+		if !users[pr.AuthorId] {
+			p.addf("PR %s refers to unknown author %s", pr.PullRequestId, pr.AuthorId)
+		}
+		if pr.Status != "OPEN" && pr.Status != "MERGED" {
+			p.addf("PR %s has unknown status %s", pr.PullRequestId, pr.Status)
+		}
+
+		assigned := make(map[string]bool)
+		for _, r := range pr.AssignedReviewers {
+			if assigned[r] {
+				p.addf("reviewer %s of PR %s is listed twice", r, pr.PullRequestId)
+			}
+			assigned[r] = true
+			if !users[r] {
+				p.addf("PR %s refers to unknown reviewer %s", pr.PullRequestId, r)
+			}
+		}
+		for _, r := range pr.FallbackReviewers {
+			if !assigned[r] {
+				p.addf("fallback reviewer %s of PR %s is not assigned", r, pr.PullRequestId)
+			}
+		}
+	}
+
+	events := make(map[int64]bool)
+	for _, e := range d.prEvents {
+		if events[e.EventId] {
+			p.addf("PR event %d is duplicated", e.EventId)
+		}
+		events[e.EventId] = true
+		if !prs[e.PullRequestId] {
+			p.addf("PR event %d refers to unknown PR %s", e.EventId, e.PullRequestId)
+		}
+	}
+
+	breaches := make(map[int64]bool)
+	for _, b := range d.slaBreaches {
+		if breaches[b.BreachId] {
+			p.addf("SLA breach %d is duplicated", b.BreachId)
+		}
+		breaches[b.BreachId] = true
+		if !prs[b.PullRequestId] {
+			p.addf("SLA breach %d refers to unknown PR %s", b.BreachId, b.PullRequestId)
+		}
+		if !users[b.UserId] {
+			p.addf("SLA breach %d refers to unknown reviewer %s", b.BreachId, b.UserId)
+		}
+	}
+
+	audit := make(map[int64]bool)
+	for _, a := range d.auditRecords {
+		if audit[a.AuditId] {
+			p.addf("audit record %d is duplicated", a.AuditId)
+		}
+		audit[a.AuditId] = true
+	}
+
+	return p.err()
+}
+
+// Restore writes the dataset into the database in a single transaction. The database must be empty,
+// restoring over existing data would mix two histories.
+func Restore(ctx context.Context, database *db.DB, d *Dataset) error {
+	tx, err := database.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Nobody may write between the emptiness check and the restore. SQLite write transactions lock the
+	// whole database from their start already.
+	if database.Dialect == db.Postgres {
+		_, err = tx.Exec(ctx, `
+			LOCK TABLE teams, users, team_fallbacks, ownership_rules, review_slas, absences, digest_settings, prs,
+				pr_reviewers, pr_events, sla_breaches, audit_log
+			IN EXCLUSIVE MODE
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to lock tables: %w", err)
+		}
+	}
+
+	var empty bool
+	err = tx.QueryRow(ctx, `SELECT NOT EXISTS(SELECT 1 FROM teams) AND NOT EXISTS(SELECT 1 FROM audit_log)`).Scan(&empty)
+	if err != nil {
+		return fmt.Errorf("failed to check database: %w", err)
+	}
+	if !empty {
+		return errors.New("database is not empty, restore needs a fresh one")
+	}
+
+	copies := []struct {
+		table   string
+		columns []string
+		rows    [][]any
+	}{
+		{"teams", []string{"team_name", "default_max_open_reviews"}, rowsOf(d.teams, func(t team) []any {
+			return []any{t.TeamName, t.DefaultMaxOpenReviews}
+		})},
+		{"users", []string{"user_id", "username", "team_name", "is_active", "max_open_reviews"}, rowsOf(d.users, func(u user) []any {
+			return []any{u.UserId, u.Username, u.TeamName, u.IsActive, u.MaxOpenReviews}
+		})},
+		{"team_fallbacks", []string{"team_name", "fallback_team_name", "priority"}, rowsOf(d.teamFallbacks, func(f teamFallback) []any {
+			return []any{f.TeamName, f.FallbackTeamName, f.Priority}
+		})},
+		{"ownership_rules", []string{"team_name", "position", "pattern", "owners"}, rowsOf(d.ownershipRules, func(r ownershipRule) []any {
+			return []any{r.TeamName, r.Position, r.Pattern, db.Strings(nonNil(r.Owners))}
+		})},
+		{"review_slas", []string{"team_name", "sla_seconds", "escalation", "lead_user_id"}, rowsOf(d.reviewSLAs, func(r reviewSLA) []any {
+			return []any{r.TeamName, r.SLASeconds, r.Escalation, r.LeadUserId}
+		})},
+		{"absences", []string{"absence_id", "user_id", "starts_at", "ends_at", "reason", "reassigned_at"}, rowsOf(d.absences, func(a absence) []any {
+			return []any{a.AbsenceId, a.UserId, a.StartsAt, a.EndsAt, a.Reason, a.ReassignedAt}
+		})},
+		{"digest_settings", []string{"user_id", "enabled", "timezone", "send_at", "email"}, rowsOf(d.digestSettings, func(ds digestSetting) []any {
+			return []any{ds.UserId, ds.Enabled, ds.Timezone, ds.SendAt, ds.Email}
+		})},
+		{"prs", []string{
+			"pull_request_id", "pull_request_name", "author_id", "status", "changed_files", "created_at", "merged_at", "version",
+		}, rowsOf(d.pullRequests, func(p pullRequest) []any {
+			return []any{
+				p.PullRequestId, p.PullRequestName, p.AuthorId, p.Status, db.Strings(nonNil(p.ChangedFiles)), p.CreatedAt, p.MergedAt, p.Version,
+			}
+		})},
+		{"pr_reviewers", []string{"pull_request_id", "user_id", "position", "is_fallback"}, reviewerRows(d.pullRequests)},
+		{"pr_events", []string{"event_id", "pull_request_id", "type", "at", "actor", "user_id", "replaced_by"}, rowsOf(d.prEvents, func(e prEvent) []any {
+			return []any{e.EventId, e.PullRequestId, e.Type, e.At, e.Actor, e.UserId, e.ReplacedBy}
+		})},
+		{"sla_breaches", []string{
+			"breach_id", "pull_request_id", "user_id", "team_name", "assigned_at", "due_at", "detected_at", "escalation",
+			"lead_user_id", "replaced_by",
+		}, rowsOf(d.slaBreaches, func(b slaBreach) []any {
+			return []any{
+				b.BreachId, b.PullRequestId, b.UserId, b.TeamName, b.AssignedAt, b.DueAt, b.DetectedAt, b.Escalation,
+				b.LeadUserId, b.ReplacedBy,
+			}
+		})},
+		{"audit_log", []string{"audit_id", "at", "actor", "operation", "target_type", "target_id", "before", "after"}, rowsOf(d.auditRecords, func(a auditRecord) []any {
+			return []any{a.AuditId, a.At, a.Actor, a.Operation, a.TargetType, a.TargetId, jsonb(a.Before), jsonb(a.After)}
+		})},
+	}
+
+	for _, c := range copies {
+		if _, err := tx.CopyFrom(ctx, c.table, c.columns, c.rows); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", c.table, err)
+		}
+	}
+
+	// Ids were restored as is, new rows must continue after them. SQLite continues after the largest id by itself.
+	if database.Dialect != db.Postgres {
+		return tx.Commit(ctx)
+	}
+	for _, seq := range [][2]string{
+		{"absences", "absence_id"}, {"pr_events", "event_id"}, {"sla_breaches", "breach_id"}, {"audit_log", "audit_id"},
+	} {
+		_, err := tx.Exec(ctx, fmt.Sprintf(
+			`SELECT setval(pg_get_serial_sequence('%[1]s', '%[2]s'), COALESCE(MAX(%[2]s), 0) + 1, false) FROM %[1]s`,
+			seq[0], seq[1],
+		))
+		if err != nil {
+			return fmt.Errorf("failed to reset %s sequence: %w", seq[0], err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func rowsOf[T any](items []T, values func(T) []any) [][]any {
+	rows := make([][]any, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, values(item))
+	}
+	return rows
+}
+
+// reviewerRows lists reviewers of every PR in the order they were assigned
+func reviewerRows(prs []pullRequest) [][]any {
+	rows := make([][]any, 0)
+	for _, p := range prs {
+		fallback := make(map[string]bool, len(p.FallbackReviewers))
+		for _, r := range p.FallbackReviewers {
+			fallback[r] = true
+		}
+		for i, r := range p.AssignedReviewers {
+			rows = append(rows, []any{p.PullRequestId, r, i, fallback[r]})
+		}
+	}
+	return rows
+}
+
+// nonNil keeps NOT NULL list columns from getting NULL out of rows written as "null"
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// jsonb passes a snapshot to a JSON column as text, absent snapshots stay NULL
+func jsonb(data json.RawMessage) any {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	return string(data)
+}
@@ -0,0 +1,155 @@
+// Package codeowners parses CODEOWNERS files and matches changed paths against their rules.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Rule is a single CODEOWNERS line: a path pattern and the owners of matching paths.
+// Owners are stored without the leading "@".
+type Rule struct {
+	Pattern string
+	Owners  []string
+	re      *regexp.Regexp
+}
+
+// NewRule compiles the pattern of a rule
+func NewRule(pattern string, owners []string) (Rule, error) {
+	re, err := compile(pattern)
+	if err != nil {
+		return Rule{}, err
+	}
+	return Rule{Pattern: pattern, Owners: owners, re: re}, nil
+}
+
+// Match reports whether the path (relative to the repository root) is matched by the rule
+func (r Rule) Match(path string) bool {
+	return r.re.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+// Parse parses CODEOWNERS file contents. Owners may be "@user", "@org/team" or an email.
+func Parse(text string) ([]Rule, error) {
+	var rules []Rule
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := stripComment(scanner.Text())
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		owners := make([]string, 0, len(fields)-1)
+		for _, owner := range fields[1:] {
+			switch {
+			case strings.HasPrefix(owner, "@") && len(owner) > 1:
+				owners = append(owners, owner[1:])
+			case strings.Index(owner, "@") > 0 && !strings.HasSuffix(owner, "@"):
+				owners = append(owners, owner)
+			default:
+				return nil, fmt.Errorf("line %d: invalid owner %q", lineNo, owner)
+			}
+		}
+
+		rule, err := NewRule(fields[0], owners)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// stripComment cuts the line at the first "#" which is not escaped as "\#"
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// Owners returns owners of the path. As in GitHub, the last matching rule wins.
+func Owners(rules []Rule, path string) []string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].Match(path) {
+			return rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// compile turns a gitignore-like pattern into a regexp:
+//   - a pattern with a slash at the beginning or in the middle is relative to the root,
+//     otherwise it matches at any depth;
+//   - "*" and "?" don't cross directory boundaries, "**" does;
+//   - a backslash makes the next character literal, e.g. "\#" or "\*";
+//   - a pattern matches directories with everything inside them, except for patterns
+//     ending with "/*", which match only direct children; a pattern ending with "/"
+//     matches only directories, so not a file of the same name.
+func compile(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" || strings.HasPrefix(pattern, "!") {
+		return nil, fmt.Errorf("unsupported pattern %q", pattern)
+	}
+
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+	if trimmed == "" {
+		return nil, fmt.Errorf("unsupported pattern %q", pattern)
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		c := trimmed[i]
+		switch {
+		case c == '\\' && i+1 < len(trimmed):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(trimmed[i])))
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(pattern, "/*") && !strings.HasSuffix(pattern, "**/*"):
+		b.WriteString("$")
+	case strings.HasSuffix(pattern, "/"):
+		b.WriteString("/.*$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,162 @@
+package codeowners
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		matched []string
+		other   []string
+	}{
+		// Without a slash in the middle a pattern matches at any depth, with a leading one only at the root
+		{"*.go", []string{"main.go", "cmd/prr/main.go"}, []string{"main.go.orig", "go"}},
+		{"Makefile", []string{"Makefile", "build/Makefile", "Makefile/rules.mk"}, []string{"Makefile.old"}},
+		{"/Makefile", []string{"Makefile", "/Makefile"}, []string{"build/Makefile"}},
+		{"docs/api", []string{"docs/api", "docs/api/index.md"}, []string{"web/docs/api"}},
+		{"/docs/api", []string{"docs/api/index.md"}, []string{"web/docs/api/index.md"}},
+
+		// "*" and "?" stay within a directory, "**" crosses directories
+		{"/src/*.go", []string{"src/main.go"}, []string{"src/handler/main.go"}},
+		{"/src/?.go", []string{"src/a.go"}, []string{"src/ab.go", "src/a/b.go"}},
+		{"/src/*", []string{"src/main.go"}, []string{"src/handler/main.go"}},
+		{"/src/**", []string{"src/main.go", "src/handler/main.go"}, []string{"src"}},
+		{"**/testdata", []string{"testdata/a.json", "internal/api/testdata/a.json"}, []string{"testdata.go"}},
+		{"/internal/**/*_test.go", []string{"internal/a_test.go", "internal/api/db/a_test.go"}, []string{"internal/a.go", "a_test.go"}},
+		{"/a/**/b", []string{"a/b", "a/x/y/b", "a/x/b/c.go"}, []string{"a/xb"}},
+
+		// A trailing slash matches only directories, at any depth unless there is another slash
+		{"build/", []string{"build/out.bin", "web/build/out.bin"}, []string{"build", "web/build"}},
+		{"/web/build/", []string{"web/build/out.bin"}, []string{"web/build", "x/web/build/out.bin"}},
+
+		// Regexp characters and escaped wildcards are literal
+		{"*.min.js", []string{"a.min.js"}, []string{"a_minxjs"}},
+		{"/c++/(a)+[b]$", []string{"c++/(a)+[b]$"}, []string{"c/(a)+[b]$", "c++/aa"}},
+		{`/\*.md`, []string{"*.md"}, []string{"README.md"}},
+		{`/\#notes`, []string{"#notes"}, []string{"notes"}},
+	} {
+		rule, err := NewRule(tc.pattern, nil)
+		if err != nil {
+			t.Errorf("%s: %v", tc.pattern, err)
+			continue
+		}
+		for _, path := range tc.matched {
+			if !rule.Match(path) {
+				t.Errorf("%s doesn't match %s", tc.pattern, path)
+			}
+		}
+		for _, path := range tc.other {
+			if rule.Match(path) {
+				t.Errorf("%s matches %s", tc.pattern, path)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		text  string
+		rules []Rule
+		err   bool
+	}{
+		{
+			name: "owners of every kind",
+			text: "*.go @alice @org/backend bob@example.com",
+			rules: []Rule{
+				{Pattern: "*.go", Owners: []string{"alice", "org/backend", "bob@example.com"}},
+			},
+		},
+		{
+			name: "comments and blank lines",
+			text: "# Owners\n\n/docs/ @carol # writes the docs\n   \n*.sql @dave\n",
+			rules: []Rule{
+				{Pattern: "/docs/", Owners: []string{"carol"}},
+				{Pattern: "*.sql", Owners: []string{"dave"}},
+			},
+		},
+		{
+			name: "escaped hash",
+			text: `\#notes @eve # not a pattern`,
+			rules: []Rule{
+				{Pattern: `\#notes`, Owners: []string{"eve"}},
+			},
+		},
+		{
+			name: "pattern without owners",
+			text: "/vendor/",
+			rules: []Rule{
+				{Pattern: "/vendor/", Owners: []string{}},
+			},
+		},
+		{name: "owner without an @", text: "*.go alice", err: true},
+		{name: "bare @", text: "*.go @", err: true},
+		{name: "negation", text: "!*.go @alice", err: true},
+		{name: "root only", text: "/ @alice", err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rules, err := Parse(tc.text)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", rules)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rules) != len(tc.rules) {
+				t.Fatalf("expected %d rules, got %+v", len(tc.rules), rules)
+			}
+			for i, rule := range rules {
+				if rule.Pattern != tc.rules[i].Pattern || !slices.Equal(rule.Owners, tc.rules[i].Owners) {
+					t.Errorf("rule %d: expected %+v, got %+v", i, tc.rules[i], rule)
+				}
+			}
+		})
+	}
+}
+
+func TestParseReportsLine(t *testing.T) {
+	_, err := Parse("*.go @alice\n\n*.sql dave\n")
+	if err == nil || err.Error() != `line 3: invalid owner "dave"` {
+		t.Fatalf("expected the error on line 3, got %v", err)
+	}
+}
+
+func TestOwners(t *testing.T) {
+	rules, err := Parse(`
+*                   @lead
+*.go                @gophers
+/internal/db/       @dba
+/internal/db/*.sql  @dba @analysts
+/docs/
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		path   string
+		owners []string
+	}{
+		{"README.md", []string{"lead"}},
+		{"main.go", []string{"gophers"}},
+		// The later rule wins even when an earlier one is more specific
+		{"internal/db/db.go", []string{"dba"}},
+		{"internal/db/schema.sql", []string{"dba", "analysts"}},
+		{"internal/db/migrations/1.sql", []string{"dba"}},
+		// A rule without owners takes the ownership away
+		{"docs/index.md", []string{}},
+	} {
+		if owners := Owners(rules, tc.path); !slices.Equal(owners, tc.owners) {
+			t.Errorf("%s: expected %v, got %v", tc.path, tc.owners, owners)
+		}
+	}
+
+	if owners := Owners(nil, "main.go"); owners != nil {
+		t.Errorf("expected no owners without rules, got %v", owners)
+	}
+}
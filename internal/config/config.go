@@ -0,0 +1,203 @@
+// Package config reads service settings from environment variables.
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	// AbsenceReassignInterval is how often open reviews of users whose absence has started
+	// are handed over to other reviewers. Zero disables the job.
+	AbsenceReassignInterval time.Duration
+
+	// SLACheckInterval is how often reviews past the review SLA of their team are escalated. Zero disables the job.
+	SLACheckInterval time.Duration
+
+	// DigestCheckInterval is how often users whose daily digest of open reviews is due are looked for.
+	// Zero disables digests, so does the lack of notifiers.
+	DigestCheckInterval time.Duration
+
+	// DigestWebhookURL receives digests as JSON
+	DigestWebhookURL string
+
+	// DigestSlackWebhookURL is a Slack-compatible incoming webhook receiving digests as messages
+	DigestSlackWebhookURL string
+
+	// SMTPAddr is host:port of the mail server emailing digests to users with an address. SMTPUsername
+	// and SMTPPassword are optional.
+	SMTPAddr     string
+	SMTPFrom     string
+	SMTPUsername string
+	SMTPPassword string
+
+	// AuditRetention is how long audit records are kept. Zero keeps them forever.
+	AuditRetention time.Duration
+
+	// EventsRetention is how long events of the stream are kept for clients resuming it
+	EventsRetention time.Duration
+
+	// IdempotencyTTL is how long idempotency keys and stored responses are kept
+	IdempotencyTTL time.Duration
+
+	// ReviewerSelection is how reviewers are chosen among candidates of the same pool: "random" or "fair".
+	// Fair selection avoids pairing the same author and reviewer over and over.
+	ReviewerSelection string
+
+	// FairnessWindow is how far back fair selection looks at reviews of the author. Older reviews don't count,
+	// recent ones count more.
+	FairnessWindow time.Duration
+
+	// RandomSeed seeds reviewer selection, so that the same requests sent one by one get the same reviewers.
+	// Nil seeds it from the clock.
+	RandomSeed *int64
+
+	// GRPCPort is the port of the gRPC API. Zero disables it.
+	GRPCPort int
+
+	// ValidateResponses turns on validation of responses against the OpenAPI spec, for tests and development
+	ValidateResponses bool
+}
+
+func Load() (*Config, error) {
+	cfg := &Config{}
+
+	var err error
+	if cfg.AbsenceReassignInterval, err = durationFromEnv("ABSENCE_REASSIGN_INTERVAL", 0); err != nil {
+		return nil, err
+	}
+
+	if cfg.SLACheckInterval, err = durationFromEnv("SLA_CHECK_INTERVAL", time.Minute); err != nil {
+		return nil, err
+	}
+
+	if cfg.DigestCheckInterval, err = durationFromEnv("DIGEST_CHECK_INTERVAL", 5*time.Minute); err != nil {
+		return nil, err
+	}
+	cfg.DigestWebhookURL = os.Getenv("DIGEST_WEBHOOK_URL")
+	cfg.DigestSlackWebhookURL = os.Getenv("DIGEST_SLACK_WEBHOOK_URL")
+	cfg.SMTPAddr = os.Getenv("SMTP_ADDR")
+	cfg.SMTPFrom = os.Getenv("SMTP_FROM")
+	cfg.SMTPUsername = os.Getenv("SMTP_USERNAME")
+	cfg.SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	if cfg.SMTPAddr != "" && cfg.SMTPFrom == "" {
+		return nil, fmt.Errorf("SMTP_FROM is required with SMTP_ADDR")
+	}
+
+	if cfg.AuditRetention, err = durationFromEnv("AUDIT_RETENTION", 0); err != nil {
+		return nil, err
+	}
+
+	if cfg.EventsRetention, err = durationFromEnv("EVENTS_RETENTION", 24*time.Hour); err != nil {
+		return nil, err
+	}
+
+	if cfg.IdempotencyTTL, err = durationFromEnv("IDEMPOTENCY_TTL", 24*time.Hour); err != nil {
+		return nil, err
+	}
+
+	if cfg.ReviewerSelection, err = oneOfFromEnv("REVIEWER_SELECTION", "random", "fair"); err != nil {
+		return nil, err
+	}
+
+	if cfg.FairnessWindow, err = durationFromEnv("FAIRNESS_WINDOW", 30*24*time.Hour); err != nil {
+		return nil, err
+	}
+	if cfg.FairnessWindow == 0 {
+		return nil, fmt.Errorf("FAIRNESS_WINDOW must be positive")
+	}
+
+	if cfg.RandomSeed, err = optionalInt64FromEnv("RANDOM_SEED"); err != nil {
+		return nil, err
+	}
+
+	if cfg.GRPCPort, err = intFromEnv("GRPC_PORT", 9090); err != nil {
+		return nil, err
+	}
+
+	if cfg.ValidateResponses, err = boolFromEnv("OPENAPI_VALIDATE_RESPONSES", false); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// oneOfFromEnv reads a value which must be one of values, the first one is the default
+func oneOfFromEnv(name string, values ...string) (string, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return values[0], nil
+	}
+
+	if !slices.Contains(values, value) {
+		return "", fmt.Errorf("%s must be one of %s", name, strings.Join(values, ", "))
+	}
+
+	return value, nil
+}
+
+func boolFromEnv(name string, def bool) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s is not a valid boolean: %w", name, err)
+	}
+
+	return b, nil
+}
+
+func intFromEnv(name string, def int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid integer: %w", name, err)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("%s must not be negative", name)
+	}
+
+	return n, nil
+}
+
+func optionalInt64FromEnv(name string) (*int64, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid integer: %w", name, err)
+	}
+
+	return &n, nil
+}
+
+func durationFromEnv(name string, def time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid duration: %w", name, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s must not be negative", name)
+	}
+
+	return d, nil
+}
@@ -1,74 +1,152 @@
+// Package db opens the storage of the service. PostgreSQL is the main backend, SQLite is meant for small
+// deployments and local development. Both have the same tables and queries are written in SQL both of them
+// understand: "$1" placeholders, NOW(), RETURNING, ON CONFLICT and FILTER work in either. The few differences
+// left are covered by Dialect.
 package db
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"os"
-	"time"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// Dialect is the SQL flavour of the backend
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// sqliteScheme starts DATABASE_URL of SQLite databases, e.g. sqlite:///var/lib/reviewers/data.db
+const sqliteScheme = "sqlite:"
+
+// ErrNoRows is returned by Row.Scan when the query selects nothing. Both backends report it with errors.Is.
+var ErrNoRows = sql.ErrNoRows
+
+// Row is a single row returned by QueryRow
+type Row interface {
+	Scan(dest ...any) error
+}
+
+// Rows is the result of Query, it must be closed
+type Rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Close()
+	Err() error
+}
+
+// Result tells how many rows Exec changed
+type Result interface {
+	RowsAffected() int64
+}
+
+// Querier is implemented both by DB and Tx, so helpers can be used in and out of transactions
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (Result, error)
+	Query(ctx context.Context, sql string, args ...any) (Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) Row
+}
+
+// Tx is a transaction. Rollback after Commit does nothing, so it is safe to defer.
+type Tx interface {
+	Querier
+	// CopyFrom inserts rows into the table, it is the fast way to load a lot of them
+	CopyFrom(ctx context.Context, table string, columns []string, rows [][]any) (int64, error)
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
+// backend is what both PostgreSQL and SQLite provide
+type backend interface {
+	Querier
+	Begin(ctx context.Context) (Tx, error)
+	// BeginSnapshot starts a read-only transaction which sees the data as of its start
+	BeginSnapshot(ctx context.Context) (Tx, error)
+	Close()
+}
+
 type DB struct {
+	backend
+	Dialect Dialect
+	// Pool is the connection pool of PostgreSQL, nil for SQLite. The event broker listens for notifications on it.
 	Pool *pgxpool.Pool
 }
 
 func NewDB() (*DB, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
 	dbURL := os.Getenv("DATABASE_URL")
 	if dbURL == "" {
 		return nil, fmt.Errorf("DATABASE_URL is not set")
 	}
 
-	pool, err := pgxpool.New(ctx, dbURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create connection pool: %w", err)
-	}
+	return Connect(dbURL)
+}
 
-	if err := pool.Ping(ctx); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+// Connect opens the database at dbURL and creates missing tables. The scheme of dbURL chooses the backend:
+// sqlite: URLs open a SQLite file, anything else is a PostgreSQL connection string.
+func Connect(dbURL string) (*DB, error) {
+	if strings.HasPrefix(dbURL, sqliteScheme) {
+		return connectSQLite(dbURL)
 	}
+	return connectPostgres(dbURL)
+}
 
-	if err := createTables(ctx, pool); err != nil {
-		return nil, err
+// ForUpdate is the locking clause of SELECT. SQLite has none: a write transaction locks the whole database
+// from its start, so rows it reads can't change under it anyway.
+func (d Dialect) ForUpdate() string {
+	if d == SQLite {
+		return ""
 	}
+	return " FOR UPDATE"
+}
 
-	return &DB{Pool: pool}, nil
+// JSONArrayContains is a condition that the JSON array of strings in column contains value, a placeholder
+func (d Dialect) JSONArrayContains(column, value string) string {
+	if d == SQLite {
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s) WHERE json_each.value = %s)", column, value)
+	}
+	return fmt.Sprintf("%s @> jsonb_build_array(%s::TEXT)", column, value)
 }
 
-func createTables(ctx context.Context, pool *pgxpool.Pool) error {
-	queries := []string{
-		`CREATE TABLE IF NOT EXISTS teams (
-			team_name TEXT PRIMARY KEY
-		);`,
-		`CREATE TABLE IF NOT EXISTS users (
-			user_id TEXT PRIMARY KEY,
-			username TEXT NOT NULL,
-			team_name TEXT NOT NULL REFERENCES teams(team_name),
-			is_active BOOLEAN NOT NULL DEFAULT TRUE
-		);`,
-		`CREATE TABLE IF NOT EXISTS prs (
-    		pull_request_id TEXT PRIMARY KEY,
-    		pull_request_name TEXT NOT NULL,
-    		author_id TEXT NOT NULL REFERENCES users(user_id),
-    		status TEXT NOT NULL CHECK ( status IN ('OPEN', 'MERGED')),
-    		assigned_reviewers TEXT[] NOT NULL,
-    		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-    		merged_at TIMESTAMPTZ
-    	);`,
+// Strings is a list of strings kept in a column as a JSON array, which both backends can store
+type Strings []string
+
+func (s Strings) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(s))
+	if err != nil {
+		return nil, err
 	}
+	return string(data), nil
+}
 
-	for _, q := range queries {
-		if _, err := pool.Exec(ctx, q); err != nil {
-			return fmt.Errorf("failed to execute query: %w", err)
-		}
+func (s *Strings) Scan(src any) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		*s = Strings{}
+		return nil
+	case string:
+		data = []byte(src)
+	case []byte:
+		data = src
+	default:
+		return fmt.Errorf("cannot scan %T into Strings", src)
 	}
 
+	list := make([]string, 0)
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("failed to decode list: %w", err)
+	}
+	*s = list
 	return nil
 }
-
-func (db *DB) Close() {
-	db.Pool.Close()
-}
@@ -0,0 +1,111 @@
+// Package dbtest opens empty stores of every backend for tests. SQLite runs always, PostgreSQL runs when
+// TEST_DATABASE_URL points to a database the tests may create schemas in; every store gets its own schema.
+// With TEST_REQUIRE_POSTGRES=1 a missing TEST_DATABASE_URL fails tests instead of skipping them, for CI.
+package dbtest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+// Open opens a new empty store, closed and dropped when the test ends
+type Open func(t *testing.T) *db.DB
+
+// Backend is a storage backend the tests run against
+type Backend struct {
+	Name string
+	Open Open
+}
+
+// stores numbers the stores of the test binary, so that their files and schemas never clash
+var stores atomic.Int64
+
+// Backends returns SQLite and, if the test database is configured, PostgreSQL
+func Backends() []Backend {
+	return []Backend{
+		{Name: "sqlite", Open: SQLite},
+		{Name: "postgres", Open: Postgres},
+	}
+}
+
+// Run runs the test once for every backend as a subtest named after the backend
+func Run(t *testing.T, test func(t *testing.T, open Open)) {
+	for _, b := range Backends() {
+		t.Run(b.Name, func(t *testing.T) {
+			test(t, b.Open)
+		})
+	}
+}
+
+// SQLite opens a new file in the temporary directory of the test
+func SQLite(t *testing.T) *db.DB {
+	t.Helper()
+
+	store, err := db.Connect(fmt.Sprintf("sqlite://%s/store_%d.db", t.TempDir(), stores.Add(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(store.Close)
+
+	return store
+}
+
+// Postgres opens a new schema of the test database, the test is skipped if there is none
+func Postgres(t *testing.T) *db.DB {
+	t.Helper()
+
+	store, err := db.Connect(PostgresURL(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(store.Close)
+
+	return store
+}
+
+// PostgresURL creates a new empty schema of the test database and returns the URL to connect to it.
+// Nothing is created in the schema, so tests can set up tables the way older versions of the service did.
+func PostgresURL(t *testing.T) string {
+	t.Helper()
+
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	if dbURL == "" {
+		if os.Getenv("TEST_REQUIRE_POSTGRES") == "1" {
+			t.Fatal("TEST_REQUIRE_POSTGRES is set, but TEST_DATABASE_URL is not")
+		}
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	schema := fmt.Sprintf("test_%d_%d", time.Now().UnixNano(), stores.Add(1))
+
+	admin, err := db.Connect(dbURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(admin.Close)
+
+	if _, err := admin.Exec(t.Context(), "CREATE SCHEMA "+schema); err != nil {
+		t.Fatal(err)
+	}
+	// t.Context is cancelled before cleanups run
+	t.Cleanup(func() {
+		admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+	})
+
+	schemaURL, err := url.Parse(dbURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := schemaURL.Query()
+	q.Set("search_path", schema)
+	schemaURL.RawQuery = q.Encode()
+
+	return schemaURL.String()
+}
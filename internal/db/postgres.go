@@ -0,0 +1,351 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pgQuerier is implemented both by *pgxpool.Pool and pgx.Tx
+type pgQuerier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// postgres runs queries as they are, pgx already has everything Querier needs
+type postgres struct {
+	q pgQuerier
+}
+
+func (p postgres) Exec(ctx context.Context, sql string, args ...any) (Result, error) {
+	return p.q.Exec(ctx, sql, args...)
+}
+
+func (p postgres) Query(ctx context.Context, sql string, args ...any) (Rows, error) {
+	return p.q.Query(ctx, sql, args...)
+}
+
+func (p postgres) QueryRow(ctx context.Context, sql string, args ...any) Row {
+	return p.q.QueryRow(ctx, sql, args...)
+}
+
+type postgresPool struct {
+	postgres
+	pool *pgxpool.Pool
+}
+
+func (p postgresPool) Begin(ctx context.Context) (Tx, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return postgresTx{postgres{tx}, tx}, nil
+}
+
+func (p postgresPool) BeginSnapshot(ctx context.Context) (Tx, error) {
+	tx, err := p.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	return postgresTx{postgres{tx}, tx}, nil
+}
+
+func (p postgresPool) Close() {
+	p.pool.Close()
+}
+
+type postgresTx struct {
+	postgres
+	tx pgx.Tx
+}
+
+func (t postgresTx) CopyFrom(ctx context.Context, table string, columns []string, rows [][]any) (int64, error) {
+	return t.tx.CopyFrom(ctx, pgx.Identifier{table}, columns, pgx.CopyFromRows(rows))
+}
+
+func (t postgresTx) Commit(ctx context.Context) error {
+	return t.tx.Commit(ctx)
+}
+
+func (t postgresTx) Rollback(ctx context.Context) error {
+	return t.tx.Rollback(ctx)
+}
+
+func connectPostgres(dbURL string) (*DB, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	if err := createPostgresTables(ctx, pool); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return &DB{backend: postgresPool{postgres{pool}, pool}, Dialect: Postgres, Pool: pool}, nil
+}
+
+func createPostgresTables(ctx context.Context, pool *pgxpool.Pool) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS teams (
+			team_name TEXT PRIMARY KEY
+		);`,
+		`CREATE TABLE IF NOT EXISTS users (
+			user_id TEXT PRIMARY KEY,
+			username TEXT NOT NULL,
+			team_name TEXT NOT NULL REFERENCES teams(team_name),
+			is_active BOOLEAN NOT NULL DEFAULT TRUE
+		);`,
+		`CREATE TABLE IF NOT EXISTS prs (
+    		pull_request_id TEXT PRIMARY KEY,
+    		pull_request_name TEXT NOT NULL,
+    		author_id TEXT NOT NULL REFERENCES users(user_id),
+    		status TEXT NOT NULL CHECK ( status IN ('OPEN', 'MERGED')),
+    		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    		merged_at TIMESTAMPTZ
+    	);`,
+		`CREATE TABLE IF NOT EXISTS team_fallbacks (
+			team_name TEXT NOT NULL REFERENCES teams(team_name),
+			fallback_team_name TEXT NOT NULL REFERENCES teams(team_name),
+			priority INT NOT NULL,
+			PRIMARY KEY (team_name, fallback_team_name),
+			CHECK ( team_name <> fallback_team_name )
+		);`,
+		`CREATE TABLE IF NOT EXISTS ownership_rules (
+			team_name TEXT NOT NULL REFERENCES teams(team_name),
+			position INT NOT NULL,
+			pattern TEXT NOT NULL,
+			owners JSONB NOT NULL,
+			PRIMARY KEY (team_name, position)
+		);`,
+		`ALTER TABLE prs ADD COLUMN IF NOT EXISTS changed_files JSONB NOT NULL DEFAULT '[]';`,
+		`CREATE TABLE IF NOT EXISTS absences (
+			absence_id BIGSERIAL PRIMARY KEY,
+			user_id TEXT NOT NULL REFERENCES users(user_id),
+			starts_at TIMESTAMPTZ NOT NULL,
+			ends_at TIMESTAMPTZ NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			reassigned_at TIMESTAMPTZ,
+			CHECK ( ends_at > starts_at )
+		);`,
+		`CREATE INDEX IF NOT EXISTS absences_user_id_idx ON absences(user_id, ends_at);`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INT CHECK ( max_open_reviews >= 0 );`,
+		`ALTER TABLE teams ADD COLUMN IF NOT EXISTS default_max_open_reviews INT CHECK ( default_max_open_reviews >= 0 );`,
+		`CREATE INDEX IF NOT EXISTS prs_created_idx ON prs(created_at DESC, pull_request_id DESC);`,
+		`CREATE INDEX IF NOT EXISTS prs_status_created_idx ON prs(status, created_at DESC, pull_request_id DESC);`,
+		`CREATE INDEX IF NOT EXISTS prs_author_created_idx ON prs(author_id, created_at DESC, pull_request_id DESC);`,
+		`CREATE INDEX IF NOT EXISTS prs_merged_idx ON prs(merged_at) WHERE merged_at IS NOT NULL;`,
+		`CREATE INDEX IF NOT EXISTS users_team_name_idx ON users(team_name);`,
+		`CREATE TABLE IF NOT EXISTS pr_events (
+			event_id BIGSERIAL PRIMARY KEY,
+			pull_request_id TEXT NOT NULL REFERENCES prs(pull_request_id),
+			type TEXT NOT NULL,
+			at TIMESTAMPTZ NOT NULL,
+			actor TEXT,
+			user_id TEXT,
+			replaced_by TEXT
+		);`,
+		`CREATE INDEX IF NOT EXISTS pr_events_pull_request_id_idx ON pr_events(pull_request_id, event_id);`,
+		`CREATE TABLE IF NOT EXISTS audit_log (
+			audit_id BIGSERIAL PRIMARY KEY,
+			at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			actor TEXT,
+			operation TEXT NOT NULL,
+			target_type TEXT NOT NULL,
+			target_id TEXT NOT NULL,
+			before JSONB,
+			after JSONB
+		);`,
+		`CREATE INDEX IF NOT EXISTS audit_log_target_idx ON audit_log(target_type, target_id, audit_id);`,
+		`CREATE INDEX IF NOT EXISTS audit_log_at_idx ON audit_log(at);`,
+		// Records are never changed, only the old ones are deleted by retention. Retention tells the trigger
+		// how old they must be with a row in audit_log_pruning, which no other transaction ever sees.
+		`CREATE TABLE IF NOT EXISTS audit_log_pruning (
+			cutoff TIMESTAMPTZ NOT NULL
+		);`,
+		`CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_log is append-only';
+		END;
+		$$ LANGUAGE plpgsql;`,
+		`CREATE OR REPLACE FUNCTION audit_log_pruned_only() RETURNS trigger AS $$
+		BEGIN
+			IF NOT EXISTS (SELECT 1 FROM audit_log_pruning WHERE OLD.at < audit_log_pruning.cutoff) THEN
+				RAISE EXCEPTION 'audit_log records are only deleted by retention';
+			END IF;
+			RETURN OLD;
+		END;
+		$$ LANGUAGE plpgsql;`,
+		// CREATE OR REPLACE TRIGGER needs PostgreSQL 14, the trigger is rather created once
+		`DO $$
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_trigger WHERE tgname = 'audit_log_append_only' AND tgrelid = 'audit_log'::regclass
+			) THEN
+				CREATE TRIGGER audit_log_append_only BEFORE UPDATE ON audit_log
+					FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+			END IF;
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_trigger WHERE tgname = 'audit_log_pruned_only' AND tgrelid = 'audit_log'::regclass
+			) THEN
+				CREATE TRIGGER audit_log_pruned_only BEFORE DELETE ON audit_log
+					FOR EACH ROW EXECUTE FUNCTION audit_log_pruned_only();
+			END IF;
+		END;
+		$$;`,
+		`CREATE TABLE IF NOT EXISTS idempotency_keys (
+			key TEXT PRIMARY KEY,
+			request_hash TEXT NOT NULL,
+			status INT,
+			body BYTEA,
+			expires_at TIMESTAMPTZ NOT NULL
+		);`,
+		`CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys(expires_at);`,
+		`ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS headers JSONB;`,
+		`ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;`,
+		`ALTER TABLE prs ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;`,
+		`CREATE TABLE IF NOT EXISTS stream_events (
+			event_id BIGSERIAL PRIMARY KEY,
+			type TEXT NOT NULL,
+			at TIMESTAMPTZ NOT NULL,
+			user_ids JSONB NOT NULL,
+			team_names JSONB NOT NULL,
+			data JSONB NOT NULL
+		);`,
+		`CREATE INDEX IF NOT EXISTS stream_events_at_idx ON stream_events(at);`,
+		// Event ids follow the order of commits, not of inserts, so that readers going on from the last id
+		// they have seen never skip an event committed late. An event gets a negative pending id when it is
+		// inserted and the real one when its transaction commits; the advisory lock taken for that is held
+		// until the commit ends, so the next transaction takes its id only after this one is visible.
+		// New events are announced on the stream_events channel, the payload is the event id.
+		`CREATE SEQUENCE IF NOT EXISTS stream_events_pending_seq;`,
+		`ALTER TABLE stream_events ALTER COLUMN event_id SET DEFAULT -nextval('stream_events_pending_seq');`,
+		`DROP TRIGGER IF EXISTS stream_events_notify ON stream_events;`,
+		`DROP FUNCTION IF EXISTS stream_events_notify();`,
+		`CREATE OR REPLACE FUNCTION stream_events_commit() RETURNS trigger AS $$
+		DECLARE
+			committed_id BIGINT;
+		BEGIN
+			PERFORM pg_advisory_xact_lock(hashtext('stream_events'));
+			committed_id := nextval('stream_events_event_id_seq');
+			UPDATE stream_events SET event_id = committed_id WHERE event_id = NEW.event_id;
+			PERFORM pg_notify('stream_events', committed_id::TEXT);
+			RETURN NULL;
+		END;
+		$$ LANGUAGE plpgsql;`,
+		`DO $$
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_trigger WHERE tgname = 'stream_events_commit' AND tgrelid = 'stream_events'::regclass
+			) THEN
+				CREATE CONSTRAINT TRIGGER stream_events_commit AFTER INSERT ON stream_events
+					DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION stream_events_commit();
+			END IF;
+		END;
+		$$;`,
+		// Reviewers of a PR in the order they were assigned, fallback ones came from fallback teams
+		`CREATE TABLE IF NOT EXISTS pr_reviewers (
+			pull_request_id TEXT NOT NULL REFERENCES prs(pull_request_id),
+			user_id TEXT NOT NULL REFERENCES users(user_id),
+			position INT NOT NULL,
+			is_fallback BOOLEAN NOT NULL DEFAULT FALSE,
+			PRIMARY KEY (pull_request_id, user_id)
+		);`,
+		`CREATE INDEX IF NOT EXISTS pr_reviewers_user_id_idx ON pr_reviewers(user_id, pull_request_id);`,
+		// Databases created before SQLite was supported keep lists in arrays: reviewers move to pr_reviewers,
+		// other lists become JSON like in SQLite. Databases older than fallback teams have no fallback reviewers.
+		`DO $$
+		BEGIN
+			IF EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = 'prs' AND column_name = 'assigned_reviewers'
+			) THEN
+				ALTER TABLE prs ADD COLUMN IF NOT EXISTS fallback_reviewers TEXT[] NOT NULL DEFAULT '{}';
+				INSERT INTO pr_reviewers(pull_request_id, user_id, position, is_fallback)
+				SELECT prs.pull_request_id, r.user_id, r.position - 1, r.user_id = ANY(prs.fallback_reviewers)
+				FROM prs, unnest(prs.assigned_reviewers) WITH ORDINALITY AS r(user_id, position);
+				ALTER TABLE prs DROP COLUMN assigned_reviewers, DROP COLUMN fallback_reviewers;
+			END IF;
+		END;
+		$$;`,
+		`DO $$
+		BEGIN
+			IF EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = 'prs' AND column_name = 'changed_files' AND data_type = 'ARRAY'
+			) THEN
+				ALTER TABLE prs ALTER COLUMN changed_files DROP DEFAULT;
+				ALTER TABLE prs ALTER COLUMN changed_files TYPE JSONB USING to_jsonb(changed_files);
+				ALTER TABLE prs ALTER COLUMN changed_files SET DEFAULT '[]';
+			END IF;
+			IF EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = 'ownership_rules' AND column_name = 'owners' AND data_type = 'ARRAY'
+			) THEN
+				ALTER TABLE ownership_rules ALTER COLUMN owners TYPE JSONB USING to_jsonb(owners);
+			END IF;
+			IF EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = 'stream_events' AND column_name = 'user_ids' AND data_type = 'ARRAY'
+			) THEN
+				ALTER TABLE stream_events ALTER COLUMN user_ids TYPE JSONB USING to_jsonb(user_ids);
+				ALTER TABLE stream_events ALTER COLUMN team_names TYPE JSONB USING to_jsonb(team_names);
+			END IF;
+		END;
+		$$;`,
+		`CREATE TABLE IF NOT EXISTS review_slas (
+			team_name TEXT PRIMARY KEY REFERENCES teams(team_name),
+			sla_seconds BIGINT NOT NULL CHECK ( sla_seconds > 0 ),
+			escalation TEXT NOT NULL CHECK ( escalation IN ('event', 'notify_lead', 'reassign') ),
+			lead_user_id TEXT REFERENCES users(user_id)
+		);`,
+		`CREATE TABLE IF NOT EXISTS sla_breaches (
+			breach_id BIGSERIAL PRIMARY KEY,
+			pull_request_id TEXT NOT NULL REFERENCES prs(pull_request_id),
+			user_id TEXT NOT NULL REFERENCES users(user_id),
+			team_name TEXT NOT NULL,
+			assigned_at TIMESTAMPTZ NOT NULL,
+			due_at TIMESTAMPTZ NOT NULL,
+			detected_at TIMESTAMPTZ NOT NULL,
+			escalation TEXT NOT NULL,
+			lead_user_id TEXT,
+			replaced_by TEXT,
+			UNIQUE (pull_request_id, user_id, assigned_at)
+		);`,
+		`CREATE INDEX IF NOT EXISTS sla_breaches_detected_at_idx ON sla_breaches(detected_at DESC, breach_id DESC);`,
+		`CREATE TABLE IF NOT EXISTS digest_settings (
+			user_id TEXT PRIMARY KEY REFERENCES users(user_id),
+			enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			timezone TEXT NOT NULL,
+			send_at TEXT NOT NULL,
+			email TEXT
+		);`,
+		`CREATE TABLE IF NOT EXISTS digest_deliveries (
+			user_id TEXT NOT NULL REFERENCES users(user_id),
+			digest_date TEXT NOT NULL,
+			sent_at TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (user_id, digest_date)
+		);`,
+	}
+
+	for _, q := range queries {
+		if _, err := pool.Exec(ctx, q); err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+	}
+
+	return nil
+}
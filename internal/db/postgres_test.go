@@ -0,0 +1,147 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+)
+
+// baselineSchema is how the first version of the service created its tables
+var baselineSchema = []string{
+	`CREATE TABLE teams (
+		team_name TEXT PRIMARY KEY
+	);`,
+	`CREATE TABLE users (
+		user_id TEXT PRIMARY KEY,
+		username TEXT NOT NULL,
+		team_name TEXT NOT NULL REFERENCES teams(team_name),
+		is_active BOOLEAN NOT NULL DEFAULT TRUE
+	);`,
+	`CREATE TABLE prs (
+		pull_request_id TEXT PRIMARY KEY,
+		pull_request_name TEXT NOT NULL,
+		author_id TEXT NOT NULL REFERENCES users(user_id),
+		status TEXT NOT NULL CHECK ( status IN ('OPEN', 'MERGED')),
+		assigned_reviewers TEXT[] NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		merged_at TIMESTAMPTZ
+	);`,
+	`INSERT INTO teams VALUES ('backend');`,
+	`INSERT INTO users VALUES ('u1', 'Alice', 'backend', TRUE), ('u2', 'Bob', 'backend', TRUE), ('u3', 'Carol', 'backend', TRUE);`,
+	`INSERT INTO prs(pull_request_id, pull_request_name, author_id, status, assigned_reviewers, merged_at) VALUES
+		('pr-1', 'Add search', 'u1', 'OPEN', '{u2,u3}', NULL),
+		('pr-2', 'Init repository', 'u1', 'MERGED', '{u3}', NOW());`,
+}
+
+type reviewer struct {
+	prId     string
+	userId   string
+	position int
+	fallback bool
+}
+
+// TestPostgresMigratesArrays opens databases created by older versions and expects reviewers
+// to move from arrays to pr_reviewers
+func TestPostgresMigratesArrays(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		schema    []string
+		reviewers []reviewer
+	}{
+		{
+			name:   "baseline",
+			schema: baselineSchema,
+			reviewers: []reviewer{
+				{"pr-1", "u2", 0, false},
+				{"pr-1", "u3", 1, false},
+				{"pr-2", "u3", 0, false},
+			},
+		},
+		{
+			name: "with fallback reviewers",
+			schema: append(baselineSchema[:len(baselineSchema):len(baselineSchema)],
+				`ALTER TABLE prs ADD COLUMN fallback_reviewers TEXT[] NOT NULL DEFAULT '{}';`,
+				`UPDATE prs SET fallback_reviewers='{u3}' WHERE pull_request_id='pr-1';`,
+			),
+			reviewers: []reviewer{
+				{"pr-1", "u2", 0, false},
+				{"pr-1", "u3", 1, true},
+				{"pr-2", "u3", 0, false},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dbURL := dbtest.PostgresURL(t)
+
+			old, err := pgxpool.New(t.Context(), dbURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, q := range tc.schema {
+				if _, err := old.Exec(t.Context(), q); err != nil {
+					old.Close()
+					t.Fatalf("failed to create the old schema: %v", err)
+				}
+			}
+			old.Close()
+
+			// The first start migrates, the next one finds nothing left to do
+			first, err := db.Connect(dbURL)
+			if err != nil {
+				t.Fatalf("failed to open the old database: %v", err)
+			}
+			first.Close()
+
+			store, err := db.Connect(dbURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(store.Close)
+
+			rows, err := store.Query(t.Context(), `
+				SELECT pull_request_id, user_id, position, is_fallback FROM pr_reviewers ORDER BY pull_request_id, position
+			`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
+
+			var got []reviewer
+			for rows.Next() {
+				var r reviewer
+				if err := rows.Scan(&r.prId, &r.userId, &r.position, &r.fallback); err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, r)
+			}
+			if err := rows.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != len(tc.reviewers) {
+				t.Fatalf("expected reviewers %+v, got %+v", tc.reviewers, got)
+			}
+			for i := range got {
+				if got[i] != tc.reviewers[i] {
+					t.Errorf("expected reviewers %+v, got %+v", tc.reviewers, got)
+					break
+				}
+			}
+
+			var arrays int
+			err = store.QueryRow(t.Context(), `
+				SELECT COUNT(*) FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = 'prs' AND data_type = 'ARRAY'
+			`).Scan(&arrays)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if arrays != 0 {
+				t.Errorf("expected no array columns left in prs, got %d", arrays)
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+// sqliteTimeFormat is how times are written, see _time_format=sqlite. All of them are in UTC,
+// so they compare as strings the same way as times.
+const sqliteTimeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+// sqliteOptions are added to every SQLite URL. Write transactions take the lock at once instead of upgrading
+// to it later, which would fail if another connection got there first. Readers don't block the writer in WAL mode.
+var sqliteOptions = url.Values{
+	"_pragma": {
+		"foreign_keys(1)",
+		"journal_mode(WAL)",
+		"busy_timeout(10000)",
+	},
+	"_txlock":      {"immediate"},
+	"_time_format": {"sqlite"},
+	"_timezone":    {"UTC"},
+}
+
+func init() {
+	// Queries and defaults are shared with PostgreSQL, which has NOW()
+	sqlite.MustRegisterScalarFunction("now", 0, func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+		return time.Now().UTC().Format(sqliteTimeFormat), nil
+	})
+}
+
+// sqlQuerier is implemented both by *sql.DB and *sql.Tx
+type sqlQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type sqliteQuerier struct {
+	q sqlQuerier
+}
+
+func (s sqliteQuerier) Exec(ctx context.Context, query string, args ...any) (Result, error) {
+	res, err := s.q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	return rowsAffected(n), nil
+}
+
+func (s sqliteQuerier) Query(ctx context.Context, query string, args ...any) (Rows, error) {
+	rows, err := s.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return sqliteRows{rows}, nil
+}
+
+func (s sqliteQuerier) QueryRow(ctx context.Context, query string, args ...any) Row {
+	return s.q.QueryRowContext(ctx, query, args...)
+}
+
+type rowsAffected int64
+
+func (n rowsAffected) RowsAffected() int64 {
+	return int64(n)
+}
+
+// sqliteRows closes like pgx rows do, errors of Close are reported by Err
+type sqliteRows struct {
+	*sql.Rows
+}
+
+func (r sqliteRows) Close() {
+	r.Rows.Close()
+}
+
+type sqliteDB struct {
+	sqliteQuerier
+	db *sql.DB
+}
+
+func (s sqliteDB) Begin(ctx context.Context) (Tx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sqliteTx{sqliteQuerier{tx}, tx}, nil
+}
+
+func (s sqliteDB) BeginSnapshot(ctx context.Context) (Tx, error) {
+	// A read-only transaction doesn't take the write lock and sees a snapshot in WAL mode
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return sqliteTx{sqliteQuerier{tx}, tx}, nil
+}
+
+func (s sqliteDB) Close() {
+	s.db.Close()
+}
+
+type sqliteTx struct {
+	sqliteQuerier
+	tx *sql.Tx
+}
+
+func (t sqliteTx) CopyFrom(ctx context.Context, table string, columns []string, rows [][]any) (int64, error) {
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	stmt, err := t.tx.PrepareContext(ctx, fmt.Sprintf(`INSERT INTO %s(%s) VALUES(%s)`,
+		table, strings.Join(columns, ", "), strings.Join(placeholders, ", ")))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(rows)), nil
+}
+
+func (t sqliteTx) Commit(context.Context) error {
+	return t.tx.Commit()
+}
+
+func (t sqliteTx) Rollback(context.Context) error {
+	err := t.tx.Rollback()
+	if err == sql.ErrTxDone {
+		return nil
+	}
+	return err
+}
+
+// sqliteDSN turns sqlite:path or sqlite://path into a file name the driver understands, with options added
+func sqliteDSN(dbURL string) (string, error) {
+	path := strings.TrimPrefix(strings.TrimPrefix(dbURL, sqliteScheme), "//")
+
+	var query string
+	path, query, _ = strings.Cut(path, "?")
+	if path == "" {
+		return "", fmt.Errorf("SQLite database path is empty")
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return "", fmt.Errorf("invalid SQLite options: %w", err)
+	}
+	for key, options := range sqliteOptions {
+		if _, ok := values[key]; !ok || key == "_pragma" {
+			values[key] = append(values[key], options...)
+		}
+	}
+
+	return "file:" + path + "?" + values.Encode(), nil
+}
+
+func connectSQLite(dbURL string) (*DB, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dsn, err := sqliteDSN(dbURL)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := createSQLiteTables(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &DB{backend: sqliteDB{sqliteQuerier{db}, db}, Dialect: SQLite}, nil
+}
+
+// createSQLiteTables creates the same tables as PostgreSQL has. Times are TIMESTAMP for the driver to read them
+// back as times, lists are JSON arrays and ids are AUTOINCREMENT so that they are never reused like sequences.
+func createSQLiteTables(ctx context.Context, db *sql.DB) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS teams (
+			team_name TEXT PRIMARY KEY,
+			default_max_open_reviews INTEGER CHECK ( default_max_open_reviews >= 0 )
+		);`,
+		`CREATE TABLE IF NOT EXISTS users (
+			user_id TEXT PRIMARY KEY,
+			username TEXT NOT NULL,
+			team_name TEXT NOT NULL REFERENCES teams(team_name),
+			is_active BOOLEAN NOT NULL DEFAULT TRUE,
+			max_open_reviews INTEGER CHECK ( max_open_reviews >= 0 )
+		);`,
+		`CREATE INDEX IF NOT EXISTS users_team_name_idx ON users(team_name);`,
+		`CREATE TABLE IF NOT EXISTS team_fallbacks (
+			team_name TEXT NOT NULL REFERENCES teams(team_name),
+			fallback_team_name TEXT NOT NULL REFERENCES teams(team_name),
+			priority INTEGER NOT NULL,
+			PRIMARY KEY (team_name, fallback_team_name),
+			CHECK ( team_name <> fallback_team_name )
+		);`,
+		`CREATE TABLE IF NOT EXISTS ownership_rules (
+			team_name TEXT NOT NULL REFERENCES teams(team_name),
+			position INTEGER NOT NULL,
+			pattern TEXT NOT NULL,
+			owners TEXT NOT NULL,
+			PRIMARY KEY (team_name, position)
+		);`,
+		`CREATE TABLE IF NOT EXISTS absences (
+			absence_id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id TEXT NOT NULL REFERENCES users(user_id),
+			starts_at TIMESTAMP NOT NULL,
+			ends_at TIMESTAMP NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			reassigned_at TIMESTAMP,
+			CHECK ( ends_at > starts_at )
+		);`,
+		`CREATE INDEX IF NOT EXISTS absences_user_id_idx ON absences(user_id, ends_at);`,
+		`CREATE TABLE IF NOT EXISTS prs (
+			pull_request_id TEXT PRIMARY KEY,
+			pull_request_name TEXT NOT NULL,
+			author_id TEXT NOT NULL REFERENCES users(user_id),
+			status TEXT NOT NULL CHECK ( status IN ('OPEN', 'MERGED')),
+			changed_files TEXT NOT NULL DEFAULT '[]',
+			created_at TIMESTAMP NOT NULL DEFAULT (now()),
+			merged_at TIMESTAMP,
+			version INTEGER NOT NULL DEFAULT 1
+		);`,
+		`CREATE INDEX IF NOT EXISTS prs_created_idx ON prs(created_at DESC, pull_request_id DESC);`,
+		`CREATE INDEX IF NOT EXISTS prs_status_created_idx ON prs(status, created_at DESC, pull_request_id DESC);`,
+		`CREATE INDEX IF NOT EXISTS prs_author_created_idx ON prs(author_id, created_at DESC, pull_request_id DESC);`,
+		`CREATE INDEX IF NOT EXISTS prs_merged_idx ON prs(merged_at) WHERE merged_at IS NOT NULL;`,
+		`CREATE TABLE IF NOT EXISTS pr_reviewers (
+			pull_request_id TEXT NOT NULL REFERENCES prs(pull_request_id),
+			user_id TEXT NOT NULL REFERENCES users(user_id),
+			position INTEGER NOT NULL,
+			is_fallback BOOLEAN NOT NULL DEFAULT FALSE,
+			PRIMARY KEY (pull_request_id, user_id)
+		);`,
+		`CREATE INDEX IF NOT EXISTS pr_reviewers_user_id_idx ON pr_reviewers(user_id, pull_request_id);`,
+		`CREATE TABLE IF NOT EXISTS pr_events (
+			event_id INTEGER PRIMARY KEY AUTOINCREMENT,
+			pull_request_id TEXT NOT NULL REFERENCES prs(pull_request_id),
+			type TEXT NOT NULL,
+			at TIMESTAMP NOT NULL,
+			actor TEXT,
+			user_id TEXT,
+			replaced_by TEXT
+		);`,
+		`CREATE INDEX IF NOT EXISTS pr_events_pull_request_id_idx ON pr_events(pull_request_id, event_id);`,
+		`CREATE TABLE IF NOT EXISTS audit_log (
+			audit_id INTEGER PRIMARY KEY AUTOINCREMENT,
+			at TIMESTAMP NOT NULL DEFAULT (now()),
+			actor TEXT,
+			operation TEXT NOT NULL,
+			target_type TEXT NOT NULL,
+			target_id TEXT NOT NULL,
+			before TEXT,
+			after TEXT
+		);`,
+		`CREATE INDEX IF NOT EXISTS audit_log_target_idx ON audit_log(target_type, target_id, audit_id);`,
+		`CREATE INDEX IF NOT EXISTS audit_log_at_idx ON audit_log(at);`,
+		// Records are never changed, only the old ones are deleted by retention. Retention tells the trigger
+		// how old they must be with a row in audit_log_pruning, which lives only in its own transaction.
+		`CREATE TABLE IF NOT EXISTS audit_log_pruning (
+			cutoff TIMESTAMP NOT NULL
+		);`,
+		`CREATE TRIGGER IF NOT EXISTS audit_log_append_only BEFORE UPDATE ON audit_log
+		BEGIN
+			SELECT RAISE(ABORT, 'audit_log is append-only');
+		END;`,
+		`CREATE TRIGGER IF NOT EXISTS audit_log_pruned_only BEFORE DELETE ON audit_log
+		WHEN NOT EXISTS (SELECT 1 FROM audit_log_pruning WHERE OLD.at < audit_log_pruning.cutoff)
+		BEGIN
+			SELECT RAISE(ABORT, 'audit_log records are only deleted by retention');
+		END;`,
+		`CREATE TABLE IF NOT EXISTS idempotency_keys (
+			key TEXT PRIMARY KEY,
+			request_hash TEXT NOT NULL,
+			status INTEGER,
+			body BLOB,
+			expires_at TIMESTAMP NOT NULL,
+			headers TEXT,
+			locked_until TIMESTAMP
+		);`,
+		`CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys(expires_at);`,
+		`CREATE TABLE IF NOT EXISTS stream_events (
+			event_id INTEGER PRIMARY KEY AUTOINCREMENT,
+			type TEXT NOT NULL,
+			at TIMESTAMP NOT NULL,
+			user_ids TEXT NOT NULL,
+			team_names TEXT NOT NULL,
+			data TEXT NOT NULL
+		);`,
+		`CREATE INDEX IF NOT EXISTS stream_events_at_idx ON stream_events(at);`,
+		`CREATE TABLE IF NOT EXISTS review_slas (
+			team_name TEXT PRIMARY KEY REFERENCES teams(team_name),
+			sla_seconds INTEGER NOT NULL CHECK ( sla_seconds > 0 ),
+			escalation TEXT NOT NULL CHECK ( escalation IN ('event', 'notify_lead', 'reassign') ),
+			lead_user_id TEXT REFERENCES users(user_id)
+		);`,
+		`CREATE TABLE IF NOT EXISTS sla_breaches (
+			breach_id INTEGER PRIMARY KEY AUTOINCREMENT,
+			pull_request_id TEXT NOT NULL REFERENCES prs(pull_request_id),
+			user_id TEXT NOT NULL REFERENCES users(user_id),
+			team_name TEXT NOT NULL,
+			assigned_at TIMESTAMP NOT NULL,
+			due_at TIMESTAMP NOT NULL,
+			detected_at TIMESTAMP NOT NULL,
+			escalation TEXT NOT NULL,
+			lead_user_id TEXT,
+			replaced_by TEXT,
+			UNIQUE (pull_request_id, user_id, assigned_at)
+		);`,
+		`CREATE INDEX IF NOT EXISTS sla_breaches_detected_at_idx ON sla_breaches(detected_at DESC, breach_id DESC);`,
+		`CREATE TABLE IF NOT EXISTS digest_settings (
+			user_id TEXT PRIMARY KEY REFERENCES users(user_id),
+			enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			timezone TEXT NOT NULL,
+			send_at TEXT NOT NULL,
+			email TEXT
+		);`,
+		`CREATE TABLE IF NOT EXISTS digest_deliveries (
+			user_id TEXT NOT NULL REFERENCES users(user_id),
+			digest_date TEXT NOT NULL,
+			sent_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (user_id, digest_date)
+		);`,
+	}
+
+	for _, q := range queries {
+		if _, err := db.ExecContext(ctx, q); err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+	}
+
+	return nil
+}
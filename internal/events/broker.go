@@ -0,0 +1,263 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+const (
+	// subscriptionBuffer is how many events a slow subscriber may lag behind before it is dropped
+	subscriptionBuffer = 64
+	// reconnectDelay is the pause before listening again after the connection is lost
+	reconnectDelay = 5 * time.Second
+	// pollInterval is how often new events are looked for on SQLite, which has no notifications
+	pollInterval = 250 * time.Millisecond
+	// ReplayPageSize is the maximum number of events Since returns at once
+	ReplayPageSize = 1000
+)
+
+// Broker listens for new events and hands them over to subscribers of this replica. Event ids follow
+// the order of commits on both backends, so the broker reads on from the last event it dispatched and
+// misses nothing committed while it was reconnecting.
+type Broker struct {
+	db *db.DB
+
+	// lastId is the last dispatched event, it is used only by the goroutine running the broker
+	lastId  int64
+	started bool
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+func NewBroker(db *db.DB) *Broker {
+	return &Broker{db: db, subs: make(map[*Subscription]struct{})}
+}
+
+// Subscription receives events matching its filter. Events is closed when the subscriber lags too much
+// behind, the client is expected to reconnect and resume from the last event it got.
+type Subscription struct {
+	Events <-chan *Event
+
+	events chan *Event
+	filter Filter
+}
+
+func (b *Broker) Subscribe(filter Filter) *Subscription {
+	events := make(chan *Event, subscriptionBuffer)
+	sub := &Subscription{Events: events, events: events, filter: filter}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
+
+// Run listens for new events until ctx is cancelled, reconnecting if the connection is lost.
+// Then it ends all subscriptions, so streams don't hold up the shutdown.
+// It blocks, so it is usually started in a goroutine.
+func (b *Broker) Run(ctx context.Context) {
+	defer b.closeAll()
+
+	for {
+		if err := b.watch(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("events: listening failed, retrying in %s: %v", reconnectDelay, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (b *Broker) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
+
+// watch dispatches new events until ctx is cancelled or the connection is lost
+func (b *Broker) watch(ctx context.Context) error {
+	if b.db.Dialect == db.SQLite {
+		return b.poll(ctx)
+	}
+	return b.listen(ctx)
+}
+
+// catchUp dispatches the events committed after the last dispatched one. The first call only finds
+// where the stream ends: older events are replayed by clients resuming from their last event.
+func (b *Broker) catchUp(ctx context.Context) error {
+	if !b.started {
+		if err := b.db.QueryRow(ctx, `SELECT COALESCE(MAX(event_id), 0) FROM stream_events`).Scan(&b.lastId); err != nil {
+			return err
+		}
+		b.started = true
+		return nil
+	}
+
+	for {
+		events, err := b.Since(ctx, b.lastId, Filter{})
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			b.dispatch(e)
+			b.lastId = e.Id
+		}
+
+		if len(events) < ReplayPageSize {
+			return nil
+		}
+	}
+}
+
+// listen wakes up on notifications about new events. The events themselves are read on from the last
+// dispatched one, so those committed while the broker was disconnected are dispatched too.
+func (b *Broker) listen(ctx context.Context) error {
+	conn, err := b.db.Pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection is in LISTEN state, it must not go back to the pool
+	defer conn.Hijack().Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	// Events committed before LISTEN have no notification coming
+	if err := b.catchUp(ctx); err != nil {
+		return err
+	}
+
+	for {
+		if _, err := conn.Conn().WaitForNotification(ctx); err != nil {
+			return err
+		}
+
+		if err := b.catchUp(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// poll looks for new events every pollInterval. SQLite has a single writer, so events are committed
+// in the order of their ids.
+func (b *Broker) poll(ctx context.Context) error {
+	if err := b.catchUp(ctx); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		if err := b.catchUp(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (b *Broker) dispatch(e *Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		if !sub.filter.matches(e) {
+			continue
+		}
+		select {
+		case sub.events <- e:
+		default:
+			// The subscriber can't keep up; it resumes from the stored events after reconnecting
+			delete(b.subs, sub)
+			close(sub.events)
+		}
+	}
+}
+
+const eventColumns = `event_id, type, at, user_ids, team_names, data`
+
+func scanEvent(row db.Row) (*Event, error) {
+	var (
+		e                  = &Event{}
+		userIds, teamNames db.Strings
+		data               []byte
+	)
+	if err := row.Scan(&e.Id, &e.Type, &e.At, &userIds, &teamNames, &data); err != nil {
+		return nil, err
+	}
+	e.At = e.At.UTC()
+	e.UserIds, e.TeamNames, e.Data = userIds, teamNames, data
+	return e, nil
+}
+
+// Since returns up to ReplayPageSize stored events after the given one which match the filter, oldest first
+func (b *Broker) Since(ctx context.Context, lastId int64, filter Filter) ([]*Event, error) {
+	rows, err := b.db.Query(ctx, `
+		SELECT `+eventColumns+` FROM stream_events
+		WHERE event_id > $1
+			AND ($2 = '' OR `+b.db.Dialect.JSONArrayContains("user_ids", "$2")+`)
+			AND ($3 = '' OR `+b.db.Dialect.JSONArrayContains("team_names", "$3")+`)
+		ORDER BY event_id
+		LIMIT $4
+	`, lastId, filter.UserId, filter.TeamName, ReplayPageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*Event, 0)
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// Prune deletes events older than retention, clients can't resume from them anymore
+func (b *Broker) Prune(ctx context.Context, retention time.Duration) error {
+	tag, err := b.db.Exec(ctx, `DELETE FROM stream_events WHERE at < $1`, time.Now().Add(-retention))
+	if err != nil {
+		return fmt.Errorf("failed to prune stream events: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		log.Printf("events: %d events older than %s deleted", tag.RowsAffected(), retention)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+)
+
+func publish(t *testing.T, q db.Querier, name string) {
+	t.Helper()
+
+	e := Event{Type: PRCreated, At: time.Now().UTC(), Data: json.RawMessage(fmt.Sprintf(`{"name":%q}`, name))}
+	if err := Publish(t.Context(), q, e); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// receive returns names of the next events of the subscription
+func receive(t *testing.T, sub *Subscription, n int) ([]string, []int64) {
+	t.Helper()
+
+	names := make([]string, 0, n)
+	ids := make([]int64, 0, n)
+	for range n {
+		select {
+		case e, ok := <-sub.Events:
+			if !ok {
+				t.Fatal("subscription is closed")
+			}
+			var data struct{ Name string }
+			if err := json.Unmarshal(e.Data, &data); err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, data.Name)
+			ids = append(ids, e.Id)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %d events, got %v", n, names)
+		}
+	}
+	return names, ids
+}
+
+// watch runs one connection of the broker until the returned function stops it
+func watch(t *testing.T, b *Broker) (stop func()) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.watch(ctx)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// TestBrokerReconnect dispatches events committed while the broker was disconnected once it is back
+func TestBrokerReconnect(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, open dbtest.Open) {
+		store := open(t)
+		b := NewBroker(store)
+		sub := b.Subscribe(Filter{})
+
+		publish(t, store, "before start")
+		// The stream starts where the broker does, earlier events are only replayed
+		if err := b.catchUp(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+
+		stop := watch(t, b)
+		publish(t, store, "first")
+		if names, _ := receive(t, sub, 1); names[0] != "first" {
+			t.Fatalf("expected the first event, got %v", names)
+		}
+		stop()
+
+		publish(t, store, "second")
+		publish(t, store, "third")
+
+		stop = watch(t, b)
+		defer stop()
+		if names, _ := receive(t, sub, 2); names[0] != "second" || names[1] != "third" {
+			t.Fatalf("expected events committed while disconnected, got %v", names)
+		}
+	})
+}
+
+// TestBrokerCommitOrder gives ids in the order transactions commit, so that readers going on from the last
+// id they have seen don't skip an event of a transaction which began earlier but committed later
+func TestBrokerCommitOrder(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, open dbtest.Open) {
+		store := open(t)
+		if store.Dialect == db.SQLite {
+			t.Skip("SQLite has a single writer, transactions can't commit out of order")
+		}
+
+		b := NewBroker(store)
+		sub := b.Subscribe(Filter{})
+		if err := b.catchUp(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+		stop := watch(t, b)
+		defer stop()
+
+		early, err := store.Begin(t.Context())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer early.Rollback(t.Context())
+		publish(t, early, "inserted first")
+
+		late, err := store.Begin(t.Context())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer late.Rollback(t.Context())
+		publish(t, late, "inserted second")
+
+		if err := late.Commit(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+		names, ids := receive(t, sub, 1)
+		if names[0] != "inserted second" {
+			t.Fatalf("expected the committed event, got %v", names)
+		}
+
+		if err := early.Commit(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+		names, laterIds := receive(t, sub, 1)
+		if names[0] != "inserted first" || laterIds[0] <= ids[0] {
+			t.Fatalf("expected the event committed last with a greater id than %d, got %v with %v", ids[0], names, laterIds)
+		}
+
+		// A client which has seen the first commit resumes with the second one
+		missed, err := b.Since(t.Context(), ids[0], Filter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(missed) != 1 || missed[0].Id != laterIds[0] {
+			t.Fatalf("expected the event committed last after %d, got %+v", ids[0], missed)
+		}
+	})
+}
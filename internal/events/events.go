@@ -0,0 +1,69 @@
+// Package events feeds the live stream of assignment events. Events are written to stream_events table
+// in the same transaction as the change. Their ids follow the order of commits: on PostgreSQL a trigger gives
+// an event its id when the transaction commits and announces it with NOTIFY, so every replica's Broker sees it;
+// on SQLite, which has a single writer, ids are in commit order anyway and the Broker polls the table. The table also lets
+// clients resume the stream after reconnecting.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+// Types of events
+const (
+	PRCreated        = "pr.created"
+	ReviewerAssigned = "reviewer.assigned"
+	ReviewerRemoved  = "reviewer.removed"
+	ReviewerReplaced = "reviewer.replaced"
+	PRReviewed       = "pr.reviewed"
+	PRMerged         = "pr.merged"
+	UserActivity     = "user.activity"
+	SLABreached      = "review.sla_breached"
+)
+
+// channel is the NOTIFY channel of the trigger on stream_events, the payload is the id of a new event
+const channel = "stream_events"
+
+// Event is a row of stream_events table
+type Event struct {
+	Id        int64
+	Type      string
+	At        time.Time
+	UserIds   []string // users the event concerns, for filtering
+	TeamNames []string // teams of these users, for filtering
+	Data      json.RawMessage
+}
+
+// Filter selects events of a user or a team. Empty filter selects every event.
+type Filter struct {
+	UserId   string
+	TeamName string
+}
+
+func (f Filter) matches(e *Event) bool {
+	return (f.UserId == "" || contains(e.UserIds, f.UserId)) &&
+		(f.TeamName == "" || contains(e.TeamNames, f.TeamName))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Publish writes the event. Callers publish in the same transaction as the change itself,
+// so subscribers never see changes which were rolled back.
+func Publish(ctx context.Context, q db.Querier, e Event) error {
+	_, err := q.Exec(ctx, `
+		INSERT INTO stream_events(type, at, user_ids, team_names, data)
+		VALUES($1, $2, $3, $4, $5)
+	`, e.Type, e.At, db.Strings(e.UserIds), db.Strings(e.TeamNames), string(e.Data))
+	return err
+}
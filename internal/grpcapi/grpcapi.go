@@ -0,0 +1,4 @@
+// Package grpcapi contains the protobuf definition of the gRPC API and the code generated from it
+package grpcapi
+
+//go:generate go tool buf generate
@@ -0,0 +1,117 @@
+package grpcserver
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/grpcapi"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+func fromTeam(team *grpcapi.Team) api.Team {
+	members := make([]api.TeamMember, 0, len(team.GetMembers()))
+	for _, m := range team.GetMembers() {
+		members = append(members, api.TeamMember{UserId: m.GetUserId(), Username: m.GetUsername(), IsActive: m.GetIsActive()})
+	}
+
+	fallbacks := team.GetFallbackTeams()
+	result := api.Team{
+		TeamName:      team.GetTeamName(),
+		Members:       members,
+		FallbackTeams: &fallbacks,
+	}
+	if team.DefaultMaxOpenReviews != nil {
+		capacity := int(team.GetDefaultMaxOpenReviews())
+		result.DefaultMaxOpenReviews = &capacity
+	}
+	return result
+}
+
+func toTeam(team *api.Team) *grpcapi.Team {
+	members := make([]*grpcapi.TeamMember, 0, len(team.Members))
+	for _, m := range team.Members {
+		members = append(members, &grpcapi.TeamMember{UserId: m.UserId, Username: m.Username, IsActive: m.IsActive})
+	}
+
+	result := &grpcapi.Team{
+		TeamName:              team.TeamName,
+		Members:               members,
+		DefaultMaxOpenReviews: toInt32(team.DefaultMaxOpenReviews),
+	}
+	if team.FallbackTeams != nil {
+		result.FallbackTeams = *team.FallbackTeams
+	}
+	return result
+}
+
+func toUser(user *api.User) *grpcapi.User {
+	result := &grpcapi.User{
+		UserId:         user.UserId,
+		Username:       user.Username,
+		TeamName:       user.TeamName,
+		IsActive:       user.IsActive,
+		MaxOpenReviews: toInt32(user.MaxOpenReviews),
+	}
+	if user.OpenReviews != nil {
+		result.OpenReviews = int32(*user.OpenReviews)
+	}
+	return result
+}
+
+func toPullRequest(pr *handler.VersionedPullRequest) *grpcapi.PullRequest {
+	result := &grpcapi.PullRequest{
+		PullRequestId:     pr.PullRequestId,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorId,
+		Status:            toStatusEnum(string(pr.Status)),
+		AssignedReviewers: pr.AssignedReviewers,
+		CreatedAt:         toTimestamp(pr.CreatedAt),
+		MergedAt:          toTimestamp(pr.MergedAt),
+		Version:           pr.Version,
+	}
+	if pr.FallbackReviewers != nil {
+		result.FallbackReviewers = *pr.FallbackReviewers
+	}
+	return result
+}
+
+func toPullRequestShort(pr api.PullRequestShort) *grpcapi.PullRequestShort {
+	result := &grpcapi.PullRequestShort{
+		PullRequestId:   pr.PullRequestId,
+		PullRequestName: pr.PullRequestName,
+		AuthorId:        pr.AuthorId,
+		Status:          toStatusEnum(string(pr.Status)),
+		CreatedAt:       toTimestamp(pr.CreatedAt),
+	}
+	if pr.OtherReviewers != nil {
+		result.OtherReviewers = *pr.OtherReviewers
+	}
+	return result
+}
+
+func toStatusEnum(status string) grpcapi.PullRequestStatus {
+	switch status {
+	case string(api.PullRequestStatusOPEN):
+		return grpcapi.PullRequestStatus_PULL_REQUEST_STATUS_OPEN
+	case string(api.PullRequestStatusMERGED):
+		return grpcapi.PullRequestStatus_PULL_REQUEST_STATUS_MERGED
+	}
+	return grpcapi.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toInt32(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
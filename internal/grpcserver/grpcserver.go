@@ -0,0 +1,127 @@
+// Package grpcserver serves the gRPC API. It calls the same domain methods of handler.Handler as the HTTP API,
+// so both APIs share validation, storage, PR history and the audit log.
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/grpcapi"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+const (
+	// actorKey is the metadata key with the id of whoever makes the change, like X-Actor-Id of the HTTP API
+	actorKey = "x-actor-id"
+	// errorDomain is the domain of ErrorInfo in error details
+	errorDomain = "pr-reviewers"
+)
+
+type Server struct {
+	grpcapi.UnimplementedTeamServiceServer
+	grpcapi.UnimplementedUserServiceServer
+	grpcapi.UnimplementedPullRequestServiceServer
+
+	h *handler.Handler
+}
+
+// New returns a gRPC server with all services registered
+func New(h *handler.Handler) *grpc.Server {
+	s := &Server{h: h}
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(recoverPanics))
+	grpcapi.RegisterTeamServiceServer(server, s)
+	grpcapi.RegisterUserServiceServer(server, s)
+	grpcapi.RegisterPullRequestServiceServer(server, s)
+
+	return server
+}
+
+// statusCodes maps error codes of the API to gRPC status codes
+var statusCodes = map[api.ErrorResponseErrorCode]codes.Code{
+	api.INVALIDREQUEST:      codes.InvalidArgument,
+	api.NOTFOUND:            codes.NotFound,
+	api.TEAMEXISTS:          codes.AlreadyExists,
+	api.PREXISTS:            codes.AlreadyExists,
+	api.ALREADYASSIGNED:     codes.AlreadyExists,
+	api.PRMERGED:            codes.FailedPrecondition,
+	api.NOTASSIGNED:         codes.FailedPrecondition,
+	api.NOCANDIDATE:         codes.FailedPrecondition,
+	api.REVIEWERNOTELIGIBLE: codes.FailedPrecondition,
+	api.TOOMANYREVIEWERS:    codes.FailedPrecondition,
+	api.ATCAPACITY:          codes.ResourceExhausted,
+	api.PRVERSIONMISMATCH:   codes.Aborted,
+}
+
+// toStatus turns an error of the domain methods into a gRPC status error. The error code of the API
+// goes to ErrorInfo reason, internal errors are logged and hidden behind INTERNAL_ERROR.
+func toStatus(err error, method string) error {
+	code, msg, ok := handler.ClientError(err)
+	if !ok {
+		log.Printf("grpc: %s failed: %v", method, err)
+		msg = "internal error"
+	}
+
+	grpcCode, found := statusCodes[code]
+	if !found {
+		grpcCode = codes.Internal
+	}
+
+	return errorStatus(grpcCode, code, msg)
+}
+
+// errorStatus returns the status with the error code of the API in ErrorInfo
+func errorStatus(grpcCode codes.Code, code api.ErrorResponseErrorCode, msg string) error {
+	st, err := status.New(grpcCode, msg).WithDetails(&errdetails.ErrorInfo{Reason: string(code), Domain: errorDomain})
+	if err != nil {
+		return status.Error(grpcCode, msg)
+	}
+	return st.Err()
+}
+
+// invalidArgument rejects a request the domain methods can't be called with, like INVALID_REQUEST of the HTTP API
+func invalidArgument(msg string) error {
+	return errorStatus(codes.InvalidArgument, api.INVALIDREQUEST, msg)
+}
+
+// recoverPanics turns a panic of a call into INTERNAL_ERROR, so that a bug in one call doesn't bring
+// the whole server down
+func recoverPanics(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			log.Printf("grpc: %s panicked: %v\n%s", info.FullMethod, v, debug.Stack())
+			resp, err = nil, errorStatus(codes.Internal, api.INTERNALERROR, "internal error")
+		}
+	}()
+
+	return next(ctx, req)
+}
+
+// requestActor returns the actor of the call or nil if the client didn't tell
+func requestActor(ctx context.Context) *string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	if values := md.Get(actorKey); len(values) > 0 && values[0] != "" {
+		return &values[0]
+	}
+	return nil
+}
+
+// ifMatch turns the expected version into the entity tag the domain methods compare with
+func ifMatch(expectedVersion *int64) *string {
+	if expectedVersion == nil {
+		return nil
+	}
+	tag := handler.ETag(*expectedVersion)
+	return &tag
+}
@@ -0,0 +1,296 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/events"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/grpcapi"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/handler"
+)
+
+// clients talks to a server over an in-memory connection
+type clients struct {
+	teams grpcapi.TeamServiceClient
+	users grpcapi.UserServiceClient
+	prs   grpcapi.PullRequestServiceClient
+}
+
+// newClients serves the API over the store and returns clients of it, backend has four members,
+// so there is always somebody to reassign to
+func newClients(t *testing.T, open dbtest.Open) clients {
+	t.Helper()
+
+	store := open(t)
+	server := New(handler.NewHandler(store, events.NewBroker(store), handler.Options{}))
+
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	c := clients{
+		teams: grpcapi.NewTeamServiceClient(conn),
+		users: grpcapi.NewUserServiceClient(conn),
+		prs:   grpcapi.NewPullRequestServiceClient(conn),
+	}
+
+	_, err = c.teams.AddTeam(t.Context(), &grpcapi.AddTeamRequest{Team: &grpcapi.Team{
+		TeamName: "backend",
+		Members: []*grpcapi.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Carol", IsActive: true},
+			{UserId: "u7", Username: "Grace", IsActive: true},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+// asActor adds the actor to the outgoing metadata
+func asActor(ctx context.Context, actor string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, actorKey, actor)
+}
+
+// expectStatus checks the status code and the error code of the API in ErrorInfo
+func expectStatus(t *testing.T, err error, grpcCode codes.Code, code api.ErrorResponseErrorCode) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != grpcCode {
+		t.Fatalf("expected %s, got %v", grpcCode, err)
+	}
+
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if info.GetReason() != string(code) || info.GetDomain() != errorDomain {
+				t.Errorf("expected ErrorInfo %s of %s, got %s of %s", code, errorDomain, info.GetReason(), info.GetDomain())
+			}
+			return
+		}
+	}
+	t.Errorf("expected ErrorInfo with %s in details of %v", code, err)
+}
+
+func TestRoundTrip(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, open dbtest.Open) {
+		c := newClients(t, open)
+		ctx := asActor(t.Context(), "u1")
+
+		team, err := c.teams.GetTeam(ctx, &grpcapi.GetTeamRequest{TeamName: "backend"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(team.GetTeam().GetMembers()) != 4 {
+			t.Errorf("expected four members of backend, got %+v", team.GetTeam())
+		}
+
+		created, err := c.prs.CreatePullRequest(ctx, &grpcapi.CreatePullRequestRequest{
+			PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pr := created.GetPr()
+		if pr.GetStatus() != grpcapi.PullRequestStatus_PULL_REQUEST_STATUS_OPEN || len(pr.GetAssignedReviewers()) != 2 ||
+			slices.Contains(pr.GetAssignedReviewers(), "u1") || pr.GetCreatedAt() == nil || pr.GetMergedAt() != nil {
+			t.Fatalf("expected an open PR with two reviewers other than the author, got %+v", pr)
+		}
+
+		old := pr.GetAssignedReviewers()[0]
+		reassigned, err := c.prs.ReassignReviewer(ctx, &grpcapi.ReassignReviewerRequest{PullRequestId: "pr-1", OldUserId: old})
+		if err != nil {
+			t.Fatal(err)
+		}
+		replacedBy := reassigned.GetReplacedBy()
+		reviewers := reassigned.GetPr().GetAssignedReviewers()
+		if replacedBy == old || replacedBy == "u1" || slices.Contains(reviewers, old) || !slices.Contains(reviewers, replacedBy) {
+			t.Errorf("expected %s to be replaced, got %s and reviewers %v", old, replacedBy, reviewers)
+		}
+		if reassigned.GetPr().GetVersion() != pr.GetVersion()+1 {
+			t.Errorf("expected version %d after reassign, got %d", pr.GetVersion()+1, reassigned.GetPr().GetVersion())
+		}
+
+		merged, err := c.prs.MergePullRequest(ctx, &grpcapi.MergePullRequestRequest{PullRequestId: "pr-1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if merged.GetPr().GetStatus() != grpcapi.PullRequestStatus_PULL_REQUEST_STATUS_MERGED || merged.GetPr().GetMergedAt() == nil {
+			t.Errorf("expected the PR to be merged, got %+v", merged.GetPr())
+		}
+
+		// Merging again changes nothing
+		again, err := c.prs.MergePullRequest(ctx, &grpcapi.MergePullRequestRequest{PullRequestId: "pr-1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again.GetPr().GetVersion() != merged.GetPr().GetVersion() ||
+			!again.GetPr().GetMergedAt().AsTime().Equal(merged.GetPr().GetMergedAt().AsTime()) {
+			t.Errorf("expected the second merge to change nothing, got %+v after %+v", again.GetPr(), merged.GetPr())
+		}
+
+		review, err := c.users.GetReview(ctx, &grpcapi.GetReviewRequest{
+			UserId: replacedBy, Status: grpcapi.ReviewStatusFilter_REVIEW_STATUS_FILTER_MERGED,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if review.GetTotal() != 1 || review.GetPullRequests()[0].GetPullRequestId() != "pr-1" {
+			t.Errorf("expected pr-1 among merged reviews of %s, got %+v", replacedBy, review)
+		}
+	})
+}
+
+func TestErrors(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, open dbtest.Open) {
+		c := newClients(t, open)
+		ctx := t.Context()
+
+		created, err := c.prs.CreatePullRequest(ctx, &grpcapi.CreatePullRequestRequest{
+			PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.prs.CreatePullRequest(ctx, &grpcapi.CreatePullRequestRequest{
+			PullRequestId: "pr-2", PullRequestName: "Fix login", AuthorId: "u1",
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.prs.MergePullRequest(ctx, &grpcapi.MergePullRequestRequest{PullRequestId: "pr-2"}); err != nil {
+			t.Fatal(err)
+		}
+		reviewer := created.GetPr().GetAssignedReviewers()[0]
+
+		for _, tc := range []struct {
+			name     string
+			call     func() error
+			grpcCode codes.Code
+			code     api.ErrorResponseErrorCode
+		}{
+			{"team without a team", func() error {
+				_, err := c.teams.AddTeam(ctx, &grpcapi.AddTeamRequest{})
+				return err
+			}, codes.InvalidArgument, api.INVALIDREQUEST},
+			{"unknown team", func() error {
+				_, err := c.teams.GetTeam(ctx, &grpcapi.GetTeamRequest{TeamName: "mobile"})
+				return err
+			}, codes.NotFound, api.NOTFOUND},
+			{"team exists", func() error {
+				_, err := c.teams.AddTeam(ctx, &grpcapi.AddTeamRequest{Team: &grpcapi.Team{
+					TeamName: "backend",
+					Members:  []*grpcapi.TeamMember{{UserId: "u8", Username: "Heidi", IsActive: true}},
+				}})
+				return err
+			}, codes.AlreadyExists, api.TEAMEXISTS},
+			{"unknown user", func() error {
+				_, err := c.users.GetUser(ctx, &grpcapi.GetUserRequest{UserId: "u404"})
+				return err
+			}, codes.NotFound, api.NOTFOUND},
+			{"PR exists", func() error {
+				_, err := c.prs.CreatePullRequest(ctx, &grpcapi.CreatePullRequestRequest{
+					PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1",
+				})
+				return err
+			}, codes.AlreadyExists, api.PREXISTS},
+			{"PR merged", func() error {
+				_, err := c.prs.ReassignReviewer(ctx, &grpcapi.ReassignReviewerRequest{PullRequestId: "pr-2", OldUserId: reviewer})
+				return err
+			}, codes.FailedPrecondition, api.PRMERGED},
+			{"not assigned", func() error {
+				_, err := c.prs.ReassignReviewer(ctx, &grpcapi.ReassignReviewerRequest{PullRequestId: "pr-1", OldUserId: "u1"})
+				return err
+			}, codes.FailedPrecondition, api.NOTASSIGNED},
+			{"version mismatch", func() error {
+				stale := created.GetPr().GetVersion() + 1
+				_, err := c.prs.MergePullRequest(ctx, &grpcapi.MergePullRequestRequest{PullRequestId: "pr-1", ExpectedVersion: &stale})
+				return err
+			}, codes.Aborted, api.PRVERSIONMISMATCH},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				expectStatus(t, tc.call(), tc.grpcCode, tc.code)
+			})
+		}
+	})
+}
+
+func TestExpectedVersion(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, open dbtest.Open) {
+		c := newClients(t, open)
+		ctx := t.Context()
+
+		created, err := c.prs.CreatePullRequest(ctx, &grpcapi.CreatePullRequestRequest{
+			PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		version := created.GetPr().GetVersion()
+
+		reassigned, err := c.prs.ReassignReviewer(ctx, &grpcapi.ReassignReviewerRequest{
+			PullRequestId: "pr-1", OldUserId: created.GetPr().GetAssignedReviewers()[0], ExpectedVersion: &version,
+		})
+		if err != nil {
+			t.Fatalf("expected reassign with the current version to succeed, got %v", err)
+		}
+
+		// The version read before the reassign is stale now
+		_, err = c.prs.ReassignReviewer(ctx, &grpcapi.ReassignReviewerRequest{
+			PullRequestId: "pr-1", OldUserId: reassigned.GetReplacedBy(), ExpectedVersion: &version,
+		})
+		expectStatus(t, err, codes.Aborted, api.PRVERSIONMISMATCH)
+		_, err = c.prs.MergePullRequest(ctx, &grpcapi.MergePullRequestRequest{PullRequestId: "pr-1", ExpectedVersion: &version})
+		expectStatus(t, err, codes.Aborted, api.PRVERSIONMISMATCH)
+
+		current := reassigned.GetPr().GetVersion()
+		merged, err := c.prs.MergePullRequest(ctx, &grpcapi.MergePullRequestRequest{PullRequestId: "pr-1", ExpectedVersion: &current})
+		if err != nil {
+			t.Fatalf("expected merge with the current version to succeed, got %v", err)
+		}
+		if merged.GetPr().GetVersion() != current+1 {
+			t.Errorf("expected version %d after merge, got %d", current+1, merged.GetPr().GetVersion())
+		}
+	})
+}
+
+func TestRecoverPanics(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/prreviewers.v1.TeamService/AddTeam"}
+
+	_, err := recoverPanics(t.Context(), nil, info, func(ctx context.Context, req any) (any, error) {
+		panic("nil pointer dereference")
+	})
+	expectStatus(t, err, codes.Internal, api.INTERNALERROR)
+
+	resp, err := recoverPanics(t.Context(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	if resp != "ok" || err != nil {
+		t.Errorf("expected the call to pass through, got %v and %v", resp, err)
+	}
+}
@@ -0,0 +1,125 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/grpcapi"
+)
+
+func (s *Server) AddTeam(ctx context.Context, req *grpcapi.AddTeamRequest) (*grpcapi.AddTeamResponse, error) {
+	if req.GetTeam() == nil {
+		return nil, invalidArgument("team is required")
+	}
+
+	team, err := s.h.AddTeam(ctx, fromTeam(req.GetTeam()), requestActor(ctx))
+	if err != nil {
+		return nil, toStatus(err, "AddTeam")
+	}
+	return &grpcapi.AddTeamResponse{Team: toTeam(team)}, nil
+}
+
+func (s *Server) GetTeam(ctx context.Context, req *grpcapi.GetTeamRequest) (*grpcapi.GetTeamResponse, error) {
+	team, err := s.h.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, toStatus(err, "GetTeam")
+	}
+	return &grpcapi.GetTeamResponse{Team: toTeam(team)}, nil
+}
+
+func (s *Server) SetIsActive(ctx context.Context, req *grpcapi.SetIsActiveRequest) (*grpcapi.SetIsActiveResponse, error) {
+	user, err := s.h.SetIsActive(ctx, req.GetUserId(), req.GetIsActive(), requestActor(ctx))
+	if err != nil {
+		return nil, toStatus(err, "SetIsActive")
+	}
+	return &grpcapi.SetIsActiveResponse{User: toUser(user)}, nil
+}
+
+func (s *Server) GetUser(ctx context.Context, req *grpcapi.GetUserRequest) (*grpcapi.GetUserResponse, error) {
+	user, err := s.h.GetUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(err, "GetUser")
+	}
+	return &grpcapi.GetUserResponse{User: toUser(user)}, nil
+}
+
+func (s *Server) GetReview(ctx context.Context, req *grpcapi.GetReviewRequest) (*grpcapi.GetReviewResponse, error) {
+	params := api.GetUsersGetReviewParams{
+		UserId: req.GetUserId(),
+		Cursor: req.Cursor,
+	}
+
+	switch req.GetStatus() {
+	case grpcapi.ReviewStatusFilter_REVIEW_STATUS_FILTER_MERGED:
+		status := api.MERGED
+		params.Status = &status
+	case grpcapi.ReviewStatusFilter_REVIEW_STATUS_FILTER_ALL:
+		status := api.ALL
+		params.Status = &status
+	}
+
+	if req.GetOrder() == grpcapi.SortOrder_SORT_ORDER_ASC {
+		order := api.Asc
+		params.Order = &order
+	}
+
+	if req.Limit != nil {
+		limit := int(req.GetLimit())
+		params.Limit = &limit
+	}
+
+	inbox, err := s.h.GetReview(ctx, params)
+	if err != nil {
+		return nil, toStatus(err, "GetReview")
+	}
+
+	prs := make([]*grpcapi.PullRequestShort, 0, len(inbox.PullRequests))
+	for _, pr := range inbox.PullRequests {
+		prs = append(prs, toPullRequestShort(pr))
+	}
+
+	return &grpcapi.GetReviewResponse{
+		UserId:       inbox.UserId,
+		PullRequests: prs,
+		Total:        int32(inbox.Total),
+		NextCursor:   inbox.NextCursor,
+	}, nil
+}
+
+func (s *Server) CreatePullRequest(ctx context.Context, req *grpcapi.CreatePullRequestRequest) (*grpcapi.CreatePullRequestResponse, error) {
+	changedFiles := req.GetChangedFiles()
+	body := api.PostPullRequestCreateJSONBody{
+		PullRequestId:   req.GetPullRequestId(),
+		PullRequestName: req.GetPullRequestName(),
+		AuthorId:        req.GetAuthorId(),
+		ChangedFiles:    &changedFiles,
+	}
+
+	pr, capacityLimited, err := s.h.CreatePullRequest(ctx, body, requestActor(ctx), nil)
+	if err != nil {
+		return nil, toStatus(err, "CreatePullRequest")
+	}
+	return &grpcapi.CreatePullRequestResponse{Pr: toPullRequest(pr), CapacityLimited: capacityLimited}, nil
+}
+
+func (s *Server) MergePullRequest(ctx context.Context, req *grpcapi.MergePullRequestRequest) (*grpcapi.MergePullRequestResponse, error) {
+	pr, err := s.h.MergePullRequest(ctx, req.GetPullRequestId(), ifMatch(req.ExpectedVersion), requestActor(ctx))
+	if err != nil {
+		return nil, toStatus(err, "MergePullRequest")
+	}
+	return &grpcapi.MergePullRequestResponse{Pr: toPullRequest(pr)}, nil
+}
+
+func (s *Server) ReassignReviewer(ctx context.Context, req *grpcapi.ReassignReviewerRequest) (*grpcapi.ReassignReviewerResponse, error) {
+	body := api.PostPullRequestReassignJSONBody{
+		PullRequestId: req.GetPullRequestId(),
+		OldUserId:     req.GetOldUserId(),
+		NewUserId:     req.NewUserId,
+	}
+
+	pr, replacedBy, err := s.h.ReassignReviewer(ctx, body, ifMatch(req.ExpectedVersion), requestActor(ctx), nil)
+	if err != nil {
+		return nil, toStatus(err, "ReassignReviewer")
+	}
+	return &grpcapi.ReassignReviewerResponse{Pr: toPullRequest(pr), ReplacedBy: replacedBy}, nil
+}
@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+func (h *Handler) PostUsersAddAbsence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body api.PostUsersAddAbsenceJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if body.UserId == "" {
+		writeError(w, api.INVALIDREQUEST, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	if body.StartsAt.IsZero() || body.EndsAt.IsZero() {
+		writeError(w, api.INVALIDREQUEST, "starts_at and ends_at are required", http.StatusBadRequest)
+		return
+	}
+
+	if !body.EndsAt.After(body.StartsAt) {
+		writeError(w, api.INVALIDREQUEST, "ends_at must be after starts_at", http.StatusBadRequest)
+		return
+	}
+
+	var exists bool
+	err := h.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE user_id=$1)", body.UserId).Scan(&exists)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to check if user exists", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		writeError(w, api.NOTFOUND, "user not found", http.StatusNotFound)
+		return
+	}
+
+	absence := api.Absence{
+		UserId:   body.UserId,
+		StartsAt: body.StartsAt.UTC(),
+		EndsAt:   body.EndsAt.UTC(),
+	}
+	if body.Reason != nil {
+		absence.Reason = *body.Reason
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO absences(user_id, starts_at, ends_at, reason)
+		VALUES($1, $2, $3, $4)
+		RETURNING absence_id
+	`, absence.UserId, absence.StartsAt, absence.EndsAt, absence.Reason).Scan(&absence.AbsenceId)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to add absence", http.StatusInternalServerError)
+		return
+	}
+
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      requestActor(r),
+		operation:  "users.addAbsence",
+		targetType: targetAbsence,
+		targetId:   strconv.FormatInt(absence.AbsenceId, 10),
+		after:      snapshot(absence),
+	})
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to write audit log", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to add absence", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]api.Absence{"absence": absence})
+}
+
+func (h *Handler) GetUsersGetAbsences(w http.ResponseWriter, r *http.Request, params api.GetUsersGetAbsencesParams) {
+	ctx := r.Context()
+	userId := params.UserId
+
+	var exists bool
+	err := h.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE user_id=$1)", userId).Scan(&exists)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to check if user exists", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		writeError(w, api.NOTFOUND, "user not found", http.StatusNotFound)
+		return
+	}
+
+	// Finished absences are of no interest, so we return only current and upcoming ones
+	rows, err := h.db.Query(ctx, `
+		SELECT absence_id, user_id, starts_at, ends_at, reason
+		FROM absences
+		WHERE user_id=$1 AND ends_at > $2
+		ORDER BY starts_at
+	`, userId, h.now())
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch absences", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	absences := make([]api.Absence, 0)
+	for rows.Next() {
+		var a api.Absence
+		if err := rows.Scan(&a.AbsenceId, &a.UserId, &a.StartsAt, &a.EndsAt, &a.Reason); err != nil {
+			writeError(w, api.INTERNALERROR, "failed to scan absence", http.StatusInternalServerError)
+			return
+		}
+		absences = append(absences, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch absences", http.StatusInternalServerError)
+		return
+	}
+
+	resp := map[string]interface{}{
+		"user_id":  userId,
+		"absences": absences,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PostUsersRemoveAbsence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body api.PostUsersRemoveAbsenceJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	var a api.Absence
+	err = tx.QueryRow(ctx, `
+		DELETE FROM absences
+		WHERE absence_id=$1
+		RETURNING absence_id, user_id, starts_at, ends_at, reason
+	`, body.AbsenceId).Scan(&a.AbsenceId, &a.UserId, &a.StartsAt, &a.EndsAt, &a.Reason)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			writeError(w, api.NOTFOUND, "absence not found", http.StatusNotFound)
+			return
+		}
+		writeError(w, api.INTERNALERROR, "failed to remove absence", http.StatusInternalServerError)
+		return
+	}
+	a.StartsAt = a.StartsAt.UTC()
+	a.EndsAt = a.EndsAt.UTC()
+
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      requestActor(r),
+		operation:  "users.removeAbsence",
+		targetType: targetAbsence,
+		targetId:   strconv.FormatInt(a.AbsenceId, 10),
+		before:     snapshot(a),
+	})
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to write audit log", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to remove absence", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]api.Absence{"absence": a})
+}
+
+// ReassignAbsentReviewers hands open reviews of users whose absence has just started over to other
+// candidates. Each absence is processed once, in a transaction of its own which claims it first, so that
+// replicas running the job at the same time never both act on it. Reviews without a replacement candidate
+// stay as they are.
+func (h *Handler) ReassignAbsentReviewers(ctx context.Context) error {
+	now := h.now()
+
+	rows, err := h.db.Query(ctx, `
+		SELECT absence_id, user_id
+		FROM absences
+		WHERE reassigned_at IS NULL AND starts_at <= $1 AND ends_at > $1
+		ORDER BY absence_id
+	`, now)
+	if err != nil {
+		return fmt.Errorf("failed to fetch started absences: %w", err)
+	}
+
+	started := make([]startedAbsence, 0)
+	for rows.Next() {
+		var a startedAbsence
+		if err := rows.Scan(&a.absenceId, &a.userId); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan started absences: %w", err)
+		}
+		started = append(started, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to fetch started absences: %w", err)
+	}
+
+	for _, a := range started {
+		if err := h.reassignAbsence(ctx, a, now); err != nil {
+			return fmt.Errorf("absence %d: %w", a.absenceId, err)
+		}
+	}
+
+	return nil
+}
+
+type startedAbsence struct {
+	absenceId int64
+	userId    string
+}
+
+// reassignAbsence claims the absence and reassigns open reviews of the absent user in one transaction.
+// If another replica has claimed it first, there is nothing left to do.
+func (h *Handler) reassignAbsence(ctx context.Context, a startedAbsence, now time.Time) error {
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// The row stays locked until commit, a concurrent claim waits for it and then finds it processed
+	res, err := tx.Exec(ctx, `
+		UPDATE absences SET reassigned_at=$1 WHERE absence_id=$2 AND reassigned_at IS NULL
+	`, now, a.absenceId)
+	if err != nil {
+		return fmt.Errorf("failed to claim absence: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return nil
+	}
+
+	prIds, err := openReviewsOf(ctx, tx, a.userId)
+	if err != nil {
+		return fmt.Errorf("failed to fetch reviews of %s: %w", a.userId, err)
+	}
+
+	for _, prId := range prIds {
+		actor := systemActor
+		_, newReviewer, err := h.reassignReviewerTx(ctx, tx, prId, a.userId, "", nil, &actor, nil)
+		var apiErr *apiError
+		if errors.As(err, &apiErr) {
+			// Nobody is there to take it, nothing we can do
+			log.Printf("absence %d: PR %s stays with %s: %s", a.absenceId, prId, a.userId, apiErr.msg)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to reassign PR %s: %w", prId, err)
+		}
+		log.Printf("absence %d: PR %s reassigned from %s to %s", a.absenceId, prId, a.userId, newReviewer)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// openReviewsOf returns ids of open PRs the user is a reviewer of
+func openReviewsOf(ctx context.Context, q querier, userId string) ([]string, error) {
+	rows, err := q.Query(ctx, `
+		SELECT prs.pull_request_id
+		FROM prs JOIN pr_reviewers ON pr_reviewers.pull_request_id = prs.pull_request_id
+		WHERE prs.status = 'OPEN' AND pr_reviewers.user_id = $1
+		ORDER BY prs.pull_request_id
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prIds := make([]string, 0)
+	for rows.Next() {
+		var prId string
+		if err := rows.Scan(&prId); err != nil {
+			return nil, err
+		}
+		prIds = append(prIds, prId)
+	}
+
+	return prIds, rows.Err()
+}
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+func (h *Handler) PostPullRequestAddReviewer(w http.ResponseWriter, r *http.Request, params api.PostPullRequestAddReviewerParams) {
+	ctx := r.Context()
+	var body api.PostPullRequestAddReviewerJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if body.PullRequestId == "" {
+		writeError(w, api.INVALIDREQUEST, "pull_request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	if body.UserId == "" {
+		writeError(w, api.INVALIDREQUEST, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	pr, err := h.lockPullRequest(ctx, tx, body.PullRequestId)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch PR")
+		return
+	}
+
+	if err := pr.checkIfMatch(params.IfMatch); err != nil {
+		writeAPIError(w, err, "failed to check PR version")
+		return
+	}
+
+	if pr.status != string(api.PullRequestStatusOPEN) {
+		writeError(w, api.PRMERGED, "cannot change reviewers of merged PR", http.StatusConflict)
+		return
+	}
+
+	before := snapshot(pr.toAPI())
+
+	var authorTeam string
+	err = tx.QueryRow(ctx, "SELECT team_name FROM users WHERE user_id=$1", pr.authorId).Scan(&authorTeam)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to get author's team", http.StatusInternalServerError)
+		return
+	}
+
+	teams, err := reviewerTeams(ctx, tx, authorTeam)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to get fallback teams", http.StatusInternalServerError)
+		return
+	}
+
+	reviewer, err := checkReviewer(ctx, tx, h.now(), pr, body.UserId, authorTeam, teams)
+	if err != nil {
+		writeAPIError(w, err, "failed to check reviewer")
+		return
+	}
+
+	if len(pr.reviewers) >= reviewersPerPR {
+		writeError(w, api.TOOMANYREVIEWERS, fmt.Sprintf("PR already has %d reviewers", reviewersPerPR), http.StatusConflict)
+		return
+	}
+
+	pr.reviewers = append(pr.reviewers, reviewer.userId)
+	pr.fallback = append(pr.fallback, fallbackReviewers([]candidate{reviewer}, authorTeam)...)
+
+	if err := pr.saveReviewers(ctx, tx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update reviewers", http.StatusInternalServerError)
+		return
+	}
+
+	event := reviewerEvent(api.PullRequestEventTypeREVIEWERASSIGNED, h.now(), requestActor(r), reviewer.userId)
+	if err := recordEvents(ctx, tx, pr, event); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to record PR history", http.StatusInternalServerError)
+		return
+	}
+
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      event.actor,
+		operation:  "pullRequest.addReviewer",
+		targetType: targetPullRequest,
+		targetId:   pr.id,
+		before:     before,
+		after:      snapshot(pr.toAPI()),
+	})
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to write audit log", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update reviewers", http.StatusInternalServerError)
+		return
+	}
+
+	setETag(w, pr.version)
+	writeJSON(w, http.StatusOK, map[string]api.PullRequest{"pr": pr.toAPI()})
+}
+
+func (h *Handler) PostPullRequestRemoveReviewer(w http.ResponseWriter, r *http.Request, params api.PostPullRequestRemoveReviewerParams) {
+	ctx := r.Context()
+	var body api.PostPullRequestRemoveReviewerJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if body.PullRequestId == "" {
+		writeError(w, api.INVALIDREQUEST, "pull_request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	if body.UserId == "" {
+		writeError(w, api.INVALIDREQUEST, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	pr, err := h.lockPullRequest(ctx, tx, body.PullRequestId)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch PR")
+		return
+	}
+
+	if err := pr.checkIfMatch(params.IfMatch); err != nil {
+		writeAPIError(w, err, "failed to check PR version")
+		return
+	}
+
+	if pr.status != string(api.PullRequestStatusOPEN) {
+		writeError(w, api.PRMERGED, "cannot change reviewers of merged PR", http.StatusConflict)
+		return
+	}
+
+	before := snapshot(pr.toAPI())
+
+	if pr.reviewerIndex(body.UserId) == -1 {
+		writeError(w, api.NOTASSIGNED, "reviewer is not assigned to this PR", http.StatusConflict)
+		return
+	}
+
+	pr.removeReviewer(body.UserId)
+
+	if err := pr.saveReviewers(ctx, tx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update reviewers", http.StatusInternalServerError)
+		return
+	}
+
+	event := reviewerEvent(api.PullRequestEventTypeREVIEWERREMOVED, h.now(), requestActor(r), body.UserId)
+	if err := recordEvents(ctx, tx, pr, event); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to record PR history", http.StatusInternalServerError)
+		return
+	}
+
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      event.actor,
+		operation:  "pullRequest.removeReviewer",
+		targetType: targetPullRequest,
+		targetId:   pr.id,
+		before:     before,
+		after:      snapshot(pr.toAPI()),
+	})
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to write audit log", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update reviewers", http.StatusInternalServerError)
+		return
+	}
+
+	setETag(w, pr.version)
+	writeJSON(w, http.StatusOK, map[string]api.PullRequest{"pr": pr.toAPI()})
+}
+
+// reviewerTeams returns the teams a reviewer of the author's PR may come from: the author's team and its fallback teams
+func reviewerTeams(ctx context.Context, q querier, authorTeam string) ([]string, error) {
+	fallbacks, err := fallbackTeams(ctx, q, authorTeam)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{authorTeam}, fallbacks...), nil
+}
+
+// reassignTeams returns the teams a replacement of the old reviewer may come from: the old reviewer's team
+// first, then the author's team and its fallback teams
+func reassignTeams(ctx context.Context, q querier, oldTeam, authorTeam string) ([]string, error) {
+	authorTeams, err := reviewerTeams(ctx, q, authorTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := []string{oldTeam}
+	for _, team := range authorTeams {
+		if team != oldTeam {
+			teams = append(teams, team)
+		}
+	}
+	return teams, nil
+}
+
+// checkReviewer validates a reviewer chosen by hand: the user must exist, be active and not away at now,
+// not be the author or already assigned, and belong to one of the teams. Capacity is not checked,
+// whoever picks a reviewer by hand knows better.
+func checkReviewer(ctx context.Context, q querier, now time.Time, pr *pullRequest, userId, authorTeam string, teams []string) (candidate, error) {
+	var (
+		c        = candidate{userId: userId}
+		isActive bool
+		present  bool
+	)
+	err := q.QueryRow(ctx, `SELECT team_name, is_active, `+notAway(2)+` FROM users WHERE user_id=$1`, userId, now).Scan(&c.teamName, &isActive, &present)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return candidate{}, &apiError{api.NOTFOUND, "user not found", http.StatusNotFound}
+		}
+		return candidate{}, err
+	}
+
+	if userId == pr.authorId {
+		return candidate{}, &apiError{api.REVIEWERNOTELIGIBLE, "author cannot review own PR", http.StatusConflict}
+	}
+
+	if pr.reviewerIndex(userId) != -1 {
+		return candidate{}, &apiError{api.ALREADYASSIGNED, "user is already assigned to this PR", http.StatusConflict}
+	}
+
+	if !isActive {
+		return candidate{}, &apiError{api.REVIEWERNOTELIGIBLE, "user is not active", http.StatusConflict}
+	}
+
+	if !present {
+		return candidate{}, &apiError{api.REVIEWERNOTELIGIBLE, "user is away", http.StatusConflict}
+	}
+
+	member := false
+	for _, team := range teams {
+		if team == c.teamName {
+			member = true
+			break
+		}
+	}
+	if !member {
+		return candidate{}, &apiError{api.REVIEWERNOTELIGIBLE, "user is not a member of the author's team or its fallback teams", http.StatusConflict}
+	}
+
+	c.pool = poolTeam
+	if c.teamName != authorTeam {
+		c.pool = poolFallback
+	}
+
+	return c, nil
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+)
+
+// Targets of audit records
+const (
+	targetTeam        = "team"
+	targetUser        = "user"
+	targetAbsence     = "absence"
+	targetPullRequest = "pull_request"
+)
+
+// auditRecord is a row of audit_log table. Snapshots are marshalled right away, so later changes
+// of the objects don't leak into them.
+type auditRecord struct {
+	actor      *string
+	operation  string
+	targetType string
+	targetId   string
+	before     []byte
+	after      []byte
+}
+
+// snapshot returns JSON of the object or nil if there is no object
+func snapshot(v any) []byte {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		// Snapshots are API types, they always marshal
+		panic(fmt.Sprintf("failed to marshal audit snapshot: %v", err))
+	}
+	return data
+}
+
+// recordAudit appends the record to the audit log. Callers write it in the same transaction as the change itself.
+func recordAudit(ctx context.Context, q querier, rec auditRecord) error {
+	_, err := q.Exec(ctx, `
+		INSERT INTO audit_log(actor, operation, target_type, target_id, before, after)
+		VALUES($1, $2, $3, $4, $5, $6)
+	`, rec.actor, rec.operation, rec.targetType, rec.targetId, jsonText(rec.before), jsonText(rec.after))
+	return err
+}
+
+// jsonText passes a snapshot as text, so that SQLite stores it as JSON and not as a blob. Absent snapshots stay NULL.
+func jsonText(data []byte) *string {
+	if data == nil {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
+
+// auditCursor points at the last record of a page, records are ordered by id descending
+type auditCursor struct {
+	AuditId int64 `json:"audit_id"`
+}
+
+func (h *Handler) GetAudit(w http.ResponseWriter, r *http.Request, params api.GetAuditParams) {
+	ctx := r.Context()
+
+	limit, err := pageLimit(params.Limit)
+	if err != nil {
+		writeAPIError(w, err, "invalid limit")
+		return
+	}
+
+	var cond conditions
+
+	if params.Actor != nil {
+		cond.add("actor = ?", *params.Actor)
+	}
+	if params.Operation != nil {
+		cond.add("operation = ?", *params.Operation)
+	}
+	if params.TargetType != nil {
+		cond.add("target_type = ?", string(*params.TargetType))
+	}
+	if params.TargetId != nil {
+		cond.add("target_id = ?", *params.TargetId)
+	}
+	if params.From != nil {
+		cond.add("at >= ?", *params.From)
+	}
+	if params.To != nil {
+		cond.add("at < ?", *params.To)
+	}
+	if params.Cursor != nil {
+		var cursor auditCursor
+		if err := decodeCursor(*params.Cursor, &cursor); err != nil || cursor.AuditId == 0 {
+			writeError(w, api.INVALIDREQUEST, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+		cond.add("audit_id < ?", cursor.AuditId)
+	}
+
+	// One extra row tells whether there is a next page
+	query := `SELECT audit_id, at, actor, operation, target_type, target_id, before, after FROM audit_log` + cond.where() +
+		fmt.Sprintf(` ORDER BY audit_id DESC LIMIT %d`, limit+1)
+
+	rows, err := h.db.Query(ctx, query, cond.args...)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch audit log", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	records := make([]api.AuditRecord, 0, limit+1)
+	for rows.Next() {
+		var (
+			rec           api.AuditRecord
+			targetType    string
+			before, after []byte
+		)
+		if err := rows.Scan(&rec.AuditId, &rec.At, &rec.Actor, &rec.Operation, &targetType, &rec.TargetId, &before, &after); err != nil {
+			writeError(w, api.INTERNALERROR, "failed to scan audit record", http.StatusInternalServerError)
+			return
+		}
+		rec.TargetType = api.AuditRecordTargetType(targetType)
+		rec.At = rec.At.UTC()
+		if rec.Before, err = unmarshalSnapshot(before); err != nil {
+			writeError(w, api.INTERNALERROR, "failed to decode audit record", http.StatusInternalServerError)
+			return
+		}
+		if rec.After, err = unmarshalSnapshot(after); err != nil {
+			writeError(w, api.INTERNALERROR, "failed to decode audit record", http.StatusInternalServerError)
+			return
+		}
+		records = append(records, rec)
+	}
+
+	if err := rows.Err(); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch audit log", http.StatusInternalServerError)
+		return
+	}
+
+	var nextCursor *string
+	if len(records) > limit {
+		records = records[:limit]
+		cursor := encodeCursor(auditCursor{AuditId: records[limit-1].AuditId})
+		nextCursor = &cursor
+	}
+
+	resp := map[string]interface{}{
+		"records":     records,
+		"next_cursor": nextCursor,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func unmarshalSnapshot(data []byte) (*map[string]interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// PruneAuditLog deletes audit records older than retention. The trigger on audit_log rejects any other
+// deletion, so the cutoff is first written to audit_log_pruning and removed again in the same transaction.
+func (h *Handler) PruneAuditLog(ctx context.Context, retention time.Duration) error {
+	cutoff := h.now().Add(-retention)
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prune audit log: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `INSERT INTO audit_log_pruning(cutoff) VALUES($1)`, cutoff); err != nil {
+		return fmt.Errorf("failed to prune audit log: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM audit_log WHERE at < $1`, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to prune audit log: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM audit_log_pruning`); err != nil {
+		return fmt.Errorf("failed to prune audit log: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to prune audit log: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		log.Printf("audit log: %d records older than %s deleted", tag.RowsAffected(), retention)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+// loadUser returns the user together with the current review load and effective capacity
+func loadUser(ctx context.Context, q querier, userId string) (*api.User, error) {
+	var (
+		user        api.User
+		openReviews int
+	)
+	err := q.QueryRow(ctx, `
+		SELECT users.user_id, users.username, users.team_name, users.is_active,
+			COALESCE(users.max_open_reviews, teams.default_max_open_reviews),
+			`+openReviewCount+`
+		FROM users JOIN teams ON teams.team_name = users.team_name
+		WHERE users.user_id=$1
+	`, userId).Scan(&user.UserId, &user.Username, &user.TeamName, &user.IsActive, &user.MaxOpenReviews, &openReviews)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, &apiError{api.NOTFOUND, "user not found", http.StatusNotFound}
+		}
+		return nil, err
+	}
+	user.OpenReviews = &openReviews
+
+	return &user, nil
+}
+
+func (h *Handler) GetUsersGet(w http.ResponseWriter, r *http.Request, params api.GetUsersGetParams) {
+	user, err := h.GetUser(r.Context(), params.UserId)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch user")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]*api.User{"user": user})
+}
+
+// GetUser returns the user, see loadUser
+func (h *Handler) GetUser(ctx context.Context, userId string) (*api.User, error) {
+	return loadUser(ctx, h.db, userId)
+}
+
+func (h *Handler) PostUsersSetCapacity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body api.PostUsersSetCapacityJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if body.UserId == "" {
+		writeError(w, api.INVALIDREQUEST, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	if body.MaxOpenReviews != nil && *body.MaxOpenReviews < 0 {
+		writeError(w, api.INVALIDREQUEST, "max_open_reviews cannot be negative", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := loadUser(ctx, tx, body.UserId)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch user")
+		return
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET max_open_reviews=$1 WHERE user_id=$2`, body.MaxOpenReviews, body.UserId)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update user", http.StatusInternalServerError)
+		return
+	}
+
+	user, err := loadUser(ctx, tx, body.UserId)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch user")
+		return
+	}
+
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      requestActor(r),
+		operation:  "users.setCapacity",
+		targetType: targetUser,
+		targetId:   body.UserId,
+		before:     snapshot(before),
+		after:      snapshot(user),
+	})
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to write audit log", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update user", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]*api.User{"user": user})
+}
+
+func (h *Handler) PostTeamSetDefaultCapacity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body api.PostTeamSetDefaultCapacityJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if body.TeamName == "" {
+		writeError(w, api.INVALIDREQUEST, "team_name is required", http.StatusBadRequest)
+		return
+	}
+
+	if body.DefaultMaxOpenReviews != nil && *body.DefaultMaxOpenReviews < 0 {
+		writeError(w, api.INVALIDREQUEST, "default_max_open_reviews cannot be negative", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := loadTeam(ctx, tx, body.TeamName)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch team")
+		return
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE teams SET default_max_open_reviews=$1 WHERE team_name=$2`, body.DefaultMaxOpenReviews, body.TeamName)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update team", http.StatusInternalServerError)
+		return
+	}
+
+	team, err := loadTeam(ctx, tx, body.TeamName)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch team")
+		return
+	}
+
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      requestActor(r),
+		operation:  "team.setDefaultCapacity",
+		targetType: targetTeam,
+		targetId:   body.TeamName,
+		before:     snapshot(before),
+		after:      snapshot(team),
+	})
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to write audit log", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update team", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]*api.Team{"team": team})
+}
@@ -0,0 +1,319 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/mail"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/notify"
+)
+
+// Digest settings of users who haven't changed them
+const (
+	defaultDigestTimezone = "UTC"
+	defaultDigestTime     = "09:00"
+)
+
+// digestTimeLayout is the layout of the local time a digest is sent at
+const digestTimeLayout = "15:04"
+
+// loadDigestSettings returns digest settings of the user, the defaults if the user has never changed them
+func loadDigestSettings(ctx context.Context, q querier, userId string) (*api.DigestSettings, error) {
+	var exists bool
+	if err := q.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE user_id=$1)", userId).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &apiError{api.NOTFOUND, "user not found", http.StatusNotFound}
+	}
+
+	settings := api.DigestSettings{
+		UserId:   userId,
+		Enabled:  true,
+		Timezone: defaultDigestTimezone,
+		Time:     defaultDigestTime,
+	}
+	err := q.QueryRow(ctx, `
+		SELECT enabled, timezone, send_at, email FROM digest_settings WHERE user_id=$1
+	`, userId).Scan(&settings.Enabled, &settings.Timezone, &settings.Time, &settings.Email)
+	if err != nil && !errors.Is(err, db.ErrNoRows) {
+		return nil, err
+	}
+
+	err = q.QueryRow(ctx, `
+		SELECT MAX(digest_date) FROM digest_deliveries WHERE user_id=$1
+	`, userId).Scan(&settings.LastSentOn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (h *Handler) GetUsersGetDigest(w http.ResponseWriter, r *http.Request, params api.GetUsersGetDigestParams) {
+	settings, err := loadDigestSettings(r.Context(), h.db, params.UserId)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch digest settings")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]*api.DigestSettings{"digest": settings})
+}
+
+func (h *Handler) PostUsersSetDigest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body api.PostUsersSetDigestJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if body.UserId == "" {
+		writeError(w, api.INVALIDREQUEST, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// "Local" is the zone of the server, not of the user
+	if body.Timezone != nil {
+		if _, err := time.LoadLocation(*body.Timezone); err != nil || *body.Timezone == "" || *body.Timezone == "Local" {
+			writeError(w, api.INVALIDREQUEST, "timezone must be an IANA time zone name", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if body.Time != nil {
+		sendAt, err := time.Parse(digestTimeLayout, *body.Time)
+		if err != nil {
+			writeError(w, api.INVALIDREQUEST, "time must be HH:MM", http.StatusBadRequest)
+			return
+		}
+		normalized := sendAt.Format(digestTimeLayout)
+		body.Time = &normalized
+	}
+
+	if body.Email != nil && *body.Email != "" {
+		if _, err := mail.ParseAddress(*body.Email); err != nil {
+			writeError(w, api.INVALIDREQUEST, "email is not a valid address", http.StatusBadRequest)
+			return
+		}
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := loadDigestSettings(ctx, tx, body.UserId)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch digest settings")
+		return
+	}
+
+	// Fields missing in the request keep their values
+	settings := *before
+	if body.Enabled != nil {
+		settings.Enabled = *body.Enabled
+	}
+	if body.Timezone != nil {
+		settings.Timezone = *body.Timezone
+	}
+	if body.Time != nil {
+		settings.Time = *body.Time
+	}
+	if body.Email != nil {
+		settings.Email = optionalString(*body.Email)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO digest_settings(user_id, enabled, timezone, send_at, email)
+		VALUES($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET enabled=excluded.enabled, timezone=excluded.timezone, send_at=excluded.send_at, email=excluded.email
+	`, body.UserId, settings.Enabled, settings.Timezone, settings.Time, settings.Email)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update digest settings", http.StatusInternalServerError)
+		return
+	}
+
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      requestActor(r),
+		operation:  "users.setDigest",
+		targetType: targetUser,
+		targetId:   body.UserId,
+		before:     snapshot(before),
+		after:      snapshot(&settings),
+	})
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to write audit log", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update digest settings", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]*api.DigestSettings{"digest": &settings})
+}
+
+// digestRecipient is an active reviewer with open reviews who hasn't opted out of digests
+type digestRecipient struct {
+	digest   notify.Digest
+	timezone string
+	sendAt   string
+}
+
+// SendDigests sends every active reviewer with open reviews the list of them once a day, when the time
+// of their digest comes in their time zone. A digest which no notifier could deliver is tried again
+// on the next run, a digest delivered by some of them is not. Notifiers which skip the user don't count.
+func (h *Handler) SendDigests(ctx context.Context) error {
+	if len(h.opts.Notifiers) == 0 {
+		return nil
+	}
+
+	now := h.now()
+
+	recipients, err := h.digestRecipients(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find digest recipients: %w", err)
+	}
+
+	for _, rcpt := range recipients {
+		date, due, err := digestDue(now, rcpt.timezone, rcpt.sendAt)
+		if err != nil {
+			log.Printf("digest: skipping %s: %v", rcpt.digest.UserId, err)
+			continue
+		}
+		if !due {
+			continue
+		}
+
+		if err := h.sendDigest(ctx, &rcpt.digest, date, now); err != nil {
+			return fmt.Errorf("failed to send digest to %s: %w", rcpt.digest.UserId, err)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) digestRecipients(ctx context.Context) ([]*digestRecipient, error) {
+	rows, err := h.db.Query(ctx, `
+		SELECT users.user_id, users.username, users.team_name,
+			COALESCE(digest_settings.timezone, $1), COALESCE(digest_settings.send_at, $2),
+			COALESCE(digest_settings.email, '')
+		FROM users LEFT JOIN digest_settings ON digest_settings.user_id = users.user_id
+		WHERE users.is_active = TRUE AND COALESCE(digest_settings.enabled, TRUE) = TRUE
+			AND `+openReviewCount+` > 0
+		ORDER BY users.user_id
+	`, defaultDigestTimezone, defaultDigestTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipients := make([]*digestRecipient, 0)
+	for rows.Next() {
+		var rcpt digestRecipient
+		err := rows.Scan(&rcpt.digest.UserId, &rcpt.digest.Username, &rcpt.digest.TeamName,
+			&rcpt.timezone, &rcpt.sendAt, &rcpt.digest.Email)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, &rcpt)
+	}
+
+	return recipients, rows.Err()
+}
+
+// digestDue returns the day in the user's time zone and whether the time of its digest has come
+func digestDue(now time.Time, timezone, sendAt string) (string, bool, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", false, err
+	}
+	at, err := time.Parse(digestTimeLayout, sendAt)
+	if err != nil {
+		return "", false, err
+	}
+
+	local := now.In(loc)
+	due := local.Hour() > at.Hour() || local.Hour() == at.Hour() && local.Minute() >= at.Minute()
+
+	return local.Format(time.DateOnly), due, nil
+}
+
+// sendDigest sends the digest of the day unless it was sent already, by this or another replica
+func (h *Handler) sendDigest(ctx context.Context, digest *notify.Digest, date string, now time.Time) error {
+	tag, err := h.db.Exec(ctx, `
+		INSERT INTO digest_deliveries(user_id, digest_date, sent_at) VALUES($1, $2, $3)
+		ON CONFLICT (user_id, digest_date) DO NOTHING
+	`, digest.UserId, date, now)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+
+	rows, err := h.db.Query(ctx, `
+		SELECT prs.pull_request_id, prs.pull_request_name, prs.author_id, prs.created_at
+		FROM pr_reviewers JOIN prs ON prs.pull_request_id = pr_reviewers.pull_request_id
+		WHERE pr_reviewers.user_id=$1 AND prs.status = 'OPEN'
+		ORDER BY prs.created_at, prs.pull_request_id
+	`, digest.UserId)
+	if err != nil {
+		return err
+	}
+	digest.Reviews = make([]notify.Review, 0)
+	for rows.Next() {
+		var r notify.Review
+		if err := rows.Scan(&r.PullRequestId, &r.PullRequestName, &r.AuthorId, &r.CreatedAt); err != nil {
+			rows.Close()
+			return err
+		}
+		digest.Reviews = append(digest.Reviews, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	digest.Date = date
+
+	delivered := false
+	for _, n := range h.opts.Notifiers {
+		err := n.Send(ctx, *digest)
+		if errors.Is(err, notify.ErrSkipped) {
+			continue
+		}
+		if err != nil {
+			log.Printf("digest: %s failed to deliver to %s: %v", n.Name(), digest.UserId, err)
+			continue
+		}
+		delivered = true
+	}
+
+	if !delivered {
+		_, err := h.db.Exec(ctx, `DELETE FROM digest_deliveries WHERE user_id=$1 AND digest_date=$2`, digest.UserId, date)
+		return err
+	}
+
+	return nil
+}
+
+// optionalString returns nil for an empty string
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+)
+
+// Explanation collects how candidates were considered while reviewers were picked. Methods of a nil
+// *Explanation do nothing, so selection code doesn't check whether an explanation was asked for.
+type Explanation struct {
+	selection  Selection
+	candidates []api.CandidateExplanation
+	seen       map[string]struct{}
+	rank       int
+	// teams are the teams whose members were looked at, their unavailable members are listed too
+	teams []string
+}
+
+func (h *Handler) newExplanation() *Explanation {
+	return &Explanation{selection: h.opts.Selection, seen: make(map[string]struct{})}
+}
+
+// add records the user unless they are already recorded. The first pool a user was met in is the one shown.
+func (e *Explanation) add(c api.CandidateExplanation) {
+	if _, seen := e.seen[c.UserId]; seen {
+		return
+	}
+	e.seen[c.UserId] = struct{}{}
+	e.candidates = append(e.candidates, c)
+}
+
+// exclude records a user who can't be a reviewer of this PR at all, e.g. its author
+func (e *Explanation) exclude(userId string, reason api.CandidateExplanationReason) {
+	if e == nil {
+		return
+	}
+	e.add(api.CandidateExplanation{UserId: userId, Reason: &reason})
+}
+
+// considered records a candidate in the order they were looked at. Eligible candidates get the next rank.
+func (e *Explanation) considered(c candidate, score float64, picked, atCapacity bool) {
+	if e == nil {
+		return
+	}
+	if _, seen := e.seen[c.userId]; seen {
+		return
+	}
+
+	pool := api.CandidateExplanationPool(c.pool)
+	ce := api.CandidateExplanation{UserId: c.userId, TeamName: &c.teamName, Pool: &pool, Picked: picked}
+	if atCapacity {
+		reason := api.AtCapacity
+		ce.Reason = &reason
+	} else {
+		e.rank++
+		rank := e.rank
+		// Scores are rounded, nobody needs all the digits of 1/3
+		score = math.Round(score*1000) / 1000
+		ce.Eligible, ce.Rank, ce.Score = true, &rank, &score
+	}
+	e.add(ce)
+}
+
+// lookedAt remembers that members of the team were candidates
+func (e *Explanation) lookedAt(teamName string) {
+	if e == nil || slices.Contains(e.teams, teamName) {
+		return
+	}
+	e.teams = append(e.teams, teamName)
+}
+
+// addUnavailable records members of the teams looked at who are inactive or away at now. They never become
+// candidates, so they are found separately.
+func (e *Explanation) addUnavailable(ctx context.Context, q querier, now time.Time) error {
+	if e == nil || len(e.teams) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(e.teams)+1)
+	for _, team := range e.teams {
+		args = append(args, team)
+	}
+	args = append(args, now)
+
+	rows, err := q.Query(ctx, `
+		SELECT users.user_id, users.team_name, users.is_active
+		FROM users
+		WHERE users.team_name IN (`+placeholders(1, len(e.teams))+`)
+			AND NOT (users.is_active = TRUE AND `+notAway(len(e.teams)+1)+`)
+		ORDER BY users.user_id
+	`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			c        api.CandidateExplanation
+			teamName string
+			isActive bool
+		)
+		if err := rows.Scan(&c.UserId, &teamName, &isActive); err != nil {
+			return err
+		}
+		reason := api.Away
+		if !isActive {
+			reason = api.Inactive
+		}
+		c.TeamName, c.Reason = &teamName, &reason
+		e.add(c)
+	}
+
+	return rows.Err()
+}
+
+// toAPI lists eligible candidates by rank first, then everyone else in the order they were met
+func (e *Explanation) toAPI() api.AssignmentExplanation {
+	candidates := slices.Clone(e.candidates)
+	slices.SortStableFunc(candidates, func(a, b api.CandidateExplanation) int {
+		if a.Eligible != b.Eligible {
+			if a.Eligible {
+				return -1
+			}
+			return 1
+		}
+		if a.Eligible {
+			return cmp.Compare(*a.Rank, *b.Rank)
+		}
+		return 0
+	})
+
+	if candidates == nil {
+		candidates = make([]api.CandidateExplanation, 0)
+	}
+
+	return api.AssignmentExplanation{Selection: api.AssignmentExplanationSelection(e.selection), Candidates: candidates}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"time"
+)
+
+// Selection is how reviewers are chosen among candidates of the same pool
+type Selection string
+
+const (
+	// SelectionRandom picks any candidate with the same chance
+	SelectionRandom Selection = "random"
+	// SelectionFair is random too, but candidates who often and recently reviewed the author are less likely
+	// to be picked, so that the author gets to know more teammates
+	SelectionFair Selection = "fair"
+)
+
+const defaultFairnessWindow = 30 * 24 * time.Hour
+
+// pairingWeight is how much a review of the author done age ago counts. It fades linearly and is gone
+// by the end of the window.
+func pairingWeight(age, window time.Duration) float64 {
+	if age >= window {
+		return 0
+	}
+	if age < 0 {
+		age = 0
+	}
+	return 1 - float64(age)/float64(window)
+}
+
+// pairings sums weights of reviews of the author's PRs within the fairness window for every reviewer
+func (h *Handler) pairings(ctx context.Context, q querier, authorId string) (map[string]float64, error) {
+	now := h.now()
+
+	rows, err := q.Query(ctx, `
+		SELECT pr_reviewers.user_id, prs.created_at
+		FROM prs JOIN pr_reviewers ON pr_reviewers.pull_request_id = prs.pull_request_id
+		WHERE prs.author_id = $1 AND prs.created_at > $2
+	`, authorId, now.Add(-h.opts.FairnessWindow))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	weights := make(map[string]float64)
+	for rows.Next() {
+		var (
+			userId    string
+			createdAt time.Time
+		)
+		if err := rows.Scan(&userId, &createdAt); err != nil {
+			return nil, err
+		}
+		weights[userId] += pairingWeight(now.Sub(createdAt), h.opts.FairnessWindow)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// PRs of a dry run are created right now
+	if h.sim != nil {
+		for userId, n := range h.sim.assigned {
+			weights[userId] += float64(n) * pairingWeight(0, h.opts.FairnessWindow)
+		}
+	}
+
+	return weights, nil
+}
+
+// score is the chance of the candidate against a candidate the author has never been reviewed by.
+// Without pairings, that is under random selection, everyone has the same chance.
+func score(pairings map[string]float64, userId string) float64 {
+	return 1 / (1 + pairings[userId])
+}
+
+// arrange puts candidates in the order of fair selection. Every next candidate is drawn with the chance
+// proportional to 1/(1+w), where w is the pairing weight with the author. This is weighted sampling without
+// replacement by Efraimidis and Spirakis: a candidate gets the key u^(1+w) with random u, highest keys go first.
+func (h *Handler) arrange(candidates []candidate, pairings map[string]float64) {
+	keys := make(map[string]float64, len(candidates))
+	for _, c := range candidates {
+		// Logarithm keeps the order of keys and doesn't lose precision for heavy weights, u=0 is avoided
+		u := 1 - h.rand.Float64()
+		keys[c.userId] = math.Log(u) * (1 + pairings[c.userId])
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(keys[b.userId], keys[a.userId])
+	})
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPairingWeight(t *testing.T) {
+	const window = 30 * 24 * time.Hour
+
+	for _, tc := range []struct {
+		age    time.Duration
+		weight float64
+	}{
+		{0, 1},
+		{-time.Hour, 1},
+		{window / 4, 0.75},
+		{window / 2, 0.5},
+		{window - window/10, 0.1},
+		{window, 0},
+		{2 * window, 0},
+	} {
+		if w := pairingWeight(tc.age, window); math.Abs(w-tc.weight) > 1e-9 {
+			t.Errorf("age %v: expected weight %v, got %v", tc.age, tc.weight, w)
+		}
+	}
+}
+
+// TestArrange draws the first of three candidates many times. With the weight of 2 Bob has the chance of
+// 1/3 against each of the others, so he goes first in 1/7 of draws and each of the others in 3/7.
+func TestArrange(t *testing.T) {
+	const draws = 7000
+
+	arranged := func(pairings map[string]float64) map[string]int {
+		h := &Handler{rand: NewRandom(1)}
+		first := make(map[string]int)
+		for range draws {
+			candidates := []candidate{{userId: "u2"}, {userId: "u3"}, {userId: "u4"}}
+			h.arrange(candidates, pairings)
+			first[candidates[0].userId]++
+		}
+		return first
+	}
+
+	near := func(n int, share float64) bool {
+		return math.Abs(float64(n)/draws-share) < 0.03
+	}
+
+	first := arranged(map[string]float64{"u2": 2})
+	if !near(first["u2"], 1.0/7) || !near(first["u3"], 3.0/7) || !near(first["u4"], 3.0/7) {
+		t.Errorf("expected Bob to go first in 1/7 of draws, the others in 3/7, got %v of %d", first, draws)
+	}
+
+	// Reviews older than the window weigh nothing, so they don't change the chances
+	first = arranged(map[string]float64{"u2": pairingWeight(31*24*time.Hour, defaultFairnessWindow)})
+	for userId, n := range first {
+		if !near(n, 1.0/3) {
+			t.Errorf("expected everyone to go first in 1/3 of draws, %s did %d of %d times", userId, n, draws)
+		}
+	}
+}
+
+func TestArrangeIsReproducible(t *testing.T) {
+	order := func() []string {
+		h := &Handler{rand: NewRandom(42)}
+		candidates := []candidate{{userId: "u1"}, {userId: "u2"}, {userId: "u3"}, {userId: "u4"}, {userId: "u5"}}
+		h.arrange(candidates, map[string]float64{"u1": 0.5, "u3": 4})
+
+		ids := make([]string, 0, len(candidates))
+		for _, c := range candidates {
+			ids = append(ids, c.userId)
+		}
+		return ids
+	}
+
+	first, second := order(), order()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("same seed gave %v and %v", first, second)
+		}
+	}
+}
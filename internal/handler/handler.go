@@ -1,31 +1,68 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math/rand"
 	"net/http"
 	"time"
 
-	"github.com/jackc/pgx/v5"
-
 	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
 	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/events"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/notify"
 )
 
+type Options struct {
+	// Selection is how reviewers are chosen among candidates of the same pool, SelectionRandom if empty
+	Selection Selection
+	// FairnessWindow is how far back SelectionFair looks at reviews of the author
+	FairnessWindow time.Duration
+	// Random drives reviewer selection, seeded from the clock if nil
+	Random Random
+	// Notifiers deliver daily digests of open reviews, SendDigests does nothing without them
+	Notifiers []notify.Notifier
+	// Clock is the time of changes to PRs and of background jobs, time.Now if nil
+	Clock func() time.Time
+}
+
 type Handler struct {
-	db   *db.DB
-	rand *rand.Rand
+	db     *db.DB
+	events *events.Broker
+	rand   Random
+	opts   Options
+	// sim is set on copies of the handler which run a dry run, see Simulate
+	sim *simulation
 }
 
-func NewHandler(db *db.DB) *Handler {
+func NewHandler(db *db.DB, broker *events.Broker, opts Options) *Handler {
+	if opts.Selection == "" {
+		opts.Selection = SelectionRandom
+	}
+	if opts.FairnessWindow == 0 {
+		opts.FairnessWindow = defaultFairnessWindow
+	}
+	if opts.Random == nil {
+		opts.Random = NewRandom(time.Now().UnixNano())
+	}
+	if opts.Clock == nil {
+		opts.Clock = time.Now
+	}
+
 	return &Handler{
-		db:   db,
-		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+		db:     db,
+		events: broker,
+		rand:   opts.Random,
+		opts:   opts,
 	}
 }
 
+// now is the current time of the handler's clock
+func (h *Handler) now() time.Time {
+	return h.opts.Clock().UTC()
+}
+
 func writeJSON(w http.ResponseWriter, status int, data any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -41,8 +78,38 @@ func writeError(w http.ResponseWriter, code api.ErrorResponseErrorCode, msg stri
 	writeJSON(w, status, resp)
 }
 
-func (h *Handler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// apiError is an error which is returned to the client as is
+type apiError struct {
+	code   api.ErrorResponseErrorCode
+	msg    string
+	status int
+}
+
+func (e *apiError) Error() string {
+	return e.msg
+}
+
+// writeAPIError writes apiError as is and hides any other error behind INTERNAL_ERROR
+func writeAPIError(w http.ResponseWriter, err error, internalMsg string) {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		writeError(w, apiErr.code, apiErr.msg, apiErr.status)
+		return
+	}
+	writeError(w, api.INTERNALERROR, internalMsg, http.StatusInternalServerError)
+}
+
+// ClientError returns the code and the message of an error which is meant for the client.
+// ok is false for any other error, it should be reported as INTERNAL_ERROR without details.
+func ClientError(err error) (code api.ErrorResponseErrorCode, msg string, ok bool) {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.code, apiErr.msg, true
+	}
+	return api.INTERNALERROR, "", false
+}
+
+func (h *Handler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request, params api.PostPullRequestCreateParams) {
 	var body api.PostPullRequestCreateJSONBody
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -50,119 +117,169 @@ func (h *Handler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if body.PullRequestId == "" {
-		writeError(w, api.INVALIDREQUEST, "pull_request_id is required", http.StatusBadRequest)
-		return
+	var explain *Explanation
+	if params.Explain != nil && *params.Explain {
+		explain = h.newExplanation()
 	}
 
-	if body.PullRequestName == "" {
-		writeError(w, api.INVALIDREQUEST, "pull_request_name is required", http.StatusBadRequest)
+	pr, capacityLimited, err := h.CreatePullRequest(r.Context(), body, requestActor(r), explain)
+	if err != nil {
+		writeAPIError(w, err, "failed to create PR")
 		return
 	}
 
-	if body.AuthorId == "" {
-		writeError(w, api.INVALIDREQUEST, "author_id is required", http.StatusBadRequest)
-		return
+	resp := map[string]interface{}{
+		"pr":               pr.PullRequest,
+		"capacity_limited": capacityLimited,
+	}
+	if explain != nil {
+		resp["explanation"] = explain.toAPI()
 	}
 
-	var exists bool
-	err := h.db.Pool.QueryRow(ctx,
-		"SELECT EXISTS(SELECT 1 FROM prs WHERE pull_request_id=$1)",
-		body.PullRequestId,
-	).Scan(&exists)
+	setETag(w, pr.Version)
+	writeJSON(w, http.StatusCreated, resp)
+}
 
-	if err != nil {
-		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
-		return
+// CreatePullRequest creates a PR and assigns reviewers to it. The second result tells whether fewer reviewers
+// were assigned than could be because some candidates are at capacity. If actor is nil, the author is the actor.
+// If explain is not nil, it tells how the reviewers were picked.
+func (h *Handler) CreatePullRequest(ctx context.Context, req api.PostPullRequestCreateJSONBody, actor *string, explain *Explanation) (*VersionedPullRequest, bool, error) {
+	if req.PullRequestId == "" {
+		return nil, false, &apiError{api.INVALIDREQUEST, "pull_request_id is required", http.StatusBadRequest}
 	}
 
-	if exists {
-		writeError(w, api.PREXISTS, "PR id already exists", http.StatusConflict)
-		return
+	if req.PullRequestName == "" {
+		return nil, false, &apiError{api.INVALIDREQUEST, "pull_request_name is required", http.StatusBadRequest}
+	}
+
+	if req.AuthorId == "" {
+		return nil, false, &apiError{api.INVALIDREQUEST, "author_id is required", http.StatusBadRequest}
+	}
+
+	changedFiles := make([]string, 0)
+	if req.ChangedFiles != nil {
+		changedFiles = *req.ChangedFiles
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return nil, false, err
 	}
+	defer tx.Rollback(ctx)
 
-	err = h.db.Pool.QueryRow(ctx,
-		"SELECT EXISTS(SELECT 1 FROM users WHERE user_id=$1)",
-		body.AuthorId,
+	var exists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM prs WHERE pull_request_id=$1)",
+		req.PullRequestId,
 	).Scan(&exists)
 
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
-		return
+		return nil, false, err
 	}
 
-	if !exists {
-		writeError(w, api.NOTFOUND, "author not found", http.StatusNotFound)
-		return
+	if exists {
+		return nil, false, &apiError{api.PREXISTS, "PR id already exists", http.StatusConflict}
 	}
 
 	var teamName string
-	err = h.db.Pool.QueryRow(ctx, "SELECT team_name FROM users WHERE user_id=$1", body.AuthorId).Scan(&teamName)
+	err = tx.QueryRow(ctx, "SELECT team_name FROM users WHERE user_id=$1", req.AuthorId).Scan(&teamName)
+
+	if errors.Is(err, db.ErrNoRows) {
+		return nil, false, &apiError{api.NOTFOUND, "author not found", http.StatusNotFound}
+	}
 
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to get author's team", http.StatusInternalServerError)
-		return
+		return nil, false, fmt.Errorf("failed to get author's team: %w", err)
 	}
 
-	// Get rows where all conditions are met
-	rows, err := h.db.Pool.Query(ctx, `SELECT user_id FROM users WHERE team_name=$1 AND is_active=TRUE AND user_id<>$2`, teamName, body.AuthorId)
+	teams, err := reviewerTeams(ctx, tx, teamName)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get fallback teams: %w", err)
+	}
+	if err := h.lockCandidates(ctx, tx, teamName, changedFiles, teams); err != nil {
+		return nil, false, fmt.Errorf("failed to lock reviewer candidates: %w", err)
+	}
 
+	reviewers, saturated, err := h.selectReviewers(ctx, tx, req.AuthorId, teamName, changedFiles, explain)
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to get team members", http.StatusInternalServerError)
-		return
+		return nil, false, err
 	}
 
-	defer rows.Close()
+	// Partial assignment is fine, but if there are candidates and every one of them is saturated, we'd rather say so
+	if len(reviewers) == 0 && saturated > 0 {
+		return nil, false, &apiError{api.ATCAPACITY, "all reviewer candidates are at capacity", http.StatusConflict}
+	}
 
-	reviewerCandidates := make([]string, 0, 2)
+	assignedReviewers := make([]string, 0, len(reviewers))
+	for _, c := range reviewers {
+		assignedReviewers = append(assignedReviewers, c.userId)
+	}
 
-	for rows.Next() {
-		var userId string
-		if err := rows.Scan(&userId); err != nil {
-			writeError(w, api.INTERNALERROR, "failed to scan reviewer", http.StatusInternalServerError)
-			return
-		}
-		reviewerCandidates = append(reviewerCandidates, userId)
+	// The author is the one who opens a PR unless the client says otherwise
+	if actor == nil {
+		actor = &req.AuthorId
 	}
 
-	// We shuffle candidates
-	if len(reviewerCandidates) > 1 {
-		h.rand.Shuffle(len(reviewerCandidates), func(i, j int) {
-			reviewerCandidates[i], reviewerCandidates[j] = reviewerCandidates[j], reviewerCandidates[i]
-		})
+	createdAt := h.now()
+	pr := &pullRequest{
+		id:           req.PullRequestId,
+		name:         req.PullRequestName,
+		authorId:     req.AuthorId,
+		status:       string(api.PullRequestStatusOPEN),
+		reviewers:    assignedReviewers,
+		fallback:     fallbackReviewers(reviewers, teamName),
+		changedFiles: changedFiles,
+		createdAt:    &createdAt,
 	}
 
-	assignedReviewers := reviewerCandidates
-	if len(assignedReviewers) > 2 {
-		assignedReviewers = assignedReviewers[:2]
+	history := []prEvent{{eventType: api.PullRequestEventTypeCREATED, at: createdAt, actor: actor}}
+	for _, uid := range assignedReviewers {
+		history = append(history, reviewerEvent(api.PullRequestEventTypeREVIEWERASSIGNED, createdAt, actor, uid))
 	}
 
-	createdAt := time.Now().UTC()
-	_, err = h.db.Pool.Exec(ctx, `
-		INSERT INTO prs(pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at)
+	// A concurrent request may have created the same PR since the check, it is as much of a conflict
+	err = tx.QueryRow(ctx, `
+		INSERT INTO prs(pull_request_id, pull_request_name, author_id, status, changed_files, created_at)
 		VALUES($1, $2, $3, $4, $5, $6)
-	`, body.PullRequestId, body.PullRequestName, body.AuthorId, "OPEN", assignedReviewers, createdAt)
+		ON CONFLICT (pull_request_id) DO NOTHING
+		RETURNING version
+	`, pr.id, pr.name, pr.authorId, pr.status, db.Strings(pr.changedFiles), createdAt).Scan(&pr.version)
+
+	if errors.Is(err, db.ErrNoRows) {
+		return nil, false, &apiError{api.PREXISTS, "PR id already exists", http.StatusConflict}
+	}
 
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to create PR", http.StatusInternalServerError)
-		return
+		return nil, false, fmt.Errorf("failed to insert PR: %w", err)
+	}
+
+	if err := insertReviewers(ctx, tx, pr); err != nil {
+		return nil, false, fmt.Errorf("failed to insert reviewers: %w", err)
+	}
+
+	if err := recordEvents(ctx, tx, pr, history...); err != nil {
+		return nil, false, fmt.Errorf("failed to record PR history: %w", err)
+	}
+
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      actor,
+		operation:  "pullRequest.create",
+		targetType: targetPullRequest,
+		targetId:   pr.id,
+		after:      snapshot(pr.toAPI()),
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to write audit log: %w", err)
 	}
 
-	pr := api.PullRequest{
-		PullRequestId:     body.PullRequestId,
-		PullRequestName:   body.PullRequestName,
-		AuthorId:          body.AuthorId,
-		Status:            api.PullRequestStatusOPEN,
-		AssignedReviewers: assignedReviewers,
-		CreatedAt:         &createdAt,
-		MergedAt:          nil,
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, err
 	}
 
-	writeJSON(w, http.StatusCreated, map[string]api.PullRequest{"pr": pr})
+	return pr.versioned(), len(reviewers) < reviewersPerPR && saturated > 0, nil
 }
 
-func (h *Handler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+func (h *Handler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request, params api.PostPullRequestMergeParams) {
 	var body api.PostPullRequestMergeJSONBody
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -170,63 +287,84 @@ func (h *Handler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if body.PullRequestId == "" {
-		writeError(w, api.INVALIDREQUEST, "pull_request_id is required", http.StatusBadRequest)
+	pr, err := h.MergePullRequest(r.Context(), body.PullRequestId, params.IfMatch, requestActor(r))
+	if err != nil {
+		writeAPIError(w, err, "failed to merge PR")
 		return
 	}
 
-	var (
-		status            string
-		mergedAt          *time.Time
-		authorId, prName  string
-		assignedReviewers []string
-		createdAt         *time.Time
-	)
+	setETag(w, pr.Version)
+	writeJSON(w, http.StatusOK, map[string]api.PullRequest{"pr": pr.PullRequest})
+}
 
-	err := h.db.Pool.QueryRow(ctx, `SELECT status, merged_at, author_id, pull_request_name, assigned_reviewers, created_at FROM prs WHERE pull_request_id=$1`, body.PullRequestId).Scan(&status, &mergedAt, &authorId, &prName, &assignedReviewers, &createdAt)
+// MergePullRequest marks the PR as merged. Merging a merged PR changes nothing.
+// If ifMatch is set, the PR must still have one of its entity tags.
+func (h *Handler) MergePullRequest(ctx context.Context, prId string, ifMatch, actor *string) (*VersionedPullRequest, error) {
+	if prId == "" {
+		return nil, &apiError{api.INVALIDREQUEST, "pull_request_id is required", http.StatusBadRequest}
+	}
 
+	tx, err := h.db.Begin(ctx)
 	if err != nil {
-		writeError(w, api.NOTFOUND, "PR not found", http.StatusNotFound)
-		return
+		return nil, err
 	}
+	defer tx.Rollback(ctx)
 
-	if status == string(api.PullRequestStatusMERGED) {
-		pr := api.PullRequest{
-			PullRequestId:     body.PullRequestId,
-			PullRequestName:   prName,
-			AuthorId:          authorId,
-			Status:            api.PullRequestStatusMERGED,
-			AssignedReviewers: assignedReviewers,
-			CreatedAt:         createdAt,
-			MergedAt:          mergedAt,
-		}
-		writeJSON(w, http.StatusOK, map[string]api.PullRequest{"pr": pr})
-		return
+	pr, err := h.lockPullRequest(ctx, tx, prId)
+	if err != nil {
+		return nil, err
 	}
 
-	now := time.Now().UTC()
-	_, err = h.db.Pool.Exec(ctx, `UPDATE prs SET status=$1, merged_at=$2 WHERE pull_request_id=$3`, api.PullRequestStatusMERGED, now, body.PullRequestId)
+	// Merge is idempotent, merging a merged PR changes nothing, so there is no version to conflict with
+	if pr.status == string(api.PullRequestStatusMERGED) {
+		return pr.versioned(), nil
+	}
+
+	if err := pr.checkIfMatch(ifMatch); err != nil {
+		return nil, err
+	}
+
+	before := snapshot(pr.toAPI())
+
+	now := h.now()
+	err = tx.QueryRow(ctx, `
+		UPDATE prs SET status=$1, merged_at=$2, version=version+1
+		WHERE pull_request_id=$3
+		RETURNING version
+	`, api.PullRequestStatusMERGED, now, prId).Scan(&pr.version)
 
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to merge PR", http.StatusInternalServerError)
-		return
+		return nil, fmt.Errorf("failed to update PR: %w", err)
 	}
 
-	pr := api.PullRequest{
-		PullRequestId:     body.PullRequestId,
-		PullRequestName:   prName,
-		AuthorId:          authorId,
-		Status:            api.PullRequestStatusMERGED,
-		AssignedReviewers: assignedReviewers,
-		CreatedAt:         createdAt,
-		MergedAt:          &now,
+	pr.status = string(api.PullRequestStatusMERGED)
+	pr.mergedAt = &now
+
+	merged := prEvent{eventType: api.PullRequestEventTypeMERGED, at: now, actor: actor}
+	if err := recordEvents(ctx, tx, pr, merged); err != nil {
+		return nil, fmt.Errorf("failed to record PR history: %w", err)
 	}
 
-	writeJSON(w, http.StatusOK, map[string]api.PullRequest{"pr": pr})
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      actor,
+		operation:  "pullRequest.merge",
+		targetType: targetPullRequest,
+		targetId:   prId,
+		before:     before,
+		after:      snapshot(pr.toAPI()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return pr.versioned(), nil
 }
 
-func (h *Handler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+func (h *Handler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request, params api.PostPullRequestReassignParams) {
 	var body api.PostPullRequestReassignJSONBody
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -234,115 +372,191 @@ func (h *Handler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if body.PullRequestId == "" {
-		writeError(w, api.INVALIDREQUEST, "pull_request_id is required", http.StatusBadRequest)
-		return
+	// A reviewer chosen by hand isn't picked from candidates, there is nothing to explain
+	var explain *Explanation
+	if params.Explain != nil && *params.Explain && body.NewUserId == nil {
+		explain = h.newExplanation()
 	}
 
-	if body.OldUserId == "" {
-		writeError(w, api.INVALIDREQUEST, "old_user_id is required", http.StatusBadRequest)
+	pr, replacedBy, err := h.ReassignReviewer(r.Context(), body, params.IfMatch, requestActor(r), explain)
+	if err != nil {
+		writeAPIError(w, err, "failed to reassign reviewer")
 		return
 	}
 
-	var (
-		status            string
-		assignedReviewers []string
-		mergedAt          *time.Time
-		createdAt         *time.Time
-		prName, authorId  string
-	)
-	err := h.db.Pool.QueryRow(ctx, `SELECT status, assigned_reviewers, merged_at, created_at, pull_request_name, author_id FROM prs WHERE pull_request_id=$1`, body.PullRequestId).Scan(&status, &assignedReviewers, &mergedAt, &createdAt, &prName, &authorId)
+	resp := map[string]interface{}{
+		"pr":          pr.PullRequest,
+		"replaced_by": replacedBy,
+	}
+	if explain != nil {
+		resp["explanation"] = explain.toAPI()
+	}
+
+	setETag(w, pr.Version)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ReassignReviewer validates the request and replaces the reviewer, see reassignReviewer
+func (h *Handler) ReassignReviewer(ctx context.Context, req api.PostPullRequestReassignJSONBody, ifMatch, actor *string, explain *Explanation) (*VersionedPullRequest, string, error) {
+	if req.PullRequestId == "" {
+		return nil, "", &apiError{api.INVALIDREQUEST, "pull_request_id is required", http.StatusBadRequest}
+	}
+
+	if req.OldUserId == "" {
+		return nil, "", &apiError{api.INVALIDREQUEST, "old_user_id is required", http.StatusBadRequest}
+	}
+
+	newUserId := ""
+	if req.NewUserId != nil {
+		newUserId = *req.NewUserId
+	}
 
+	pr, replacedBy, err := h.reassignReviewer(ctx, req.PullRequestId, req.OldUserId, newUserId, ifMatch, actor, explain)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			writeError(w, api.NOTFOUND, "PR not found", http.StatusNotFound)
-			return
-		}
-		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
-		return
+		return nil, "", err
 	}
 
-	if status != string(api.PullRequestStatusOPEN) {
-		writeError(w, api.PRMERGED, "cannot reassign on merged PR", http.StatusBadRequest)
-		return
+	return pr.versioned(), replacedBy, nil
+}
+
+// reassignReviewer replaces the reviewer of an open PR and returns the updated PR together with the new reviewer.
+// If newUserId is empty, the replacement is picked the same way as on PR creation. If ifMatch is set,
+// the PR must still have that version. It is shared by the reassign endpoint and background jobs. If explain
+// is not nil, it tells how the replacement was picked.
+func (h *Handler) reassignReviewer(ctx context.Context, prId, oldUserId, newUserId string, ifMatch, actor *string, explain *Explanation) (*pullRequest, string, error) {
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return nil, "", err
 	}
+	defer tx.Rollback(ctx)
 
-	oldIdx := -1
-	for i, uid := range assignedReviewers {
-		if uid == body.OldUserId {
-			oldIdx = i
-			break
-		}
+	pr, newReviewer, err := h.reassignReviewerTx(ctx, tx, prId, oldUserId, newUserId, ifMatch, actor, explain)
+	if err != nil {
+		return nil, "", err
 	}
 
-	if oldIdx == -1 {
-		writeError(w, api.NOTASSIGNED, "reviewer is not assigned to this PR", http.StatusBadRequest)
-		return
+	if err := tx.Commit(ctx); err != nil {
+		return nil, "", err
+	}
+
+	return pr, newReviewer, nil
+}
+
+// reassignReviewerTx is reassignReviewer in the caller's transaction, for jobs which record more in it.
+// Errors are returned before anything is written, so the transaction stays usable after an *apiError.
+func (h *Handler) reassignReviewerTx(ctx context.Context, tx db.Tx, prId, oldUserId, newUserId string, ifMatch, actor *string, explain *Explanation) (*pullRequest, string, error) {
+	pr, err := h.lockPullRequest(ctx, tx, prId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := pr.checkIfMatch(ifMatch); err != nil {
+		return nil, "", err
+	}
+
+	if pr.status != string(api.PullRequestStatusOPEN) {
+		return nil, "", &apiError{api.PRMERGED, "cannot reassign on merged PR", http.StatusConflict}
+	}
+
+	before := snapshot(pr.toAPI())
+
+	if pr.reviewerIndex(oldUserId) == -1 {
+		return nil, "", &apiError{api.NOTASSIGNED, "reviewer is not assigned to this PR", http.StatusConflict}
 	}
 
 	var teamName string
-	err = h.db.Pool.QueryRow(ctx, "SELECT team_name FROM users WHERE user_id=$1", body.OldUserId).Scan(&teamName)
+	err = tx.QueryRow(ctx, "SELECT team_name FROM users WHERE user_id=$1", oldUserId).Scan(&teamName)
 	if err != nil {
-		writeError(w, api.NOTFOUND, "old_user_id not found", http.StatusNotFound)
-		return
+		return nil, "", &apiError{api.NOTFOUND, "old_user_id not found", http.StatusNotFound}
 	}
 
-	rows, err := h.db.Pool.Query(ctx, `SELECT user_id FROM users WHERE team_name=$1 AND is_active=TRUE AND user_id<>$2`, teamName, body.OldUserId)
+	var authorTeam string
+	err = tx.QueryRow(ctx, "SELECT team_name FROM users WHERE user_id=$1", pr.authorId).Scan(&authorTeam)
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to get team members", http.StatusInternalServerError)
-		return
+		return nil, "", fmt.Errorf("failed to get author's team: %w", err)
 	}
-	defer rows.Close()
 
-	assignedSet := make(map[string]struct{}, len(assignedReviewers))
-	// we use set because search in lists is O(n) whilst search in set is O(1)
-	for _, uid := range assignedReviewers {
-		assignedSet[uid] = struct{}{} // because empty structs don't weigh anything, they can be values
+	// Picked or chosen by hand, the replacement comes from the same teams
+	teams, err := reassignTeams(ctx, tx, teamName, authorTeam)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get fallback teams: %w", err)
 	}
-	assignedSet[authorId] = struct{}{} // exclude PR author as well
 
-	candidates := make([]string, 0)
-	for rows.Next() {
-		var uid string
-		if err := rows.Scan(&uid); err != nil {
-			writeError(w, api.INTERNALERROR, "failed to scan candidate", http.StatusInternalServerError)
-			return
+	var replacement candidate
+	if newUserId != "" {
+		replacement, err = checkReviewer(ctx, tx, h.now(), pr, newUserId, authorTeam, teams)
+		if err != nil {
+			return nil, "", err
 		}
-		if _, already := assignedSet[uid]; !already {
-			candidates = append(candidates, uid)
+	} else {
+		assignedSet := make(map[string]struct{}, len(pr.reviewers))
+		// we use set because search in lists is O(n) whilst search in set is O(1)
+		for _, uid := range pr.reviewers {
+			assignedSet[uid] = struct{}{} // because empty structs don't weigh anything, they can be values
+		}
+		assignedSet[pr.authorId] = struct{}{} // exclude PR author as well
+		explain.exclude(pr.authorId, api.Author)
+		for _, uid := range pr.reviewers {
+			explain.exclude(uid, api.AlreadyAssigned)
 		}
-	}
 
-	if len(candidates) == 0 {
-		writeError(w, api.NOCANDIDATE, "no active replacement candidate in team", http.StatusConflict)
-		return
+		if err := h.lockCandidates(ctx, tx, authorTeam, pr.changedFiles, teams); err != nil {
+			return nil, "", fmt.Errorf("failed to lock reviewer candidates: %w", err)
+		}
+
+		owners, err := h.codeOwners(ctx, tx, authorTeam, pr.changedFiles, assignedSet)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get code owners: %w", err)
+		}
+
+		candidates, saturated, err := h.pickReviewers(ctx, tx, pr.authorId, teams, 1, assignedSet, owners, explain)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get team members: %w", err)
+		}
+
+		if err := explain.addUnavailable(ctx, tx, h.now()); err != nil {
+			return nil, "", fmt.Errorf("failed to explain assignment: %w", err)
+		}
+
+		if len(candidates) == 0 && saturated > 0 {
+			return nil, "", &apiError{api.ATCAPACITY, "all replacement candidates are at capacity", http.StatusConflict}
+		}
+
+		if len(candidates) == 0 {
+			return nil, "", &apiError{api.NOCANDIDATE, "no active replacement candidate in team", http.StatusConflict}
+		}
+
+		replacement = candidates[0]
 	}
 
-	newIdx := h.rand.Intn(len(candidates))
-	newReviewer := candidates[newIdx]
-	assignedReviewers[oldIdx] = newReviewer
+	pr.replaceReviewer(oldUserId, replacement, authorTeam)
 
-	_, err = h.db.Pool.Exec(ctx, `UPDATE prs SET assigned_reviewers=$1 WHERE pull_request_id=$2`, assignedReviewers, body.PullRequestId)
-	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to update reviewers", http.StatusInternalServerError)
-		return
+	if err := pr.saveReviewers(ctx, tx); err != nil {
+		return nil, "", fmt.Errorf("failed to update reviewers: %w", err)
 	}
 
-	pr := api.PullRequest{
-		PullRequestId:     body.PullRequestId,
-		PullRequestName:   prName,
-		AuthorId:          authorId,
-		Status:            api.PullRequestStatusOPEN,
-		AssignedReviewers: assignedReviewers,
-		CreatedAt:         createdAt,
-		MergedAt:          mergedAt,
+	replaced := reviewerEvent(api.PullRequestEventTypeREVIEWERREPLACED, h.now(), actor, oldUserId)
+	replaced.replacedBy = &replacement.userId
+	if err := recordEvents(ctx, tx, pr, replaced); err != nil {
+		return nil, "", fmt.Errorf("failed to record PR history: %w", err)
 	}
 
-	writeJSON(w, http.StatusOK, map[string]api.PullRequest{"pr": pr})
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      actor,
+		operation:  "pullRequest.reassign",
+		targetType: targetPullRequest,
+		targetId:   pr.id,
+		before:     before,
+		after:      snapshot(pr.toAPI()),
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return pr, replacement.userId, nil
 }
 
 func (h *Handler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
 	var team api.Team
 
 	if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
@@ -350,48 +564,79 @@ func (h *Handler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if team.TeamName == "" {
-		writeError(w, api.INVALIDREQUEST, "team_name is required", http.StatusBadRequest)
+	created, err := h.AddTeam(r.Context(), team, requestActor(r))
+	if err != nil {
+		writeAPIError(w, err, "failed to create team")
 		return
 	}
 
+	writeJSON(w, http.StatusCreated, map[string]*api.Team{"team": created})
+}
+
+// AddTeam creates the team with its members. Existing users are moved to the team and updated.
+func (h *Handler) AddTeam(ctx context.Context, team api.Team, actor *string) (*api.Team, error) {
+	if team.TeamName == "" {
+		return nil, &apiError{api.INVALIDREQUEST, "team_name is required", http.StatusBadRequest}
+	}
+
 	if len(team.Members) == 0 {
-		writeError(w, api.INVALIDREQUEST, "members cannot be empty", http.StatusBadRequest)
-		return
+		return nil, &apiError{api.INVALIDREQUEST, "members cannot be empty", http.StatusBadRequest}
 	}
 
 	for i, m := range team.Members {
 		if m.UserId == "" || m.Username == "" {
-			writeError(w, api.INVALIDREQUEST, fmt.Sprintf("member at index %d is invalid", i), http.StatusBadRequest)
-			return
+			return nil, &apiError{api.INVALIDREQUEST, fmt.Sprintf("member at index %d is invalid", i), http.StatusBadRequest}
 		}
 	}
 
 	var exists bool
-	err := h.db.Pool.QueryRow(ctx,
+	err := h.db.QueryRow(ctx,
 		"SELECT EXISTS(SELECT 1 FROM teams WHERE team_name=$1)",
 		team.TeamName,
 	).Scan(&exists)
 
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
-		return
+		return nil, err
 	}
 
 	if exists {
-		writeError(w, api.TEAMEXISTS, "team_name already exists", http.StatusBadRequest)
-		return
+		return nil, &apiError{api.TEAMEXISTS, "team_name already exists", http.StatusBadRequest}
 	}
 
-	_, err = h.db.Pool.Exec(ctx, "INSERT INTO teams(team_name) VALUES($1)", team.TeamName)
+	var fallbacks []string
+	if team.FallbackTeams != nil {
+		fallbacks = *team.FallbackTeams
+	}
+
+	if err := validateFallbackTeams(ctx, h.db, team.TeamName, fallbacks); err != nil {
+		return nil, err
+	}
+
+	if team.DefaultMaxOpenReviews != nil && *team.DefaultMaxOpenReviews < 0 {
+		return nil, &apiError{api.INVALIDREQUEST, "default_max_open_reviews cannot be negative", http.StatusBadRequest}
+	}
 
+	tx, err := h.db.Begin(ctx)
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to create team", http.StatusInternalServerError)
-		return
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, "INSERT INTO teams(team_name, default_max_open_reviews) VALUES($1, $2)", team.TeamName, team.DefaultMaxOpenReviews)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert team: %w", err)
 	}
 
 	for _, m := range team.Members {
-		_, err := h.db.Pool.Exec(ctx, `
+		// Existing users are moved to the team and updated, the audit log keeps their previous state
+		before, err := loadUser(ctx, tx, m.UserId)
+		var apiErr *apiError
+		if err != nil && !errors.As(err, &apiErr) {
+			return nil, fmt.Errorf("failed to fetch user %s: %w", m.UserId, err)
+		}
+
+		_, err = tx.Exec(ctx, `
 			INSERT INTO users(user_id, username, team_name, is_active)
 			VALUES($1, $2, $3, $4)
 			ON CONFLICT (user_id) DO UPDATE
@@ -400,22 +645,146 @@ func (h *Handler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 			    is_active = EXCLUDED.is_active
 		`, m.UserId, m.Username, team.TeamName, m.IsActive)
 		if err != nil {
-			writeError(w, api.INTERNALERROR, fmt.Sprintf("failed to insert user %s", m.UserId), http.StatusInternalServerError)
-			return
+			return nil, fmt.Errorf("failed to insert user %s: %w", m.UserId, err)
+		}
+
+		if before == nil {
+			continue
+		}
+
+		after, err := loadUser(ctx, tx, m.UserId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch user %s: %w", m.UserId, err)
+		}
+
+		err = recordAudit(ctx, tx, auditRecord{
+			actor:      actor,
+			operation:  "team.add",
+			targetType: targetUser,
+			targetId:   m.UserId,
+			before:     snapshot(before),
+			after:      snapshot(after),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to write audit log: %w", err)
 		}
 	}
 
-	writeJSON(w, http.StatusCreated, team)
+	if err := replaceFallbackTeams(ctx, tx, team.TeamName, fallbacks); err != nil {
+		return nil, fmt.Errorf("failed to save fallback teams: %w", err)
+	}
+
+	created, err := loadTeam(ctx, tx, team.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      actor,
+		operation:  "team.add",
+		targetType: targetTeam,
+		targetId:   team.TeamName,
+		after:      snapshot(created),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 func (h *Handler) GetTeamGet(w http.ResponseWriter, r *http.Request, params api.GetTeamGetParams) {
+	team, err := h.GetTeam(r.Context(), params.TeamName)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch team")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, team)
+}
+
+// GetTeam returns the team, see loadTeam
+func (h *Handler) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
+	return loadTeam(ctx, h.db, teamName)
+}
+
+// loadTeam returns the team with its members, fallback teams and review SLA
+func loadTeam(ctx context.Context, q querier, teamName string) (*api.Team, error) {
+	var defaultCapacity *int
+	err := q.QueryRow(ctx,
+		"SELECT default_max_open_reviews FROM teams WHERE team_name=$1",
+		teamName,
+	).Scan(&defaultCapacity)
+
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, &apiError{api.NOTFOUND, "team not found", http.StatusNotFound}
+		}
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, `SELECT user_id, username, is_active FROM users WHERE team_name=$1`, teamName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	members := make([]api.TeamMember, 0)
+	for rows.Next() {
+		var m api.TeamMember
+		if err := rows.Scan(&m.UserId, &m.Username, &m.IsActive); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	fallbacks, err := fallbackTeams(ctx, q, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	sla, err := loadReviewSLA(ctx, q, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.Team{
+		TeamName:              teamName,
+		Members:               members,
+		FallbackTeams:         &fallbacks,
+		DefaultMaxOpenReviews: defaultCapacity,
+		ReviewSla:             sla,
+	}, nil
+}
+
+func (h *Handler) PostTeamSetFallbacks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	teamName := params.TeamName
+	var body api.PostTeamSetFallbacksJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if body.TeamName == "" {
+		writeError(w, api.INVALIDREQUEST, "team_name is required", http.StatusBadRequest)
+		return
+	}
 
 	var exists bool
-	err := h.db.Pool.QueryRow(ctx,
+	err := h.db.QueryRow(ctx,
 		"SELECT EXISTS(SELECT 1 FROM teams WHERE team_name=$1)",
-		teamName,
+		body.TeamName,
 	).Scan(&exists)
 
 	if err != nil {
@@ -428,88 +797,183 @@ func (h *Handler) GetTeamGet(w http.ResponseWriter, r *http.Request, params api.
 		return
 	}
 
-	rows, err := h.db.Pool.Query(ctx, `SELECT user_id, username, is_active FROM users WHERE team_name=$1`, teamName)
+	if err := validateFallbackTeams(ctx, h.db, body.TeamName, body.FallbackTeams); err != nil {
+		writeAPIError(w, err, "failed to check fallback teams")
+		return
+	}
 
+	tx, err := h.db.Begin(ctx)
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to fetch users", http.StatusInternalServerError)
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
 		return
 	}
+	defer tx.Rollback(ctx)
 
-	defer rows.Close()
+	before, err := loadTeam(ctx, tx, body.TeamName)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch team")
+		return
+	}
 
-	var members []api.TeamMember
-	for rows.Next() {
-		var m api.TeamMember
-		if err := rows.Scan(&m.UserId, &m.Username, &m.IsActive); err != nil {
-			writeError(w, api.INTERNALERROR, "failed to fetch member", http.StatusInternalServerError)
-			return
-		}
-		members = append(members, m)
+	if err := replaceFallbackTeams(ctx, tx, body.TeamName, body.FallbackTeams); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to save fallback teams", http.StatusInternalServerError)
+		return
 	}
 
-	team := api.Team{
-		TeamName: teamName,
-		Members:  members,
+	team, err := loadTeam(ctx, tx, body.TeamName)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch team")
+		return
 	}
 
-	writeJSON(w, http.StatusOK, team)
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      requestActor(r),
+		operation:  "team.setFallbacks",
+		targetType: targetTeam,
+		targetId:   body.TeamName,
+		before:     snapshot(before),
+		after:      snapshot(team),
+	})
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to write audit log", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to save fallback teams", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]*api.Team{"team": team})
+}
+
+// ReviewInbox is a page of PRs where the user is a reviewer
+type ReviewInbox struct {
+	UserId       string                 `json:"user_id"`
+	PullRequests []api.PullRequestShort `json:"pull_requests"`
+	Total        int                    `json:"total"`
+	NextCursor   *string                `json:"next_cursor"`
 }
 
 func (h *Handler) GetUsersGetReview(w http.ResponseWriter, r *http.Request, params api.GetUsersGetReviewParams) {
-	ctx := r.Context()
+	inbox, err := h.GetReview(r.Context(), params)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch pull requests")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, inbox)
+}
+
+// GetReview returns a page of PRs where the user is a reviewer, open ones newest first by default
+func (h *Handler) GetReview(ctx context.Context, params api.GetUsersGetReviewParams) (*ReviewInbox, error) {
 	userId := params.UserId
 
 	if userId == "" {
-		writeError(w, api.INVALIDREQUEST, "user_id is required", http.StatusBadRequest)
-		return
+		return nil, &apiError{api.INVALIDREQUEST, "user_id is required", http.StatusBadRequest}
 	}
 
 	var exists bool
-	err := h.db.Pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE user_id=$1)", userId).Scan(&exists)
+	err := h.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE user_id=$1)", userId).Scan(&exists)
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to check if user exists", http.StatusInternalServerError)
-		return
+		return nil, fmt.Errorf("failed to check if user exists: %w", err)
 	}
 
 	if !exists {
-		writeError(w, api.NOTFOUND, "user not found", http.StatusNotFound)
-		return
+		return nil, &apiError{api.NOTFOUND, "user not found", http.StatusNotFound}
 	}
 
-	rows, err := h.db.Pool.Query(ctx, `SELECT pull_request_id, pull_request_name, author_id, status FROM prs WHERE $1 = ANY(assigned_reviewers)`, userId)
+	limit, err := pageLimit(params.Limit)
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to fetch pull requests", http.StatusInternalServerError)
-		return
+		return nil, err
+	}
+
+	var cond conditions
+	cond.add(reviewedBy, userId)
+
+	status := api.OPEN
+	if params.Status != nil {
+		status = *params.Status
+	}
+	if status != api.ALL {
+		cond.add("status = ?", string(status))
+	}
+
+	var total int
+	if err := h.db.QueryRow(ctx, `SELECT COUNT(*) FROM prs`+cond.where(), cond.args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count pull requests: %w", err)
+	}
+
+	direction, compare := "DESC", "<"
+	if params.Order != nil && *params.Order == api.Asc {
+		direction, compare = "ASC", ">"
+	}
+
+	if params.Cursor != nil {
+		var cursor listCursor
+		if err := decodeCursor(*params.Cursor, &cursor); err != nil || cursor.PullRequestId == "" || cursor.CreatedAt.IsZero() {
+			return nil, &apiError{api.INVALIDREQUEST, "invalid cursor", http.StatusBadRequest}
+		}
+		cond.add("(created_at, pull_request_id) "+compare+" (?, ?)", cursor.CreatedAt, cursor.PullRequestId)
+	}
+
+	// One extra row tells whether there is a next page
+	rows, err := h.db.Query(ctx, `SELECT `+pullRequestColumns+` FROM prs`+cond.where()+
+		fmt.Sprintf(` ORDER BY created_at %s, pull_request_id %s LIMIT %d`, direction, direction, limit+1),
+		cond.args...)
+	if err != nil {
+		return nil, err
 	}
 	defer rows.Close()
 
-	var prs []api.PullRequestShort
+	found := make([]*pullRequest, 0, limit+1)
 	for rows.Next() {
-		var pr api.PullRequestShort
-		var status string
-		if err := rows.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &status); err != nil {
-			writeError(w, api.INTERNALERROR, "failed to scan pull request", http.StatusInternalServerError)
-			return
+		pr, err := scanPullRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pull request: %w", err)
 		}
-		pr.Status = api.PullRequestShortStatus(status)
-		prs = append(prs, pr)
+		found = append(found, pr)
 	}
 
 	if err := rows.Err(); err != nil {
-		writeError(w, api.INTERNALERROR, "failed to fetch pull requests", http.StatusInternalServerError)
-		return
+		return nil, err
 	}
+	rows.Close()
 
-	resp := map[string]interface{}{
-		"user_id":       userId,
-		"pull_requests": prs,
+	if err := loadReviewers(ctx, h.db, found...); err != nil {
+		return nil, fmt.Errorf("failed to fetch reviewers: %w", err)
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	prs := make([]api.PullRequestShort, 0, len(found))
+	for _, pr := range found {
+		others := withoutUser(pr.reviewers, userId)
+		prs = append(prs, api.PullRequestShort{
+			PullRequestId:   pr.id,
+			PullRequestName: pr.name,
+			AuthorId:        pr.authorId,
+			Status:          api.PullRequestShortStatus(pr.status),
+			CreatedAt:       pr.createdAt,
+			OtherReviewers:  &others,
+		})
+	}
+
+	var nextCursor *string
+	if len(prs) > limit {
+		prs = prs[:limit]
+		last := prs[limit-1]
+		cursor := encodeCursor(listCursor{CreatedAt: *last.CreatedAt, PullRequestId: last.PullRequestId})
+		nextCursor = &cursor
+	}
+
+	return &ReviewInbox{
+		UserId:       userId,
+		PullRequests: prs,
+		Total:        total,
+		NextCursor:   nextCursor,
+	}, nil
 }
 
 func (h *Handler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
 	var body api.PostUsersSetIsActiveJSONBody
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -517,38 +981,67 @@ func (h *Handler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if body.UserId == "" {
-		writeError(w, api.INVALIDREQUEST, "user_id is required", http.StatusBadRequest)
+	// is_active is required by the spec, so the validation middleware doesn't let it default to false
+
+	user, err := h.SetIsActive(r.Context(), body.UserId, body.IsActive, requestActor(r))
+	if err != nil {
+		writeAPIError(w, err, "failed to update user")
 		return
 	}
 
-	// TODO: check if is_active is in body and not just "false" by default
+	writeJSON(w, http.StatusOK, map[string]*api.User{"user": user})
+}
+
+// SetIsActive activates or deactivates the user
+func (h *Handler) SetIsActive(ctx context.Context, userId string, isActive bool, actor *string) (*api.User, error) {
+	if userId == "" {
+		return nil, &apiError{api.INVALIDREQUEST, "user_id is required", http.StatusBadRequest}
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := loadUser(ctx, tx, userId)
+	if err != nil {
+		return nil, err
+	}
 
-	cmdTag, err := h.db.Pool.Exec(ctx, `
+	_, err = tx.Exec(ctx, `
 		UPDATE users 
 		SET is_active=$1
 		WHERE user_id=$2
-	`, body.IsActive, body.UserId)
+	`, isActive, userId)
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to update user", http.StatusInternalServerError)
-		return
+		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
-	if cmdTag.RowsAffected() == 0 {
-		writeError(w, api.NOTFOUND, "user not found", http.StatusNotFound)
-		return
+	user, err := loadUser(ctx, tx, userId)
+	if err != nil {
+		return nil, err
 	}
 
-	var user api.User
-	err = h.db.Pool.QueryRow(ctx, `
-		SELECT user_id, username, team_name, is_active 
-		FROM users 
-		WHERE user_id=$1
-	`, body.UserId).Scan(&user.UserId, &user.Username, &user.TeamName, &user.IsActive)
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      actor,
+		operation:  "users.setIsActive",
+		targetType: targetUser,
+		targetId:   userId,
+		before:     snapshot(before),
+		after:      snapshot(user),
+	})
 	if err != nil {
-		writeError(w, api.INTERNALERROR, "failed to fetch user", http.StatusInternalServerError)
-		return
+		return nil, fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	if err := publishUserActivity(ctx, tx, user, actor); err != nil {
+		return nil, fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
 	}
 
-	writeJSON(w, http.StatusOK, user)
+	return user, nil
 }
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+)
+
+// actorHeader carries the id of whoever makes the change, it ends up in PR history
+const actorHeader = "X-Actor-Id"
+
+// systemActor is the actor of changes made by background jobs
+const systemActor = "system"
+
+// requestActor returns the actor of the request or nil if the client didn't tell
+func requestActor(r *http.Request) *string {
+	if actor := r.Header.Get(actorHeader); actor != "" {
+		return &actor
+	}
+	return nil
+}
+
+// prEvent is a row of pr_events table
+type prEvent struct {
+	eventType  api.PullRequestEventType
+	at         time.Time
+	actor      *string
+	userId     *string
+	replacedBy *string
+}
+
+// recordEvents appends events to the history of the PR and publishes them to the event stream.
+// Callers write them in the same transaction as the change itself, after the change is applied to pr.
+func recordEvents(ctx context.Context, q querier, pr *pullRequest, changes ...prEvent) error {
+	for _, e := range changes {
+		_, err := q.Exec(ctx, `
+			INSERT INTO pr_events(pull_request_id, type, at, actor, user_id, replaced_by)
+			VALUES($1, $2, $3, $4, $5, $6)
+		`, pr.id, string(e.eventType), e.at, e.actor, e.userId, e.replacedBy)
+		if err != nil {
+			return err
+		}
+	}
+	return publishPREvents(ctx, q, pr, changes)
+}
+
+// reviewerEvent is an event about a single reviewer
+func reviewerEvent(eventType api.PullRequestEventType, at time.Time, actor *string, userId string) prEvent {
+	return prEvent{eventType: eventType, at: at, actor: actor, userId: &userId}
+}
+
+// loadHistory returns events of the PR in the order they happened
+func loadHistory(ctx context.Context, q querier, prId string) ([]api.PullRequestEvent, error) {
+	rows, err := q.Query(ctx, `
+		SELECT event_id, type, at, actor, user_id, replaced_by
+		FROM pr_events
+		WHERE pull_request_id=$1
+		ORDER BY event_id
+	`, prId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]api.PullRequestEvent, 0)
+	for rows.Next() {
+		var (
+			e         api.PullRequestEvent
+			eventType string
+		)
+		if err := rows.Scan(&e.EventId, &eventType, &e.At, &e.Actor, &e.UserId, &e.ReplacedBy); err != nil {
+			return nil, err
+		}
+		e.Type = api.PullRequestEventType(eventType)
+		e.At = e.At.UTC()
+		history = append(history, e)
+	}
+
+	return history, rows.Err()
+}
+
+func (h *Handler) GetPullRequestGet(w http.ResponseWriter, r *http.Request, params api.GetPullRequestGetParams) {
+	ctx := r.Context()
+
+	if params.PullRequestId == "" {
+		writeError(w, api.INVALIDREQUEST, "pull_request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	pr, err := loadPullRequest(ctx, h.db, params.PullRequestId)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch PR")
+		return
+	}
+
+	resp := map[string]interface{}{
+		"pr": pr.toAPI(),
+	}
+
+	if params.Include != nil && *params.Include == api.History {
+		history, err := loadHistory(ctx, h.db, pr.id)
+		if err != nil {
+			writeError(w, api.INTERNALERROR, "failed to fetch PR history", http.StatusInternalServerError)
+			return
+		}
+		resp["history"] = history
+	}
+
+	setETag(w, pr.version)
+	writeJSON(w, http.StatusOK, resp)
+}
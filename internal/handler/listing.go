@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+)
+
+// listCursor points at the last PR of a page. PRs are ordered by creation time and id, both descending,
+// so the next page starts right after it.
+type listCursor struct {
+	CreatedAt     time.Time `json:"created_at"`
+	PullRequestId string    `json:"pull_request_id"`
+}
+
+func (h *Handler) GetPullRequestList(w http.ResponseWriter, r *http.Request, params api.GetPullRequestListParams) {
+	ctx := r.Context()
+
+	limit, err := pageLimit(params.Limit)
+	if err != nil {
+		writeAPIError(w, err, "invalid limit")
+		return
+	}
+
+	var cond conditions
+
+	if params.Status != nil {
+		cond.add("status = ?", string(*params.Status))
+	}
+	if params.AuthorId != nil {
+		cond.add("author_id = ?", *params.AuthorId)
+	}
+	if params.ReviewerId != nil {
+		cond.add(reviewedBy, *params.ReviewerId)
+	}
+	if params.TeamName != nil {
+		cond.add("author_id IN (SELECT user_id FROM users WHERE team_name = ?)", *params.TeamName)
+	}
+	if params.CreatedFrom != nil {
+		cond.add("created_at >= ?", *params.CreatedFrom)
+	}
+	if params.CreatedTo != nil {
+		cond.add("created_at < ?", *params.CreatedTo)
+	}
+	if params.MergedFrom != nil {
+		cond.add("merged_at >= ?", *params.MergedFrom)
+	}
+	if params.MergedTo != nil {
+		cond.add("merged_at < ?", *params.MergedTo)
+	}
+	if params.Q != nil && *params.Q != "" {
+		cond.add(`LOWER(pull_request_name) LIKE LOWER(?) ESCAPE '\'`, "%"+escapeLike(*params.Q)+"%")
+	}
+	if params.Cursor != nil {
+		var cursor listCursor
+		if err := decodeCursor(*params.Cursor, &cursor); err != nil || cursor.PullRequestId == "" || cursor.CreatedAt.IsZero() {
+			writeError(w, api.INVALIDREQUEST, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+		cond.add("(created_at, pull_request_id) < (?, ?)", cursor.CreatedAt, cursor.PullRequestId)
+	}
+
+	// One extra row tells whether there is a next page
+	query := `SELECT ` + pullRequestColumns + ` FROM prs` + cond.where() +
+		fmt.Sprintf(` ORDER BY created_at DESC, pull_request_id DESC LIMIT %d`, limit+1)
+
+	rows, err := h.db.Query(ctx, query, cond.args...)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch pull requests", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	prs := make([]*pullRequest, 0, limit+1)
+	for rows.Next() {
+		pr, err := scanPullRequest(rows)
+		if err != nil {
+			writeError(w, api.INTERNALERROR, "failed to scan pull request", http.StatusInternalServerError)
+			return
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch pull requests", http.StatusInternalServerError)
+		return
+	}
+	rows.Close()
+
+	if err := loadReviewers(ctx, h.db, prs...); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch reviewers", http.StatusInternalServerError)
+		return
+	}
+
+	var nextCursor *string
+	if len(prs) > limit {
+		prs = prs[:limit]
+		last := prs[limit-1]
+		cursor := encodeCursor(listCursor{CreatedAt: *last.createdAt, PullRequestId: last.id})
+		nextCursor = &cursor
+	}
+
+	result := make([]api.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		result = append(result, pr.toAPI())
+	}
+
+	resp := map[string]interface{}{
+		"pull_requests": result,
+		"next_cursor":   nextCursor,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
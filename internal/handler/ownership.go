@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/codeowners"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+func (h *Handler) PostTeamSetCodeowners(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body api.PostTeamSetCodeownersJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if body.TeamName == "" {
+		writeError(w, api.INVALIDREQUEST, "team_name is required", http.StatusBadRequest)
+		return
+	}
+
+	rules, err := codeowners.Parse(body.Codeowners)
+	if err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid codeowners: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var exists bool
+	err = h.db.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM teams WHERE team_name=$1)",
+		body.TeamName,
+	).Scan(&exists)
+
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		writeError(w, api.NOTFOUND, "team not found", http.StatusNotFound)
+		return
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	previous, err := ownershipRules(ctx, tx, body.TeamName)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch codeowners", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM ownership_rules WHERE team_name=$1`, body.TeamName); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to save codeowners", http.StatusInternalServerError)
+		return
+	}
+
+	for i, rule := range rules {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO ownership_rules(team_name, position, pattern, owners)
+			VALUES($1, $2, $3, $4)
+		`, body.TeamName, i, rule.Pattern, db.Strings(rule.Owners))
+		if err != nil {
+			writeError(w, api.INTERNALERROR, "failed to save codeowners", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	resp := codeownersResponse(body.TeamName, rules)
+
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      requestActor(r),
+		operation:  "team.setCodeowners",
+		targetType: targetTeam,
+		targetId:   body.TeamName,
+		before:     snapshot(codeownersResponse(body.TeamName, previous)),
+		after:      snapshot(resp),
+	})
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to write audit log", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to save codeowners", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) GetTeamGetCodeowners(w http.ResponseWriter, r *http.Request, params api.GetTeamGetCodeownersParams) {
+	ctx := r.Context()
+
+	var exists bool
+	err := h.db.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM teams WHERE team_name=$1)",
+		params.TeamName,
+	).Scan(&exists)
+
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		writeError(w, api.NOTFOUND, "team not found", http.StatusNotFound)
+		return
+	}
+
+	rules, err := ownershipRules(ctx, h.db, params.TeamName)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch codeowners", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, codeownersResponse(params.TeamName, rules))
+}
+
+func codeownersResponse(teamName string, rules []codeowners.Rule) api.Codeowners {
+	resp := api.Codeowners{
+		TeamName: teamName,
+		Rules:    make([]api.OwnershipRule, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		resp.Rules = append(resp.Rules, api.OwnershipRule{Pattern: rule.Pattern, Owners: rule.Owners})
+	}
+	return resp
+}
+
+// ownershipRules returns CODEOWNERS rules of the team in their original order
+func ownershipRules(ctx context.Context, q querier, teamName string) ([]codeowners.Rule, error) {
+	rows, err := q.Query(ctx, `SELECT pattern, owners FROM ownership_rules WHERE team_name=$1 ORDER BY position`, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]codeowners.Rule, 0)
+	for rows.Next() {
+		var (
+			pattern string
+			owners  db.Strings
+		)
+		if err := rows.Scan(&pattern, &owners); err != nil {
+			return nil, err
+		}
+		rule, err := codeowners.NewRule(pattern, owners)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// codeOwners returns active owners of the changed files who are not away, in random order. Owners come from
+// CODEOWNERS rules of the team and are either users (by user_id or username) or whole teams ("org/team").
+func (h *Handler) codeOwners(ctx context.Context, q querier, teamName string, changedFiles []string, exclude map[string]struct{}) ([]candidate, error) {
+	owned, args, err := ownerCondition(ctx, q, teamName, changedFiles, 2)
+	if err != nil || owned == "" {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, candidateSelect+` AND `+owned+` ORDER BY users.user_id`, append([]any{h.now()}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	owners, err := h.scanCandidates(rows, poolOwner, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return owners, nil
+}
+
+// ownerCondition is the condition on users that they own some of the changed files by CODEOWNERS rules
+// of the team, with its arguments numbered from start. It is empty if the files have no owners.
+func ownerCondition(ctx context.Context, q querier, teamName string, changedFiles []string, start int) (string, []any, error) {
+	if len(changedFiles) == 0 {
+		return "", nil, nil
+	}
+
+	rules, err := ownershipRules(ctx, q, teamName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	userOwners := make([]string, 0)
+	teamOwners := make([]string, 0)
+	for _, file := range changedFiles {
+		for _, owner := range codeowners.Owners(rules, file) {
+			if i := strings.LastIndex(owner, "/"); i >= 0 {
+				teamOwners = append(teamOwners, owner[i+1:])
+			} else {
+				userOwners = append(userOwners, owner)
+			}
+		}
+	}
+
+	if len(userOwners) == 0 && len(teamOwners) == 0 {
+		return "", nil, nil
+	}
+
+	args := make([]any, 0, len(userOwners)+len(teamOwners))
+	for _, owner := range append(userOwners, teamOwners...) {
+		args = append(args, owner)
+	}
+	users, teams := placeholders(start, len(userOwners)), placeholders(start+len(userOwners), len(teamOwners))
+
+	return `(users.user_id IN (` + users + `) OR users.username IN (` + users + `) OR users.team_name IN (` + teams + `))`, args, nil
+}
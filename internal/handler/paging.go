@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// pageLimit returns the requested page size or the default one
+func pageLimit(limit *int) (int, error) {
+	if limit == nil {
+		return defaultPageSize, nil
+	}
+	if *limit < 1 || *limit > maxPageSize {
+		return 0, &apiError{api.INVALIDREQUEST, fmt.Sprintf("limit must be between 1 and %d", maxPageSize), http.StatusBadRequest}
+	}
+	return *limit, nil
+}
+
+// encodeCursor turns the position of the last item of a page into an opaque string for clients
+func encodeCursor(position any) string {
+	data, _ := json.Marshal(position)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string, position any) error {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, position)
+}
+
+// conditions builds WHERE clause of a query numbering placeholders as they are added
+type conditions struct {
+	clauses []string
+	args    []any
+}
+
+// add appends the clause, every "?" in it is replaced with a placeholder of the next argument
+func (c *conditions) add(clause string, args ...any) {
+	for _, arg := range args {
+		c.args = append(c.args, arg)
+		clause = strings.Replace(clause, "?", fmt.Sprintf("$%d", len(c.args)), 1)
+	}
+	c.clauses = append(c.clauses, clause)
+}
+
+func (c *conditions) where() string {
+	if len(c.clauses) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c.clauses, " AND ")
+}
+
+// placeholders returns n comma separated placeholders starting from $start, for IN lists.
+// An empty list is NULL, which matches nothing.
+func placeholders(start, n int) string {
+	if n == 0 {
+		return "NULL"
+	}
+	list := make([]string, n)
+	for i := range list {
+		list[i] = fmt.Sprintf("$%d", start+i)
+	}
+	return strings.Join(list, ", ")
+}
+
+// escapeLike makes the user input match literally in LIKE patterns
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
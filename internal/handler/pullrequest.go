@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+// pullRequest is a row of prs table
+type pullRequest struct {
+	id           string
+	name         string
+	authorId     string
+	status       string
+	reviewers    []string
+	fallback     []string // reviewers which came from fallback teams
+	changedFiles []string
+	createdAt    *time.Time
+	mergedAt     *time.Time
+	version      int64 // incremented on every change, clients see it as ETag
+}
+
+// pullRequestColumns are the columns scanPullRequest expects, in its order
+const pullRequestColumns = `pull_request_id, pull_request_name, author_id, status, changed_files, created_at, merged_at, version`
+
+// scanPullRequest reads a row of prs, reviewers are loaded separately by loadReviewers
+func scanPullRequest(row db.Row) (*pullRequest, error) {
+	var (
+		pr           = &pullRequest{}
+		changedFiles db.Strings
+	)
+	err := row.Scan(&pr.id, &pr.name, &pr.authorId, &pr.status, &changedFiles, &pr.createdAt, &pr.mergedAt, &pr.version)
+	if err != nil {
+		return nil, err
+	}
+	pr.changedFiles = changedFiles
+	return pr, nil
+}
+
+// loadReviewers fills assigned and fallback reviewers of the PRs with a single query
+func loadReviewers(ctx context.Context, q querier, prs ...*pullRequest) error {
+	if len(prs) == 0 {
+		return nil
+	}
+
+	byId := make(map[string]*pullRequest, len(prs))
+	ids := make([]any, 0, len(prs))
+	for _, pr := range prs {
+		pr.reviewers, pr.fallback = make([]string, 0), make([]string, 0)
+		byId[pr.id] = pr
+		ids = append(ids, pr.id)
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT pull_request_id, user_id, is_fallback FROM pr_reviewers
+		WHERE pull_request_id IN (`+placeholders(1, len(ids))+`)
+		ORDER BY pull_request_id, position
+	`, ids...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			prId, userId string
+			isFallback   bool
+		)
+		if err := rows.Scan(&prId, &userId, &isFallback); err != nil {
+			return err
+		}
+		pr := byId[prId]
+		pr.reviewers = append(pr.reviewers, userId)
+		if isFallback {
+			pr.fallback = append(pr.fallback, userId)
+		}
+	}
+
+	return rows.Err()
+}
+
+// reviewedBy is a condition on prs that the user is one of the reviewers, for conditions.add
+const reviewedBy = `EXISTS (
+	SELECT 1 FROM pr_reviewers WHERE pr_reviewers.pull_request_id = prs.pull_request_id AND pr_reviewers.user_id = ?
+)`
+
+// loadPullRequest returns the PR or NOT_FOUND apiError
+func loadPullRequest(ctx context.Context, q querier, prId string) (*pullRequest, error) {
+	return queryPullRequest(ctx, q, `SELECT `+pullRequestColumns+` FROM prs WHERE pull_request_id=$1`, prId)
+}
+
+// lockPullRequest loads the PR and locks it until the end of the transaction, so concurrent changes
+// of the PR are applied one after another instead of overwriting each other
+func (h *Handler) lockPullRequest(ctx context.Context, tx querier, prId string) (*pullRequest, error) {
+	return queryPullRequest(ctx, tx, `SELECT `+pullRequestColumns+` FROM prs WHERE pull_request_id=$1`+h.db.Dialect.ForUpdate(), prId)
+}
+
+func queryPullRequest(ctx context.Context, q querier, sql, prId string) (*pullRequest, error) {
+	pr, err := scanPullRequest(q.QueryRow(ctx, sql, prId))
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, &apiError{api.NOTFOUND, "PR not found", http.StatusNotFound}
+		}
+		return nil, err
+	}
+	if err := loadReviewers(ctx, q, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (pr *pullRequest) toAPI() api.PullRequest {
+	fallback := pr.fallback
+	return api.PullRequest{
+		PullRequestId:     pr.id,
+		PullRequestName:   pr.name,
+		AuthorId:          pr.authorId,
+		Status:            api.PullRequestStatus(pr.status),
+		AssignedReviewers: pr.reviewers,
+		FallbackReviewers: &fallback,
+		CreatedAt:         pr.createdAt,
+		MergedAt:          pr.mergedAt,
+	}
+}
+
+// VersionedPullRequest is a PR as clients see it together with its version
+type VersionedPullRequest struct {
+	api.PullRequest
+	Version int64
+}
+
+func (pr *pullRequest) versioned() *VersionedPullRequest {
+	return &VersionedPullRequest{PullRequest: pr.toAPI(), Version: pr.version}
+}
+
+// reviewerIndex returns position of the reviewer in assigned reviewers or -1
+func (pr *pullRequest) reviewerIndex(userId string) int {
+	for i, uid := range pr.reviewers {
+		if uid == userId {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeReviewer drops the reviewer from assigned and fallback reviewers
+func (pr *pullRequest) removeReviewer(userId string) {
+	pr.reviewers = withoutUser(pr.reviewers, userId)
+	pr.fallback = withoutUser(pr.fallback, userId)
+}
+
+// replaceReviewer puts the new reviewer in place of the old one. Old reviewer leaves the fallback list,
+// the new one joins it if it came from a fallback team of the author's team.
+func (pr *pullRequest) replaceReviewer(oldUserId string, c candidate, authorTeam string) {
+	pr.reviewers[pr.reviewerIndex(oldUserId)] = c.userId
+	pr.fallback = append(withoutUser(pr.fallback, oldUserId), fallbackReviewers([]candidate{c}, authorTeam)...)
+}
+
+// saveReviewers writes assigned and fallback reviewers of the PR and bumps its version
+func (pr *pullRequest) saveReviewers(ctx context.Context, q querier) error {
+	if _, err := q.Exec(ctx, `DELETE FROM pr_reviewers WHERE pull_request_id=$1`, pr.id); err != nil {
+		return err
+	}
+	if err := insertReviewers(ctx, q, pr); err != nil {
+		return err
+	}
+	return q.QueryRow(ctx, `
+		UPDATE prs SET version=version+1
+		WHERE pull_request_id=$1
+		RETURNING version
+	`, pr.id).Scan(&pr.version)
+}
+
+// insertReviewers writes reviewers of the PR in their order
+func insertReviewers(ctx context.Context, q querier, pr *pullRequest) error {
+	fallback := make(map[string]bool, len(pr.fallback))
+	for _, uid := range pr.fallback {
+		fallback[uid] = true
+	}
+
+	for i, uid := range pr.reviewers {
+		_, err := q.Exec(ctx, `
+			INSERT INTO pr_reviewers(pull_request_id, user_id, position, is_fallback)
+			VALUES($1, $2, $3, $4)
+		`, pr.id, uid, i, fallback[uid])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ETag returns the entity tag of the PR version
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// setETag sets ETag header of the response to the PR version
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", ETag(version))
+}
+
+// checkIfMatch compares If-Match header with the current version of the PR. Absent header matches anything.
+func (pr *pullRequest) checkIfMatch(ifMatch *string) error {
+	if ifMatch == nil {
+		return nil
+	}
+	current := ETag(pr.version)
+	for _, tag := range strings.Split(*ifMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			return nil
+		}
+	}
+	return &apiError{api.PRVERSIONMISMATCH, fmt.Sprintf("PR has changed, current version is %s", current), http.StatusPreconditionFailed}
+}
+
+func withoutUser(userIds []string, userId string) []string {
+	result := make([]string, 0, len(userIds))
+	for _, uid := range userIds {
+		if uid != userId {
+			result = append(result, uid)
+		}
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// Random is the source of randomness of reviewer selection. Requests are served concurrently, so it must be
+// safe for concurrent use.
+type Random interface {
+	Float64() float64
+	Shuffle(n int, swap func(i, j int))
+}
+
+// lockedRandom is a *rand.Rand behind a mutex, *rand.Rand itself must not be shared between goroutines
+type lockedRandom struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// NewRandom returns a Random which gives the same sequence for the same seed. With a fixed seed the same
+// requests sent one by one get the same reviewers, which makes tests and replays of incidents reproducible.
+func NewRandom(seed int64) Random {
+	return &lockedRandom{r: rand.New(rand.NewSource(seed))}
+}
+
+func (l *lockedRandom) Float64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Float64()
+}
+
+// Shuffle holds the lock for the whole shuffle, so that concurrent shuffles don't interleave their draws
+func (l *lockedRandom) Shuffle(n int, swap func(i, j int)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.r.Shuffle(n, swap)
+}
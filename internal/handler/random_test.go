@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+// TestRandomIsSafeForConcurrentUse is meant for -race: the handler shares one Random between all requests
+func TestRandomIsSafeForConcurrentUse(t *testing.T) {
+	r := NewRandom(1)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 1000 {
+				items := []int{1, 2, 3, 4, 5}
+				r.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+				if f := r.Float64(); f < 0 || f >= 1 {
+					t.Errorf("Float64 returned %v", f)
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestRandomIsReproducible(t *testing.T) {
+	draw := func() []float64 {
+		r := NewRandom(42)
+		values := make([]float64, 10)
+		for i := range values {
+			values[i] = r.Float64()
+		}
+		return values
+	}
+
+	if first, second := draw(), draw(); !slices.Equal(first, second) {
+		t.Fatalf("same seed gave %v and %v", first, second)
+	}
+}
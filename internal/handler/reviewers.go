@@ -0,0 +1,324 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+// reviewersPerPR is how many reviewers we try to assign to a new PR
+const reviewersPerPR = 2
+
+// querier is implemented both by *db.DB and db.Tx, so helpers can be used in and out of transactions
+type querier = db.Querier
+
+// Pools reviewers are picked from, in order of preference
+const (
+	poolOwner    = "owner"    // owners of the changed files by CODEOWNERS
+	poolTeam     = "team"     // members of the team itself
+	poolFallback = "fallback" // members of fallback teams
+)
+
+// notAway filters out users who are away according to their absences at the time passed as the argument n,
+// which comes from the handler's clock
+func notAway(n int) string {
+	return fmt.Sprintf(`NOT EXISTS (
+	SELECT 1 FROM absences a WHERE a.user_id = users.user_id AND a.starts_at <= $%[1]d AND a.ends_at > $%[1]d
+)`, n)
+}
+
+// openReviewCount counts open PRs the user is a reviewer of
+const openReviewCount = `(
+	SELECT COUNT(*) FROM pr_reviewers JOIN prs ON prs.pull_request_id = pr_reviewers.pull_request_id
+	WHERE prs.status = 'OPEN' AND pr_reviewers.user_id = users.user_id
+)`
+
+// candidateSelect selects active users who are not away together with their effective capacity
+// and current number of open reviews. The first argument is the current time, callers append their own
+// conditions numbering arguments from 2.
+var candidateSelect = `
+	SELECT users.user_id, users.team_name,
+		COALESCE(users.max_open_reviews, teams.default_max_open_reviews),
+		` + openReviewCount + `
+	FROM users JOIN teams ON teams.team_name = users.team_name
+	WHERE users.is_active = TRUE AND ` + notAway(1)
+
+type candidate struct {
+	userId      string
+	teamName    string
+	pool        string
+	capacity    *int // nil means there is no limit
+	openReviews int
+}
+
+func (c candidate) atCapacity() bool {
+	return c.capacity != nil && c.openReviews >= *c.capacity
+}
+
+func (h *Handler) shuffle(candidates []candidate) {
+	if len(candidates) > 1 {
+		h.rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+	}
+}
+
+// scanCandidates reads rows of candidateSelect skipping excluded users, applies the dry run if there is one
+// and shuffles the result. Rows must come
+// in a stable order, otherwise a seeded Random wouldn't give the same result twice.
+func (h *Handler) scanCandidates(rows db.Rows, pool string, exclude map[string]struct{}) ([]candidate, error) {
+	defer rows.Close()
+
+	candidates := make([]candidate, 0)
+	for rows.Next() {
+		c := candidate{pool: pool}
+		if err := rows.Scan(&c.userId, &c.teamName, &c.capacity, &c.openReviews); err != nil {
+			return nil, err
+		}
+		if h.sim != nil && !h.sim.adjust(&c) {
+			continue
+		}
+		if _, excluded := exclude[c.userId]; !excluded {
+			candidates = append(candidates, c)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	h.shuffle(candidates)
+
+	return candidates, nil
+}
+
+// activeMembers returns active members of the team which are neither away nor in exclude, in random order
+func (h *Handler) activeMembers(ctx context.Context, q querier, teamName, pool string, exclude map[string]struct{}) ([]candidate, error) {
+	rows, err := q.Query(ctx, candidateSelect+` AND users.team_name=$2 ORDER BY users.user_id`, h.now(), teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.scanCandidates(rows, pool, exclude)
+}
+
+// fallbackTeams returns fallback teams of the team ordered by priority
+func fallbackTeams(ctx context.Context, q querier, teamName string) ([]string, error) {
+	rows, err := q.Query(ctx, `SELECT fallback_team_name FROM team_fallbacks WHERE team_name=$1 ORDER BY priority`, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		teams = append(teams, name)
+	}
+
+	return teams, rows.Err()
+}
+
+// fallbacksOf returns fallback teams of the team, overridden ones during a dry run
+func (h *Handler) fallbacksOf(ctx context.Context, q querier, teamName string) ([]string, error) {
+	if h.sim != nil && h.sim.fallbacks != nil && teamName == h.sim.teamName {
+		return h.sim.fallbacks, nil
+	}
+	return fallbackTeams(ctx, q, teamName)
+}
+
+// pickReviewers picks up to n reviewers. Code owners go first, then members of the first of the teams. If they
+// can't supply enough reviewers, the rest is taken from the other teams, fallbacks in order of priority.
+// Candidates at capacity are skipped, the second return value tells how many of them there were.
+// Within a pool candidates are picked according to the selection mode. If explain is not nil, every candidate
+// of the pools looked at is recorded in it, including those left after n were picked.
+func (h *Handler) pickReviewers(ctx context.Context, q querier, authorId string, teams []string, n int, exclude map[string]struct{}, owners []candidate, explain *Explanation) ([]candidate, int, error) {
+	var pairings map[string]float64
+	if h.opts.Selection == SelectionFair {
+		var err error
+		if pairings, err = h.pairings(ctx, q, authorId); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	picked := make([]candidate, 0, n)
+	saturated := make(map[string]struct{})
+	// exclude is extended with picked reviewers, so we copy it not to surprise the caller
+	excluded := make(map[string]struct{}, len(exclude)+n)
+	for uid := range exclude {
+		excluded[uid] = struct{}{}
+	}
+
+	take := func(candidates []candidate) bool {
+		if pairings != nil {
+			h.arrange(candidates, pairings)
+		}
+		for _, c := range candidates {
+			if len(picked) == n && explain == nil {
+				break
+			}
+			if _, already := excluded[c.userId]; already {
+				continue
+			}
+			if c.atCapacity() {
+				if len(picked) < n {
+					saturated[c.userId] = struct{}{}
+				}
+				explain.considered(c, score(pairings, c.userId), false, true)
+				continue
+			}
+			if len(picked) == n {
+				explain.considered(c, score(pairings, c.userId), false, false)
+				continue
+			}
+			picked = append(picked, c)
+			excluded[c.userId] = struct{}{}
+			explain.considered(c, score(pairings, c.userId), true, false)
+		}
+		return len(picked) == n
+	}
+
+	if take(owners) {
+		return picked, len(saturated), nil
+	}
+
+	for i, team := range teams {
+		pool := poolFallback
+		if i == 0 {
+			pool = poolTeam
+		}
+		explain.lookedAt(team)
+		members, err := h.activeMembers(ctx, q, team, pool, excluded)
+		if err != nil {
+			return nil, 0, err
+		}
+		if take(members) {
+			return picked, len(saturated), nil
+		}
+	}
+
+	return picked, len(saturated), nil
+}
+
+// lockCandidates locks the users a PR of the author's team could be assigned to: owners of the changed files
+// and members of the teams. Open reviews counted after that include the ones assigned to them by concurrent
+// transactions, so nobody is pushed past their capacity. All rows are locked by one statement in the order
+// of ids, so that transactions never wait for each other in a cycle. SQLite needs none of this, its write
+// transactions run one at a time.
+func (h *Handler) lockCandidates(ctx context.Context, tx db.Tx, authorTeam string, changedFiles, teams []string) error {
+	if h.db.Dialect == db.SQLite {
+		return nil
+	}
+
+	args := make([]any, 0, len(teams))
+	for _, team := range teams {
+		args = append(args, team)
+	}
+	owned, ownerArgs, err := ownerCondition(ctx, tx, authorTeam, changedFiles, len(teams)+1)
+	if err != nil {
+		return err
+	}
+	if owned == "" {
+		owned = "FALSE"
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT users.user_id FROM users
+		WHERE users.team_name IN (`+placeholders(1, len(teams))+`) OR `+owned+`
+		ORDER BY users.user_id`+h.db.Dialect.ForUpdate(),
+		append(args, ownerArgs...)...)
+	if err != nil {
+		return err
+	}
+	// The rows are locked as the statement runs, Close waits for it to finish
+	rows.Close()
+
+	return rows.Err()
+}
+
+// selectReviewers picks reviewers for a new PR of the author, see pickReviewers
+func (h *Handler) selectReviewers(ctx context.Context, q querier, authorId, teamName string, changedFiles []string, explain *Explanation) ([]candidate, int, error) {
+	exclude := map[string]struct{}{authorId: {}}
+	explain.exclude(authorId, api.Author)
+
+	owners, err := h.codeOwners(ctx, q, teamName, changedFiles, exclude)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get code owners: %w", err)
+	}
+
+	fallbacks, err := h.fallbacksOf(ctx, q, teamName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get fallback teams: %w", err)
+	}
+
+	// Code owners go first, then author's teammates, fallback teams fill the remaining slots
+	teams := append([]string{teamName}, fallbacks...)
+	reviewers, saturated, err := h.pickReviewers(ctx, q, authorId, teams, reviewersPerPR, exclude, owners, explain)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	if err := explain.addUnavailable(ctx, q, h.now()); err != nil {
+		return nil, 0, fmt.Errorf("failed to explain assignment: %w", err)
+	}
+
+	return reviewers, saturated, nil
+}
+
+// fallbackReviewers returns reviewers which came from fallback teams of the author's team.
+// Code owners are not counted even if they are from another team.
+func fallbackReviewers(reviewers []candidate, authorTeam string) []string {
+	fallback := make([]string, 0)
+	for _, c := range reviewers {
+		if c.pool != poolOwner && c.teamName != authorTeam {
+			fallback = append(fallback, c.userId)
+		}
+	}
+	return fallback
+}
+
+// validateFallbackTeams checks that fallback teams exist and make sense for the team
+func validateFallbackTeams(ctx context.Context, q querier, teamName string, fallbacks []string) error {
+	seen := make(map[string]struct{}, len(fallbacks))
+	for _, fallback := range fallbacks {
+		if fallback == teamName {
+			return &apiError{api.INVALIDREQUEST, "team cannot be its own fallback", http.StatusBadRequest}
+		}
+		if _, dup := seen[fallback]; dup {
+			return &apiError{api.INVALIDREQUEST, fmt.Sprintf("fallback team %s is listed twice", fallback), http.StatusBadRequest}
+		}
+		seen[fallback] = struct{}{}
+
+		var exists bool
+		err := q.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name=$1)", fallback).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return &apiError{api.NOTFOUND, fmt.Sprintf("fallback team %s not found", fallback), http.StatusNotFound}
+		}
+	}
+	return nil
+}
+
+// replaceFallbackTeams overwrites fallback teams of the team, order of fallbacks is their priority
+func replaceFallbackTeams(ctx context.Context, q querier, teamName string, fallbacks []string) error {
+	if _, err := q.Exec(ctx, `DELETE FROM team_fallbacks WHERE team_name=$1`, teamName); err != nil {
+		return err
+	}
+	for i, fallback := range fallbacks {
+		_, err := q.Exec(ctx, `
+			INSERT INTO team_fallbacks(team_name, fallback_team_name, priority)
+			VALUES($1, $2, $3)
+		`, teamName, fallback, i)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
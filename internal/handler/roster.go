@@ -0,0 +1,336 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/roster"
+)
+
+// RosterImport is the result of /roster/import
+type RosterImport struct {
+	DryRun  bool               `json:"dry_run"`
+	Changes []api.RosterChange `json:"changes"`
+}
+
+// rosterUser is a row of users table as the roster sees it
+type rosterUser struct {
+	username string
+	teamName string
+	isActive bool
+}
+
+func (u rosterUser) state() *api.RosterUserState {
+	return &api.RosterUserState{Username: u.username, TeamName: u.teamName, IsActive: u.isActive}
+}
+
+func (h *Handler) PostRosterImport(w http.ResponseWriter, r *http.Request, params api.PostRosterImportParams) {
+	format, ok := roster.FormatOf(r.Header.Get("Content-Type"))
+	if !ok {
+		writeError(w, api.INVALIDREQUEST, "roster must be JSON, YAML or CSV", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, api.INVALIDREQUEST, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	parsed, err := roster.Parse(format, data)
+	if err != nil {
+		writeError(w, api.INVALIDREQUEST, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dryRun := params.DryRun != nil && *params.DryRun
+
+	result, err := h.ImportRoster(r.Context(), parsed, dryRun, requestActor(r))
+	if err != nil {
+		writeAPIError(w, err, "failed to import roster")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// ImportRoster brings teams and users to the roster. Members of the listed teams who are not in the roster are
+// deactivated, other teams are left as is. With dryRun the changes are only returned.
+func (h *Handler) ImportRoster(ctx context.Context, r *roster.Roster, dryRun bool, actor *string) (*RosterImport, error) {
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Users are locked so that nobody changes them between the diff and applying it
+	lock := h.db.Dialect.ForUpdate()
+	if dryRun {
+		lock = ""
+	}
+
+	users := make(map[string]rosterUser)
+	rows, err := tx.Query(ctx, `SELECT user_id, username, team_name, is_active FROM users ORDER BY user_id`+lock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch users: %w", err)
+	}
+	for rows.Next() {
+		var (
+			userId string
+			u      rosterUser
+		)
+		if err := rows.Scan(&userId, &u.username, &u.teamName, &u.isActive); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users[userId] = u
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to fetch users: %w", err)
+	}
+
+	teams := make(map[string]bool)
+	rows, err = tx.Query(ctx, `SELECT team_name FROM teams`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch teams: %w", err)
+	}
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams[teamName] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to fetch teams: %w", err)
+	}
+
+	changes := diffRoster(r, users, teams)
+
+	if !dryRun {
+		if err := applyRoster(ctx, tx, changes, actor); err != nil {
+			return nil, err
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return &RosterImport{DryRun: dryRun, Changes: changes}, nil
+}
+
+// diffRoster returns the changes in the order they are applied: new teams, then members of every team in the
+// roster order, then deactivations by user_id
+func diffRoster(r *roster.Roster, users map[string]rosterUser, teams map[string]bool) []api.RosterChange {
+	changes := make([]api.RosterChange, 0)
+
+	for _, team := range r.Teams {
+		if !teams[team.TeamName] {
+			changes = append(changes, api.RosterChange{Action: api.CreateTeam, TeamName: team.TeamName})
+		}
+	}
+
+	listedTeams := make(map[string]bool)
+	listed := make(map[string]bool)
+	for _, team := range r.Teams {
+		listedTeams[team.TeamName] = true
+		for _, m := range team.Members {
+			listed[m.UserId] = true
+
+			after := rosterUser{username: m.Username, teamName: team.TeamName, isActive: m.Active()}
+			change := api.RosterChange{TeamName: team.TeamName, UserId: &m.UserId, After: after.state()}
+
+			before, ok := users[m.UserId]
+			switch {
+			case !ok:
+				change.Action = api.CreateUser
+			case before.teamName != after.teamName:
+				change.Action = api.MoveUser
+			case before != after:
+				change.Action = api.UpdateUser
+			default:
+				continue
+			}
+
+			if ok {
+				change.Before = before.state()
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	var deactivated []string
+	for userId, u := range users {
+		if listedTeams[u.teamName] && u.isActive && !listed[userId] {
+			deactivated = append(deactivated, userId)
+		}
+	}
+	sort.Strings(deactivated)
+
+	for _, userId := range deactivated {
+		before := users[userId]
+		after := before
+		after.isActive = false
+		changes = append(changes, api.RosterChange{
+			Action:   api.DeactivateUser,
+			TeamName: before.teamName,
+			UserId:   &userId,
+			Before:   before.state(),
+			After:    after.state(),
+		})
+	}
+
+	return changes
+}
+
+// applyRoster writes the changes, recording them in the audit log and the event stream like separate requests do
+func applyRoster(ctx context.Context, q querier, changes []api.RosterChange, actor *string) error {
+	var createdTeams []string
+
+	for _, c := range changes {
+		if c.Action == api.CreateTeam {
+			if _, err := q.Exec(ctx, `INSERT INTO teams(team_name) VALUES($1)`, c.TeamName); err != nil {
+				return fmt.Errorf("failed to insert team %s: %w", c.TeamName, err)
+			}
+			createdTeams = append(createdTeams, c.TeamName)
+			continue
+		}
+
+		userId := *c.UserId
+
+		// Created users have no previous state in the audit log
+		var before []byte
+		if c.Before != nil {
+			user, err := loadUser(ctx, q, userId)
+			if err != nil {
+				return fmt.Errorf("failed to fetch user %s: %w", userId, err)
+			}
+			before = snapshot(user)
+		}
+
+		_, err := q.Exec(ctx, `
+			INSERT INTO users(user_id, username, team_name, is_active)
+			VALUES($1, $2, $3, $4)
+			ON CONFLICT (user_id) DO UPDATE
+			SET username = EXCLUDED.username,
+			    team_name = EXCLUDED.team_name,
+			    is_active = EXCLUDED.is_active
+		`, userId, c.After.Username, c.After.TeamName, c.After.IsActive)
+		if err != nil {
+			return fmt.Errorf("failed to save user %s: %w", userId, err)
+		}
+
+		after, err := loadUser(ctx, q, userId)
+		if err != nil {
+			return fmt.Errorf("failed to fetch user %s: %w", userId, err)
+		}
+
+		err = recordAudit(ctx, q, auditRecord{
+			actor:      actor,
+			operation:  "roster.import",
+			targetType: targetUser,
+			targetId:   userId,
+			before:     before,
+			after:      snapshot(after),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write audit log: %w", err)
+		}
+
+		if c.Before != nil && c.Before.IsActive != c.After.IsActive {
+			if err := publishUserActivity(ctx, q, after, actor); err != nil {
+				return fmt.Errorf("failed to publish event: %w", err)
+			}
+		}
+	}
+
+	for _, teamName := range createdTeams {
+		created, err := loadTeam(ctx, q, teamName)
+		if err != nil {
+			return fmt.Errorf("failed to fetch team %s: %w", teamName, err)
+		}
+
+		err = recordAudit(ctx, q, auditRecord{
+			actor:      actor,
+			operation:  "roster.import",
+			targetType: targetTeam,
+			targetId:   teamName,
+			after:      snapshot(created),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write audit log: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) GetRosterExport(w http.ResponseWriter, r *http.Request, params api.GetRosterExportParams) {
+	format := roster.FormatJSON
+	if params.Format != nil {
+		format = string(*params.Format)
+	}
+
+	exported, err := h.ExportRoster(r.Context())
+	if err != nil {
+		writeAPIError(w, err, "failed to export roster")
+		return
+	}
+
+	w.Header().Set("Content-Type", roster.ContentType(format))
+	w.WriteHeader(http.StatusOK)
+	if err := roster.Encode(w, format, exported); err != nil {
+		fmt.Printf("failed to encode roster: %v\n", err)
+	}
+}
+
+// ExportRoster returns all teams with their members, sorted by name, in the format /roster/import accepts
+func (h *Handler) ExportRoster(ctx context.Context) (*roster.Roster, error) {
+	rows, err := h.db.Query(ctx, `
+		SELECT teams.team_name, users.user_id, users.username, users.is_active
+		FROM teams LEFT JOIN users ON users.team_name = teams.team_name
+		ORDER BY teams.team_name, users.user_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roster: %w", err)
+	}
+	defer rows.Close()
+
+	exported := &roster.Roster{Teams: make([]roster.Team, 0)}
+	for rows.Next() {
+		var (
+			teamName string
+			userId   *string
+			username *string
+			isActive *bool
+		)
+		if err := rows.Scan(&teamName, &userId, &username, &isActive); err != nil {
+			return nil, fmt.Errorf("failed to scan roster: %w", err)
+		}
+
+		if n := len(exported.Teams); n == 0 || exported.Teams[n-1].TeamName != teamName {
+			exported.Teams = append(exported.Teams, roster.Team{TeamName: teamName, Members: make([]roster.Member, 0)})
+		}
+
+		if userId == nil {
+			continue
+		}
+
+		team := &exported.Teams[len(exported.Teams)-1]
+		team.Members = append(team.Members, roster.Member{UserId: *userId, Username: *username, IsActive: isActive})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to fetch roster: %w", err)
+	}
+
+	return exported, nil
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/roster"
+)
+
+func TestDiffRoster(t *testing.T) {
+	users := map[string]rosterUser{
+		"u1": {username: "Alice", teamName: "backend", isActive: true},
+		"u2": {username: "Bob", teamName: "backend", isActive: true},
+		"u3": {username: "Carol", teamName: "backend", isActive: true},
+		"u4": {username: "Dave", teamName: "payments", isActive: true},
+		"u6": {username: "Frank", teamName: "backend", isActive: false},
+		"u9": {username: "Ivan", teamName: "infra", isActive: true},
+	}
+	teams := map[string]bool{"backend": true, "payments": true, "infra": true}
+
+	inactive := false
+	r := &roster.Roster{Teams: []roster.Team{
+		{TeamName: "backend", Members: []roster.Member{
+			{UserId: "u1", Username: "Alice"},
+			{UserId: "u2", Username: "Robert"},
+			{UserId: "u4", Username: "Dave"},
+		}},
+		{TeamName: "mobile", Members: []roster.Member{
+			{UserId: "u20", Username: "Walter", IsActive: &inactive},
+		}},
+		{TeamName: "payments", Members: []roster.Member{}},
+	}}
+
+	id := func(userId string) *string { return &userId }
+	state := func(username, teamName string, isActive bool) *api.RosterUserState {
+		return &api.RosterUserState{Username: username, TeamName: teamName, IsActive: isActive}
+	}
+
+	// Alice is unchanged, Frank is inactive already and infra is not in the roster
+	expected := []api.RosterChange{
+		{Action: api.CreateTeam, TeamName: "mobile"},
+		{Action: api.UpdateUser, TeamName: "backend", UserId: id("u2"), Before: state("Bob", "backend", true), After: state("Robert", "backend", true)},
+		{Action: api.MoveUser, TeamName: "backend", UserId: id("u4"), Before: state("Dave", "payments", true), After: state("Dave", "backend", true)},
+		{Action: api.CreateUser, TeamName: "mobile", UserId: id("u20"), After: state("Walter", "mobile", false)},
+		{Action: api.DeactivateUser, TeamName: "backend", UserId: id("u3"), Before: state("Carol", "backend", true), After: state("Carol", "backend", false)},
+	}
+
+	if changes := diffRoster(r, users, teams); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("unexpected changes:\n%s", describeChanges(changes))
+	}
+
+	if changes := diffRoster(&roster.Roster{Teams: []roster.Team{{TeamName: "infra", Members: []roster.Member{
+		{UserId: "u9", Username: "Ivan"},
+	}}}}, users, teams); len(changes) != 0 {
+		t.Errorf("expected no changes for an unchanged team:\n%s", describeChanges(changes))
+	}
+}
+
+func describeChanges(changes []api.RosterChange) string {
+	var s string
+	for _, c := range changes {
+		s += string(c.Action) + " " + c.TeamName
+		if c.UserId != nil {
+			s += " " + *c.UserId
+		}
+		if c.Before != nil {
+			s += " " + c.Before.Username + "/" + c.Before.TeamName
+		}
+		if c.After != nil {
+			s += " -> " + c.After.Username + "/" + c.After.TeamName
+		}
+		s += "\n"
+	}
+	return s
+}
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+// maxSimulatedPRs limits how many PRs one simulation may create
+const maxSimulatedPRs = 1000
+
+func (h *Handler) PostPullRequestSimulate(w http.ResponseWriter, r *http.Request) {
+	var body api.PostPullRequestSimulateJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	simulation, err := h.Simulate(r.Context(), body)
+	if err != nil {
+		writeAPIError(w, err, "failed to simulate assignment")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, simulation)
+}
+
+// Simulate creates hypothetical PRs of the author one after another with the same selection as CreatePullRequest
+// and reports who would review them. Every next PR sees the load of the previous ones. Nothing is written:
+// overrides and simulated PRs are kept in memory and applied to candidates read from a read-only snapshot,
+// so a dry run neither locks rows nor holds up writers.
+func (h *Handler) Simulate(ctx context.Context, req api.PostPullRequestSimulateJSONBody) (*api.Simulation, error) {
+	if req.AuthorId == "" {
+		return nil, &apiError{api.INVALIDREQUEST, "author_id is required", http.StatusBadRequest}
+	}
+
+	count := 1
+	if req.Count != nil {
+		count = *req.Count
+	}
+	if count < 1 || count > maxSimulatedPRs {
+		return nil, &apiError{api.INVALIDREQUEST, fmt.Sprintf("count must be between 1 and %d", maxSimulatedPRs), http.StatusBadRequest}
+	}
+
+	changedFiles := make([]string, 0)
+	if req.ChangedFiles != nil {
+		changedFiles = *req.ChangedFiles
+	}
+
+	tx, err := h.db.BeginSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var teamName string
+	err = tx.QueryRow(ctx, "SELECT team_name FROM users WHERE user_id=$1", req.AuthorId).Scan(&teamName)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, &apiError{api.NOTFOUND, "author not found", http.StatusNotFound}
+		}
+		return nil, err
+	}
+
+	// The copy selects reviewers with overridden options, the handler itself is shared by requests.
+	// It draws from its own source, so that dry runs don't change who a seeded handler picks for real PRs.
+	dry := *h
+	dry.rand = NewRandom(time.Now().UnixNano())
+	dry.sim = &simulation{teamName: teamName, assigned: make(map[string]int)}
+	if req.Overrides != nil {
+		if err := dry.applyOverrides(ctx, tx, req.Overrides); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &api.Simulation{
+		AuthorId:     req.AuthorId,
+		TeamName:     teamName,
+		Selection:    api.SimulationSelection(dry.opts.Selection),
+		PullRequests: make([]api.SimulatedPullRequest, 0, count),
+	}
+
+	for range count {
+		reviewers, saturated, err := dry.selectReviewers(ctx, tx, req.AuthorId, teamName, changedFiles, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// The next PRs see the reviewers busy and, under fair selection, paired with the author
+		assignedReviewers := make([]string, 0, len(reviewers))
+		for _, c := range reviewers {
+			assignedReviewers = append(assignedReviewers, c.userId)
+			dry.sim.assigned[c.userId]++
+		}
+
+		if len(reviewers) < reviewersPerPR {
+			result.Understaffed++
+		}
+		result.PullRequests = append(result.PullRequests, api.SimulatedPullRequest{
+			AssignedReviewers: assignedReviewers,
+			FallbackReviewers: fallbackReviewers(reviewers, teamName),
+			CapacityLimited:   len(reviewers) < reviewersPerPR && saturated > 0,
+		})
+	}
+
+	if result.Load, err = dry.simulatedLoad(ctx, tx, req.AuthorId, teamName); err != nil {
+		return nil, fmt.Errorf("failed to get reviewer load: %w", err)
+	}
+
+	return result, nil
+}
+
+// simulation is what a dry run changes on top of the stored data. Candidates are adjusted as they are read.
+type simulation struct {
+	teamName string // the author's team, which capacity and fallback overrides apply to
+	// defaultCapacity replaces the default capacity of the team for members without a limit of their own
+	defaultCapacity *int
+	ownLimit        map[string]struct{}
+	fallbacks       []string // nil if not overridden
+	inactive        map[string]struct{}
+	assigned        map[string]int // simulated PRs of every reviewer
+}
+
+// adjust applies the simulation to the candidate and tells whether they are still active
+func (s *simulation) adjust(c *candidate) bool {
+	if _, own := s.ownLimit[c.userId]; s.defaultCapacity != nil && c.teamName == s.teamName && !own {
+		c.capacity = s.defaultCapacity
+	}
+	c.openReviews += s.assigned[c.userId]
+
+	_, inactive := s.inactive[c.userId]
+	return !inactive
+}
+
+// applyOverrides changes settings for the simulation: options in the handler, stored settings in h.sim
+func (h *Handler) applyOverrides(ctx context.Context, q querier, o *api.SimulationOverrides) error {
+	if o.Selection != nil {
+		selection := Selection(*o.Selection)
+		if selection != SelectionRandom && selection != SelectionFair {
+			return &apiError{api.INVALIDREQUEST, "selection must be random or fair", http.StatusBadRequest}
+		}
+		h.opts.Selection = selection
+	}
+
+	if o.FairnessWindow != nil {
+		window, err := time.ParseDuration(*o.FairnessWindow)
+		if err != nil || window <= 0 {
+			return &apiError{api.INVALIDREQUEST, "fairness_window must be a positive duration", http.StatusBadRequest}
+		}
+		h.opts.FairnessWindow = window
+	}
+
+	if o.DefaultMaxOpenReviews != nil {
+		if *o.DefaultMaxOpenReviews < 0 {
+			return &apiError{api.INVALIDREQUEST, "default_max_open_reviews cannot be negative", http.StatusBadRequest}
+		}
+		rows, err := q.Query(ctx, `
+			SELECT user_id FROM users WHERE team_name=$1 AND max_open_reviews IS NOT NULL
+		`, h.sim.teamName)
+		if err != nil {
+			return err
+		}
+		h.sim.ownLimit = make(map[string]struct{})
+		for rows.Next() {
+			var userId string
+			if err := rows.Scan(&userId); err != nil {
+				rows.Close()
+				return err
+			}
+			h.sim.ownLimit[userId] = struct{}{}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		h.sim.defaultCapacity = o.DefaultMaxOpenReviews
+	}
+
+	if o.FallbackTeams != nil {
+		if err := validateFallbackTeams(ctx, q, h.sim.teamName, *o.FallbackTeams); err != nil {
+			return err
+		}
+		h.sim.fallbacks = *o.FallbackTeams
+	}
+
+	if o.InactiveUsers != nil {
+		h.sim.inactive = make(map[string]struct{}, len(*o.InactiveUsers))
+		for _, userId := range *o.InactiveUsers {
+			var exists bool
+			if err := q.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE user_id=$1)", userId).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return &apiError{api.NOTFOUND, fmt.Sprintf("user %s not found", userId), http.StatusNotFound}
+			}
+			h.sim.inactive[userId] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// simulatedLoad reports the load of members of the author's team and its fallback teams, and of everyone
+// else who got a simulated PR
+func (h *Handler) simulatedLoad(ctx context.Context, q querier, authorId, teamName string) ([]api.SimulatedLoad, error) {
+	fallbacks, err := h.fallbacksOf(ctx, q, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := append([]string{teamName}, fallbacks...)
+	args := make([]any, 0, len(teams)+len(h.sim.assigned))
+	for _, team := range teams {
+		args = append(args, team)
+	}
+	for userId := range h.sim.assigned {
+		args = append(args, userId)
+	}
+
+	cond := `users.team_name IN (` + placeholders(1, len(teams)) + `)`
+	if len(h.sim.assigned) > 0 {
+		cond += ` OR users.user_id IN (` + placeholders(len(teams)+1, len(h.sim.assigned)) + `)`
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT users.user_id, users.team_name,
+			COALESCE(users.max_open_reviews, teams.default_max_open_reviews),
+			`+openReviewCount+`
+		FROM users JOIN teams ON teams.team_name = users.team_name
+		WHERE `+cond, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	load := make([]api.SimulatedLoad, 0)
+	for rows.Next() {
+		var c candidate
+		if err := rows.Scan(&c.userId, &c.teamName, &c.capacity, &c.openReviews); err != nil {
+			return nil, err
+		}
+		if c.userId == authorId {
+			continue
+		}
+		before := c.openReviews
+		// Users made inactive are reported too, they keep their current reviews
+		h.sim.adjust(&c)
+		load = append(load, api.SimulatedLoad{
+			UserId:            c.userId,
+			TeamName:          c.teamName,
+			MaxOpenReviews:    c.capacity,
+			Assigned:          h.sim.assigned[c.userId],
+			OpenReviewsBefore: before,
+			OpenReviewsAfter:  c.openReviews,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(load, func(a, b api.SimulatedLoad) int {
+		return cmp.Or(
+			cmp.Compare(b.Assigned, a.Assigned),
+			cmp.Compare(b.OpenReviewsAfter, a.OpenReviewsAfter),
+			cmp.Compare(a.UserId, b.UserId),
+		)
+	})
+
+	return load, nil
+}
@@ -0,0 +1,564 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/events"
+)
+
+// Escalations of review SLA breaches. Notifying the lead means the event stream only, the notifiers
+// of package notify deliver digests and nothing else.
+const (
+	escalationEvent      = "event"       // publish review.sla_breached to the stream
+	escalationNotifyLead = "notify_lead" // the same event, addressed to the team lead too
+	escalationReassign   = "reassign"    // hand the review over to another candidate
+)
+
+// loadReviewSLA returns the review SLA of the team or nil if the team has none
+func loadReviewSLA(ctx context.Context, q querier, teamName string) (*api.ReviewSla, error) {
+	var (
+		seconds    int64
+		escalation string
+		sla        api.ReviewSla
+	)
+	err := q.QueryRow(ctx, `
+		SELECT sla_seconds, escalation, lead_user_id FROM review_slas WHERE team_name=$1
+	`, teamName).Scan(&seconds, &escalation, &sla.LeadUserId)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	sla.Sla = (time.Duration(seconds) * time.Second).String()
+	sla.Escalation = api.ReviewSlaEscalation(escalation)
+
+	return &sla, nil
+}
+
+func (h *Handler) PostTeamSetReviewSla(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body api.PostTeamSetReviewSlaJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if body.TeamName == "" {
+		writeError(w, api.INVALIDREQUEST, "team_name is required", http.StatusBadRequest)
+		return
+	}
+
+	var sla time.Duration
+	if body.ReviewSla != nil {
+		var err error
+		sla, err = time.ParseDuration(*body.ReviewSla)
+		// The SLA is stored in whole seconds
+		if err != nil || sla < time.Second {
+			writeError(w, api.INVALIDREQUEST, "review_sla must be a positive duration", http.StatusBadRequest)
+			return
+		}
+	}
+
+	escalation := escalationEvent
+	if body.Escalation != nil {
+		escalation = string(*body.Escalation)
+	}
+	if escalation == escalationNotifyLead && body.LeadUserId == nil {
+		writeError(w, api.INVALIDREQUEST, "notify_lead escalation needs lead_user_id", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := loadTeam(ctx, tx, body.TeamName)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch team")
+		return
+	}
+
+	if body.LeadUserId != nil {
+		var exists bool
+		err := tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE user_id=$1)", *body.LeadUserId).Scan(&exists)
+		if err != nil {
+			writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+			return
+		}
+		if !exists {
+			writeError(w, api.NOTFOUND, "lead not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	if body.ReviewSla == nil {
+		_, err = tx.Exec(ctx, `DELETE FROM review_slas WHERE team_name=$1`, body.TeamName)
+	} else {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO review_slas(team_name, sla_seconds, escalation, lead_user_id)
+			VALUES($1, $2, $3, $4)
+			ON CONFLICT (team_name) DO UPDATE
+			SET sla_seconds=excluded.sla_seconds, escalation=excluded.escalation, lead_user_id=excluded.lead_user_id
+		`, body.TeamName, int64(sla/time.Second), escalation, body.LeadUserId)
+	}
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update team", http.StatusInternalServerError)
+		return
+	}
+
+	team, err := loadTeam(ctx, tx, body.TeamName)
+	if err != nil {
+		writeAPIError(w, err, "failed to fetch team")
+		return
+	}
+
+	err = recordAudit(ctx, tx, auditRecord{
+		actor:      requestActor(r),
+		operation:  "team.setReviewSla",
+		targetType: targetTeam,
+		targetId:   body.TeamName,
+		before:     snapshot(before),
+		after:      snapshot(team),
+	})
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to write audit log", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to update team", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]*api.Team{"team": team})
+}
+
+func (h *Handler) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+	var body api.PostPullRequestReviewJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, api.INVALIDREQUEST, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	pr, err := h.ReviewPullRequest(r.Context(), body.PullRequestId, body.UserId, requestActor(r))
+	if err != nil {
+		writeAPIError(w, err, "failed to record review")
+		return
+	}
+
+	setETag(w, pr.Version)
+	writeJSON(w, http.StatusOK, map[string]api.PullRequest{"pr": pr.PullRequest})
+}
+
+// ReviewPullRequest records that the reviewer has reviewed the PR. Only history changes, so the version
+// of the PR stays the same.
+func (h *Handler) ReviewPullRequest(ctx context.Context, prId, userId string, actor *string) (*VersionedPullRequest, error) {
+	if prId == "" {
+		return nil, &apiError{api.INVALIDREQUEST, "pull_request_id is required", http.StatusBadRequest}
+	}
+
+	if userId == "" {
+		return nil, &apiError{api.INVALIDREQUEST, "user_id is required", http.StatusBadRequest}
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	pr, err := h.lockPullRequest(ctx, tx, prId)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.status != string(api.PullRequestStatusOPEN) {
+		return nil, &apiError{api.PRMERGED, "cannot review merged PR", http.StatusConflict}
+	}
+
+	if pr.reviewerIndex(userId) == -1 {
+		return nil, &apiError{api.NOTASSIGNED, "reviewer is not assigned to this PR", http.StatusConflict}
+	}
+
+	event := reviewerEvent(api.PullRequestEventTypeREVIEWED, h.now(), actor, userId)
+	if err := recordEvents(ctx, tx, pr, event); err != nil {
+		return nil, fmt.Errorf("failed to record PR history: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return pr.versioned(), nil
+}
+
+// slaAssignment is a reviewer of an open PR whose author's team has a review SLA
+type slaAssignment struct {
+	prId       string
+	userId     string
+	teamName   string
+	sla        time.Duration
+	escalation string
+	leadUserId *string
+	assignedAt time.Time
+	reviewed   bool
+}
+
+// EscalateReviewSLA finds reviewers who haven't reviewed an open PR within the SLA of the author's team
+// since they were assigned, and escalates each breach once, the way the team asked for
+func (h *Handler) EscalateReviewSLA(ctx context.Context) error {
+	now := h.now()
+
+	breached, err := h.findSLABreaches(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to find SLA breaches: %w", err)
+	}
+
+	for _, a := range breached {
+		if err := h.escalate(ctx, a, now); err != nil {
+			return fmt.Errorf("failed to escalate SLA breach of %s on PR %s: %w", a.userId, a.prId, err)
+		}
+	}
+
+	return nil
+}
+
+// findSLABreaches returns assignments which are past their SLA without a review and haven't been escalated yet
+func (h *Handler) findSLABreaches(ctx context.Context, now time.Time) ([]*slaAssignment, error) {
+	// PRs in scope are open ones of authors from teams with a review SLA
+	const scope = `
+		FROM prs
+		JOIN users authors ON authors.user_id = prs.author_id
+		JOIN review_slas ON review_slas.team_name = authors.team_name
+		WHERE prs.status = 'OPEN'`
+
+	rows, err := h.db.Query(ctx, `
+		SELECT scoped.pull_request_id, scoped.created_at, pr_reviewers.user_id,
+			scoped.team_name, scoped.sla_seconds, scoped.escalation, scoped.lead_user_id
+		FROM pr_reviewers
+		JOIN (
+			SELECT prs.pull_request_id, prs.created_at, review_slas.team_name, review_slas.sla_seconds,
+				review_slas.escalation, review_slas.lead_user_id
+			`+scope+`
+		) scoped ON scoped.pull_request_id = pr_reviewers.pull_request_id
+		ORDER BY scoped.pull_request_id, pr_reviewers.position
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make([]*slaAssignment, 0)
+	byKey := make(map[[2]string]*slaAssignment)
+	for rows.Next() {
+		var (
+			a       slaAssignment
+			seconds int64
+		)
+		// Reviewers of PRs older than the history get the creation time of the PR
+		err := rows.Scan(&a.prId, &a.assignedAt, &a.userId, &a.teamName, &seconds, &a.escalation, &a.leadUserId)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		a.sla = time.Duration(seconds) * time.Second
+		assignments = append(assignments, &a)
+		byKey[[2]string{a.prId, a.userId}] = &a
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// History tells when each reviewer got the PR last time and whether they reviewed it since
+	rows, err = h.db.Query(ctx, `
+		SELECT pr_events.pull_request_id, pr_events.type, pr_events.at, pr_events.user_id, pr_events.replaced_by
+		FROM pr_events
+		WHERE pr_events.type IN ('REVIEWER_ASSIGNED', 'REVIEWER_REPLACED', 'REVIEWED')
+			AND pr_events.pull_request_id IN (SELECT prs.pull_request_id `+scope+`)
+		ORDER BY pr_events.event_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var (
+			prId, eventType    string
+			at                 time.Time
+			userId, replacedBy *string
+		)
+		if err := rows.Scan(&prId, &eventType, &at, &userId, &replacedBy); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		switch api.PullRequestEventType(eventType) {
+		case api.PullRequestEventTypeREVIEWERASSIGNED:
+			if a := byKey[[2]string{prId, deref(userId)}]; a != nil {
+				a.assignedAt, a.reviewed = at, false
+			}
+		case api.PullRequestEventTypeREVIEWERREPLACED:
+			if a := byKey[[2]string{prId, deref(replacedBy)}]; a != nil {
+				a.assignedAt, a.reviewed = at, false
+			}
+		case api.PullRequestEventTypeREVIEWED:
+			if a := byKey[[2]string{prId, deref(userId)}]; a != nil {
+				a.reviewed = true
+			}
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	escalated, err := h.escalatedAssignments(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	breached := make([]*slaAssignment, 0)
+	for _, a := range assignments {
+		if a.reviewed || now.Before(a.assignedAt.Add(a.sla)) {
+			continue
+		}
+		if at, ok := escalated[[2]string{a.prId, a.userId}]; ok && at.Equal(a.assignedAt) {
+			continue
+		}
+		breached = append(breached, a)
+	}
+
+	return breached, nil
+}
+
+// escalatedAssignments returns the latest escalated assignment of every reviewer of PRs in scope
+func (h *Handler) escalatedAssignments(ctx context.Context, scope string) (map[[2]string]time.Time, error) {
+	rows, err := h.db.Query(ctx, `
+		SELECT pull_request_id, user_id, assigned_at
+		FROM sla_breaches
+		WHERE pull_request_id IN (SELECT prs.pull_request_id `+scope+`)
+		ORDER BY breach_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	escalated := make(map[[2]string]time.Time)
+	for rows.Next() {
+		var (
+			prId, userId string
+			assignedAt   time.Time
+		)
+		if err := rows.Scan(&prId, &userId, &assignedAt); err != nil {
+			return nil, err
+		}
+		escalated[[2]string{prId, userId}] = assignedAt
+	}
+
+	return escalated, rows.Err()
+}
+
+// escalate records the breach and acts on it in one transaction, so that a breach is never recorded without
+// its escalation. The breach is recorded first, so that a review is reassigned only once even if several
+// replicas run the job.
+func (h *Handler) escalate(ctx context.Context, a *slaAssignment, now time.Time) error {
+	breach := api.SlaBreach{
+		PullRequestId: a.prId,
+		UserId:        a.userId,
+		TeamName:      a.teamName,
+		AssignedAt:    a.assignedAt.UTC(),
+		DueAt:         a.assignedAt.Add(a.sla).UTC(),
+		DetectedAt:    now,
+		Escalation:    api.SlaBreachEscalation(a.escalation),
+	}
+	if a.escalation == escalationNotifyLead {
+		breach.LeadUserId = a.leadUserId
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO sla_breaches(pull_request_id, user_id, team_name, assigned_at, due_at, detected_at, escalation, lead_user_id)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (pull_request_id, user_id, assigned_at) DO NOTHING
+		RETURNING breach_id
+	`, breach.PullRequestId, breach.UserId, breach.TeamName, a.assignedAt, breach.DueAt, breach.DetectedAt,
+		a.escalation, breach.LeadUserId).Scan(&breach.BreachId)
+	if errors.Is(err, db.ErrNoRows) {
+		// Another replica got there first
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var stays *apiError
+	if a.escalation == escalationReassign {
+		actor := systemActor
+		_, newReviewer, err := h.reassignReviewerTx(ctx, tx, a.prId, a.userId, "", nil, &actor, nil)
+		switch {
+		case errors.As(err, &stays):
+			// The PR could be merged in the meantime or there is nobody to take it, the event still tells about it
+		case err != nil:
+			return err
+		default:
+			breach.ReplacedBy = &newReviewer
+			_, err := tx.Exec(ctx, `UPDATE sla_breaches SET replaced_by=$1 WHERE breach_id=$2`, newReviewer, breach.BreachId)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := publishSLABreach(ctx, tx, &breach); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	switch {
+	case stays != nil:
+		log.Printf("review SLA: PR %s stays with %s: %s", a.prId, a.userId, stays.msg)
+	case breach.ReplacedBy != nil:
+		log.Printf("review SLA: PR %s reassigned from %s to %s", a.prId, a.userId, *breach.ReplacedBy)
+	}
+
+	return nil
+}
+
+// publishSLABreach publishes the breach to the stream. It concerns the author, the reviewer, the new reviewer
+// and the lead who is notified, together with their teams.
+func publishSLABreach(ctx context.Context, q querier, breach *api.SlaBreach) error {
+	pr, err := loadPullRequest(ctx, q, breach.PullRequestId)
+	if err != nil {
+		return err
+	}
+
+	userIds := []string{pr.authorId, breach.UserId}
+	if breach.ReplacedBy != nil {
+		userIds = append(userIds, *breach.ReplacedBy)
+	}
+	if breach.LeadUserId != nil {
+		userIds = append(userIds, *breach.LeadUserId)
+	}
+
+	teamNames, err := teamsOf(ctx, q, userIds)
+	if err != nil {
+		return err
+	}
+
+	actor := systemActor
+	prAPI := pr.toAPI()
+	data := streamEvent{
+		Type:       events.SLABreached,
+		At:         breach.DetectedAt,
+		Actor:      &actor,
+		Pr:         &prAPI,
+		UserId:     &breach.UserId,
+		ReplacedBy: breach.ReplacedBy,
+		Breach:     breach,
+	}
+	return events.Publish(ctx, q, events.Event{
+		Type:      data.Type,
+		At:        data.At,
+		UserIds:   userIds,
+		TeamNames: teamNames,
+		Data:      snapshot(data),
+	})
+}
+
+func (h *Handler) GetStatsSlaBreaches(w http.ResponseWriter, r *http.Request, params api.GetStatsSlaBreachesParams) {
+	ctx := r.Context()
+
+	limit, err := pageLimit(params.Limit)
+	if err != nil {
+		writeAPIError(w, err, "invalid limit")
+		return
+	}
+
+	var cond conditions
+
+	if params.TeamName != nil {
+		var exists bool
+		err := h.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name=$1)", *params.TeamName).Scan(&exists)
+		if err != nil {
+			writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+			return
+		}
+		if !exists {
+			writeError(w, api.NOTFOUND, "team not found", http.StatusNotFound)
+			return
+		}
+		cond.add("team_name = ?", *params.TeamName)
+	}
+	if params.UserId != nil {
+		cond.add("user_id = ?", *params.UserId)
+	}
+	if params.Since != nil {
+		cond.add("detected_at >= ?", *params.Since)
+	}
+
+	rows, err := h.db.Query(ctx, `
+		SELECT breach_id, pull_request_id, user_id, team_name, assigned_at, due_at, detected_at,
+			escalation, lead_user_id, replaced_by
+		FROM sla_breaches`+cond.where()+fmt.Sprintf(` ORDER BY detected_at DESC, breach_id DESC LIMIT %d`, limit),
+		cond.args...)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch SLA breaches", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	breaches := make([]api.SlaBreach, 0)
+	for rows.Next() {
+		var (
+			b          api.SlaBreach
+			escalation string
+		)
+		err := rows.Scan(&b.BreachId, &b.PullRequestId, &b.UserId, &b.TeamName, &b.AssignedAt, &b.DueAt, &b.DetectedAt,
+			&escalation, &b.LeadUserId, &b.ReplacedBy)
+		if err != nil {
+			writeError(w, api.INTERNALERROR, "failed to scan SLA breaches", http.StatusInternalServerError)
+			return
+		}
+		b.Escalation = api.SlaBreachEscalation(escalation)
+		b.AssignedAt, b.DueAt, b.DetectedAt = b.AssignedAt.UTC(), b.DueAt.UTC(), b.DetectedAt.UTC()
+		breaches = append(breaches, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch SLA breaches", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]api.SlaBreach{"breaches": breaches})
+}
+
+// deref returns the string or an empty one for nil
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
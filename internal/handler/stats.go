@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"cmp"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+)
+
+func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request, params api.GetStatsParams) {
+	ctx := r.Context()
+
+	teamName := ""
+	if params.TeamName != nil {
+		teamName = *params.TeamName
+	}
+
+	if teamName != "" {
+		var exists bool
+		err := h.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name=$1)", teamName).Scan(&exists)
+		if err != nil {
+			writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+			return
+		}
+
+		if !exists {
+			writeError(w, api.NOTFOUND, "team not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	var open, merged int
+	err := h.db.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE status = 'OPEN'), COUNT(*) FILTER (WHERE status = 'MERGED')
+		FROM prs
+		WHERE $1 = '' OR author_id IN (SELECT user_id FROM users WHERE team_name = $1)
+	`, teamName).Scan(&open, &merged)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to count pull requests", http.StatusInternalServerError)
+		return
+	}
+
+	// Users without reviews are listed too, they show how uneven the load is
+	rows, err := h.db.Query(ctx, `
+		SELECT users.user_id, users.username, users.team_name, users.is_active,
+			COUNT(prs.pull_request_id) FILTER (WHERE prs.status = 'OPEN') AS open_reviews,
+			COUNT(prs.pull_request_id) AS total_reviews
+		FROM users
+			LEFT JOIN pr_reviewers ON pr_reviewers.user_id = users.user_id
+			LEFT JOIN prs ON prs.pull_request_id = pr_reviewers.pull_request_id
+		WHERE $1 = '' OR users.team_name = $1
+		GROUP BY users.user_id
+		ORDER BY open_reviews DESC, total_reviews DESC, users.user_id
+	`, teamName)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch reviewer stats", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	reviewers := make([]api.ReviewerStats, 0)
+	for rows.Next() {
+		var s api.ReviewerStats
+		if err := rows.Scan(&s.UserId, &s.Username, &s.TeamName, &s.IsActive, &s.OpenReviews, &s.TotalReviews); err != nil {
+			writeError(w, api.INTERNALERROR, "failed to scan reviewer stats", http.StatusInternalServerError)
+			return
+		}
+		reviewers = append(reviewers, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch reviewer stats", http.StatusInternalServerError)
+		return
+	}
+
+	resp := map[string]interface{}{
+		"pull_requests": map[string]int{"open": open, "merged": merged},
+		"reviewers":     reviewers,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetStatsPairings shows who reviews whom in the team. Each member is a row of the matrix, reviewers of
+// their PRs are its columns, with the same weights fair selection uses.
+func (h *Handler) GetStatsPairings(w http.ResponseWriter, r *http.Request, params api.GetStatsPairingsParams) {
+	ctx := r.Context()
+
+	var exists bool
+	err := h.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name=$1)", params.TeamName).Scan(&exists)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "database error", http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		writeError(w, api.NOTFOUND, "team not found", http.StatusNotFound)
+		return
+	}
+
+	rows, err := h.db.Query(ctx, `SELECT user_id FROM users WHERE team_name=$1 ORDER BY user_id`, params.TeamName)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch team members", http.StatusInternalServerError)
+		return
+	}
+
+	authors := make([]api.AuthorPairings, 0)
+	for rows.Next() {
+		a := api.AuthorPairings{Reviewers: make([]api.Pairing, 0)}
+		if err := rows.Scan(&a.AuthorId); err != nil {
+			rows.Close()
+			writeError(w, api.INTERNALERROR, "failed to scan team members", http.StatusInternalServerError)
+			return
+		}
+		authors = append(authors, a)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch team members", http.StatusInternalServerError)
+		return
+	}
+
+	now := h.now()
+	window := h.opts.FairnessWindow
+
+	rows, err = h.db.Query(ctx, `
+		SELECT prs.author_id, pr_reviewers.user_id, prs.created_at
+		FROM prs
+			JOIN pr_reviewers ON pr_reviewers.pull_request_id = prs.pull_request_id
+			JOIN users ON users.user_id = prs.author_id
+		WHERE users.team_name = $1
+	`, params.TeamName)
+	if err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch reviews", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	pairings := make(map[string]map[string]*api.Pairing)
+	for rows.Next() {
+		var (
+			authorId, reviewerId string
+			createdAt            time.Time
+		)
+		if err := rows.Scan(&authorId, &reviewerId, &createdAt); err != nil {
+			writeError(w, api.INTERNALERROR, "failed to scan reviews", http.StatusInternalServerError)
+			return
+		}
+
+		if pairings[authorId] == nil {
+			pairings[authorId] = make(map[string]*api.Pairing)
+		}
+		p := pairings[authorId][reviewerId]
+		if p == nil {
+			p = &api.Pairing{ReviewerId: reviewerId}
+			pairings[authorId][reviewerId] = p
+		}
+		p.Reviews++
+		p.Weight += pairingWeight(now.Sub(createdAt), window)
+		if createdAt.After(p.LastReviewedAt) {
+			p.LastReviewedAt = createdAt.UTC()
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		writeError(w, api.INTERNALERROR, "failed to fetch reviews", http.StatusInternalServerError)
+		return
+	}
+
+	for i := range authors {
+		for _, p := range pairings[authors[i].AuthorId] {
+			p.Weight = math.Round(p.Weight*1000) / 1000
+			authors[i].Reviewers = append(authors[i].Reviewers, *p)
+		}
+		slices.SortFunc(authors[i].Reviewers, func(a, b api.Pairing) int {
+			return cmp.Or(cmp.Compare(b.Weight, a.Weight), cmp.Compare(b.Reviews, a.Reviews), cmp.Compare(a.ReviewerId, b.ReviewerId))
+		})
+	}
+
+	resp := map[string]interface{}{
+		"team_name":    params.TeamName,
+		"window_start": now.Add(-window),
+		"authors":      authors,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/events"
+)
+
+// keepAliveInterval is how often a comment is sent to idle streams, so proxies don't close them
+const keepAliveInterval = 15 * time.Second
+
+// streamEvent is data of a stream event, StreamEvent schema of the spec
+type streamEvent struct {
+	Type       string           `json:"type"`
+	At         time.Time        `json:"at"`
+	Actor      *string          `json:"actor"`
+	Pr         *api.PullRequest `json:"pr,omitempty"`
+	UserId     *string          `json:"user_id,omitempty"`
+	ReplacedBy *string          `json:"replaced_by,omitempty"`
+	User       *api.User        `json:"user,omitempty"`
+	Breach     *api.SlaBreach   `json:"breach,omitempty"`
+}
+
+// streamTypes maps types of PR history events to types of stream events
+var streamTypes = map[api.PullRequestEventType]string{
+	api.PullRequestEventTypeCREATED:          events.PRCreated,
+	api.PullRequestEventTypeREVIEWERASSIGNED: events.ReviewerAssigned,
+	api.PullRequestEventTypeREVIEWERREMOVED:  events.ReviewerRemoved,
+	api.PullRequestEventTypeREVIEWERREPLACED: events.ReviewerReplaced,
+	api.PullRequestEventTypeREVIEWED:         events.PRReviewed,
+	api.PullRequestEventTypeMERGED:           events.PRMerged,
+}
+
+// publishPREvents publishes PR history events to the stream. They concern the author, current reviewers
+// and the reviewers the events are about, together with their teams.
+func publishPREvents(ctx context.Context, q querier, pr *pullRequest, changes []prEvent) error {
+	userIds := append([]string{pr.authorId}, pr.reviewers...)
+	for _, e := range changes {
+		if e.userId != nil {
+			userIds = append(userIds, *e.userId)
+		}
+		if e.replacedBy != nil {
+			userIds = append(userIds, *e.replacedBy)
+		}
+	}
+
+	teamNames, err := teamsOf(ctx, q, userIds)
+	if err != nil {
+		return err
+	}
+
+	prAPI := pr.toAPI()
+	for _, e := range changes {
+		data := streamEvent{
+			Type:       streamTypes[e.eventType],
+			At:         e.at,
+			Actor:      e.actor,
+			Pr:         &prAPI,
+			UserId:     e.userId,
+			ReplacedBy: e.replacedBy,
+		}
+		err := events.Publish(ctx, q, events.Event{
+			Type:      data.Type,
+			At:        e.at,
+			UserIds:   userIds,
+			TeamNames: teamNames,
+			Data:      snapshot(data),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publishUserActivity publishes a change of user's activity to the stream
+func publishUserActivity(ctx context.Context, q querier, user *api.User, actor *string) error {
+	data := streamEvent{
+		Type:  events.UserActivity,
+		At:    time.Now().UTC(),
+		Actor: actor,
+		User:  user,
+	}
+	return events.Publish(ctx, q, events.Event{
+		Type:      data.Type,
+		At:        data.At,
+		UserIds:   []string{user.UserId},
+		TeamNames: []string{user.TeamName},
+		Data:      snapshot(data),
+	})
+}
+
+// teamsOf returns the distinct teams of the users
+func teamsOf(ctx context.Context, q querier, userIds []string) ([]string, error) {
+	args := make([]any, 0, len(userIds))
+	for _, uid := range userIds {
+		args = append(args, uid)
+	}
+
+	rows, err := q.Query(ctx, `SELECT DISTINCT team_name FROM users WHERE user_id IN (`+placeholders(1, len(args))+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := make([]string, 0)
+	for rows.Next() {
+		var team string
+		if err := rows.Scan(&team); err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, rows.Err()
+}
+
+func (h *Handler) GetEventsStream(w http.ResponseWriter, r *http.Request, params api.GetEventsStreamParams) {
+	ctx := r.Context()
+
+	var filter events.Filter
+	if params.UserId != nil {
+		filter.UserId = *params.UserId
+	}
+	if params.TeamName != nil {
+		filter.TeamName = *params.TeamName
+	}
+
+	var lastId int64
+	if params.LastEventID != nil {
+		id, err := strconv.ParseInt(*params.LastEventID, 10, 64)
+		if err != nil || id < 0 {
+			writeError(w, api.INVALIDREQUEST, "Last-Event-ID must be an event id", http.StatusBadRequest)
+			return
+		}
+		lastId = id
+	}
+
+	// Subscribe before replaying, so events committed in between are not lost; duplicates are skipped by id
+	sub := h.events.Subscribe(filter)
+	defer h.events.Unsubscribe(sub)
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if params.LastEventID != nil {
+		for {
+			missed, err := h.events.Since(ctx, lastId, filter)
+			if err != nil {
+				log.Printf("events: failed to replay events after %d: %v", lastId, err)
+				return
+			}
+			for _, e := range missed {
+				if err := writeStreamEvent(w, e); err != nil {
+					return
+				}
+				lastId = e.Id
+			}
+			if len(missed) < events.ReplayPageSize {
+				break
+			}
+		}
+	}
+
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.Events:
+			if !ok {
+				// Either the client lags too much behind or the service is shutting down, it will resume
+				return
+			}
+			// Ids follow the order of commits, an event with a lower id was replayed already
+			if e.Id <= lastId {
+				continue
+			}
+			if err := writeStreamEvent(w, e); err != nil {
+				return
+			}
+			lastId = e.Id
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeStreamEvent(w http.ResponseWriter, e *events.Event) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Id, e.Type, e.Data)
+	return err
+}
@@ -0,0 +1,293 @@
+// Package idempotency lets clients retry mutating requests safely. A request with Idempotency-Key header
+// is executed once, retries with the same key and body get the stored response instead.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db"
+)
+
+const (
+	// Header is the request header with the key chosen by the client
+	Header = "Idempotency-Key"
+	// ReplayedHeader is set on responses which are replayed from the store
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+
+	// lease is how long a request holds its key without renewing it. A request in progress renews the lease,
+	// so a key stays taken after its request was lost with a crashed replica only until the lease runs out.
+	lease = 30 * time.Second
+)
+
+// replayedHeaders are the response headers stored together with the body and sent again on replays
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+type Store struct {
+	db  *db.DB
+	ttl time.Duration
+}
+
+// New returns a store which keeps keys and responses for ttl
+func New(db *db.DB, ttl time.Duration) *Store {
+	return &Store{db: db, ttl: ttl}
+}
+
+// storedRequest is a row of idempotency_keys table. Status is nil while the first request is in progress.
+type storedRequest struct {
+	requestHash string
+	status      *int
+	body        []byte
+	headers     []byte
+}
+
+// Middleware applies to POST requests with Idempotency-Key header, the rest are passed through as is
+func (s *Store) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(Header)
+		if key == "" || r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > maxKeyLength {
+			writeError(w, api.INVALIDREQUEST, fmt.Sprintf("%s must not be longer than %d characters", Header, maxKeyLength), http.StatusBadRequest)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, api.INVALIDREQUEST, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := requestHash(r, body)
+
+		// The response is stored even if the client goes away in the meantime
+		ctx := context.WithoutCancel(r.Context())
+
+		claimed, stored, err := s.claim(ctx, key, hash)
+		if err != nil {
+			log.Printf("idempotency: failed to claim key: %v", err)
+			writeError(w, api.INTERNALERROR, "failed to check idempotency key", http.StatusInternalServerError)
+			return
+		}
+
+		if !claimed {
+			switch {
+			case stored.requestHash != hash:
+				writeError(w, api.IDEMPOTENCYKEYREUSED, "idempotency key was used with a different request", http.StatusUnprocessableEntity)
+			case stored.status == nil:
+				writeError(w, api.IDEMPOTENCYKEYINUSE, "request with this idempotency key is still in progress", http.StatusConflict)
+			default:
+				replay(w, stored)
+			}
+			return
+		}
+
+		stopRenewing := s.renew(ctx, key, hash)
+		defer stopRenewing()
+
+		// A panicking handler leaves no response to store, retries must run the request again
+		defer func() {
+			if v := recover(); v != nil {
+				stopRenewing()
+				s.release(ctx, key, hash)
+				panic(v)
+			}
+		}()
+
+		rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		stopRenewing()
+
+		// Server errors are not final, the client should be able to retry them for real
+		if rec.status >= http.StatusInternalServerError {
+			s.release(ctx, key, hash)
+			return
+		}
+
+		headers := make(http.Header)
+		for _, name := range replayedHeaders {
+			for _, v := range w.Header().Values(name) {
+				headers.Add(name, v)
+			}
+		}
+		storedHeaders, err := json.Marshal(headers)
+		if err != nil {
+			log.Printf("idempotency: failed to encode headers: %v", err)
+			return
+		}
+
+		_, err = s.db.Exec(ctx, `
+			UPDATE idempotency_keys SET status=$1, body=$2, headers=$3, locked_until=NULL
+			WHERE key=$4 AND request_hash=$5
+		`, rec.status, rec.body.Bytes(), string(storedHeaders), key, hash)
+		if err != nil {
+			log.Printf("idempotency: failed to store response: %v", err)
+		}
+	})
+}
+
+// replay writes the stored response of the first request
+func replay(w http.ResponseWriter, stored *storedRequest) {
+	var headers http.Header
+	if stored.headers != nil {
+		if err := json.Unmarshal(stored.headers, &headers); err != nil {
+			log.Printf("idempotency: failed to decode stored headers: %v", err)
+		}
+	}
+	// Responses stored before headers were kept are all JSON
+	if headers.Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	for name, values := range headers {
+		for _, v := range values {
+			w.Header().Add(name, v)
+		}
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(*stored.status)
+	w.Write(stored.body)
+}
+
+// renew extends the lease of the key while the request is in progress, until the returned function is called
+func (s *Store) renew(ctx context.Context, key, hash string) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(lease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			_, err := s.db.Exec(ctx, `
+				UPDATE idempotency_keys SET locked_until=$1 WHERE key=$2 AND request_hash=$3 AND status IS NULL
+			`, time.Now().Add(lease), key, hash)
+			if err != nil {
+				log.Printf("idempotency: failed to renew lease: %v", err)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+// release deletes the key of an unfinished request, so that its retry runs again
+func (s *Store) release(ctx context.Context, key, hash string) {
+	_, err := s.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE key=$1 AND request_hash=$2 AND status IS NULL`, key, hash)
+	if err != nil {
+		log.Printf("idempotency: failed to release key: %v", err)
+	}
+}
+
+// claim reserves the key for the request. A key is taken over once it expires, or once the lease of its
+// unfinished request runs out. Otherwise the stored request is returned.
+func (s *Store) claim(ctx context.Context, key, hash string) (bool, *storedRequest, error) {
+	var claimedKey string
+	now := time.Now()
+	err := s.db.QueryRow(ctx, `
+		INSERT INTO idempotency_keys(key, request_hash, expires_at, locked_until)
+		VALUES($1, $2, $3, $4)
+		ON CONFLICT (key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+		    status = NULL,
+		    body = NULL,
+		    headers = NULL,
+		    expires_at = EXCLUDED.expires_at,
+		    locked_until = EXCLUDED.locked_until
+		WHERE idempotency_keys.expires_at <= NOW()
+		   OR idempotency_keys.status IS NULL AND COALESCE(idempotency_keys.locked_until <= NOW(), TRUE)
+		RETURNING key
+	`, key, hash, now.Add(s.ttl), now.Add(lease)).Scan(&claimedKey)
+	if err == nil {
+		return true, nil, nil
+	}
+	if !errors.Is(err, db.ErrNoRows) {
+		return false, nil, err
+	}
+
+	stored := &storedRequest{}
+	err = s.db.QueryRow(ctx, `SELECT request_hash, status, body, headers FROM idempotency_keys WHERE key=$1`, key).
+		Scan(&stored.requestHash, &stored.status, &stored.body, &stored.headers)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return false, stored, nil
+}
+
+// Prune deletes expired keys together with their responses
+func (s *Store) Prune(ctx context.Context) error {
+	if _, err := s.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`); err != nil {
+		return fmt.Errorf("failed to prune idempotency keys: %w", err)
+	}
+	return nil
+}
+
+// requestHash identifies the request a key was used with. The query and If-Match change what the request does
+// (explain=true, a precondition on the version), so they are part of it like the body.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n"))
+	h.Write([]byte("If-Match: " + r.Header.Get("If-Match") + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recorder passes the response through and keeps a copy to store it
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// writeError writes ErrorResponse; handler package does the same, but idempotency must not depend on it
+func writeError(w http.ResponseWriter, code api.ErrorResponseErrorCode, msg string, status int) {
+	resp := api.ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = msg
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		fmt.Printf("failed to encode json: %v\n", err)
+	}
+}
@@ -0,0 +1,189 @@
+package idempotency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/db/dbtest"
+)
+
+// post sends a request with the key through the middleware
+func post(s *Store, next http.Handler, key, body string) *httptest.ResponseRecorder {
+	return postTo(s, next, key, "/pullRequest/create", "", body)
+}
+
+// postTo sends a request with the key to the target, If-Match is set unless it is empty
+func postTo(s *Store, next http.Handler, key, target, ifMatch, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	r.Header.Set(Header, key)
+	if ifMatch != "" {
+		r.Header.Set("If-Match", ifMatch)
+	}
+	w := httptest.NewRecorder()
+	s.Middleware(next).ServeHTTP(w, r)
+	return w
+}
+
+// created responds like a handler creating something, counting its calls
+func created(calls *atomic.Int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"1"`)
+		w.Header().Set("X-Request-Count", strings.Repeat("i", int(n)))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"ok":true}`))
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, open dbtest.Open) {
+		t.Run("replays the response with its headers", func(t *testing.T) {
+			s := New(open(t), time.Hour)
+			var calls atomic.Int64
+
+			first := post(s, created(&calls), "key", `{"a":1}`)
+			retry := post(s, created(&calls), "key", `{"a":1}`)
+
+			if calls.Load() != 1 {
+				t.Fatalf("expected the handler to run once, it ran %d times", calls.Load())
+			}
+			if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+				t.Errorf("expected the stored response, got %d %s", retry.Code, retry.Body)
+			}
+			if got := retry.Header().Get("ETag"); got != `"1"` {
+				t.Errorf("expected ETag to be replayed, got %q", got)
+			}
+			if got := retry.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("expected Content-Type to be replayed, got %q", got)
+			}
+			if retry.Header().Get(ReplayedHeader) != "true" || first.Header().Get(ReplayedHeader) != "" {
+				t.Errorf("expected only the retry to be marked replayed")
+			}
+			if got := retry.Header().Get("X-Request-Count"); got != "" {
+				t.Errorf("expected headers outside of the replayed ones to be dropped, got %q", got)
+			}
+		})
+
+		t.Run("rejects a different request with the same key", func(t *testing.T) {
+			s := New(open(t), time.Hour)
+			var calls atomic.Int64
+
+			post(s, created(&calls), "key", `{"a":1}`)
+			if w := post(s, created(&calls), "key", `{"a":2}`); w.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status 422, got %d", w.Code)
+			}
+		})
+
+		t.Run("rejects the same body with a different query", func(t *testing.T) {
+			s := New(open(t), time.Hour)
+			var calls atomic.Int64
+
+			postTo(s, created(&calls), "key", "/pullRequest/create?explain=true", "", `{"a":1}`)
+			if w := postTo(s, created(&calls), "key", "/pullRequest/create?explain=true", "", `{"a":1}`); w.Code != http.StatusCreated || calls.Load() != 1 {
+				t.Errorf("expected the same query to be replayed, got %d after %d calls", w.Code, calls.Load())
+			}
+			if w := postTo(s, created(&calls), "key", "/pullRequest/create", "", `{"a":1}`); w.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status 422 without the query, got %d", w.Code)
+			}
+		})
+
+		t.Run("rejects the same body with a different If-Match", func(t *testing.T) {
+			s := New(open(t), time.Hour)
+			var calls atomic.Int64
+
+			postTo(s, created(&calls), "key", "/pullRequest/merge", `"1"`, `{"a":1}`)
+			if w := postTo(s, created(&calls), "key", "/pullRequest/merge", `"1"`, `{"a":1}`); w.Code != http.StatusCreated || calls.Load() != 1 {
+				t.Errorf("expected the same If-Match to be replayed, got %d after %d calls", w.Code, calls.Load())
+			}
+			for _, ifMatch := range []string{`"2"`, ""} {
+				if w := postTo(s, created(&calls), "key", "/pullRequest/merge", ifMatch, `{"a":1}`); w.Code != http.StatusUnprocessableEntity {
+					t.Errorf("expected status 422 with If-Match %q, got %d", ifMatch, w.Code)
+				}
+			}
+		})
+
+		t.Run("rejects a retry while the request is in progress", func(t *testing.T) {
+			s := New(open(t), time.Hour)
+			var calls atomic.Int64
+
+			started, finish := make(chan struct{}), make(chan struct{})
+			slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				<-finish
+				created(&calls).ServeHTTP(w, r)
+			})
+			done := make(chan *httptest.ResponseRecorder)
+			go func() { done <- post(s, slow, "key", `{}`) }()
+			<-started
+
+			if w := post(s, created(&calls), "key", `{}`); w.Code != http.StatusConflict {
+				t.Errorf("expected status 409, got %d", w.Code)
+			}
+			close(finish)
+			if w := <-done; w.Code != http.StatusCreated {
+				t.Errorf("expected the first request to complete, got %d", w.Code)
+			}
+		})
+
+		t.Run("releases the key of a panicking request", func(t *testing.T) {
+			s := New(open(t), time.Hour)
+			var calls atomic.Int64
+
+			panicking := http.HandlerFunc(func(http.ResponseWriter, *http.Request) { panic("boom") })
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Error("expected the panic to be passed on")
+					}
+				}()
+				post(s, panicking, "key", `{}`)
+			}()
+
+			if w := post(s, created(&calls), "key", `{}`); w.Code != http.StatusCreated || calls.Load() != 1 {
+				t.Errorf("expected the retry to run, got %d after %d calls", w.Code, calls.Load())
+			}
+		})
+
+		t.Run("releases the key after a server error", func(t *testing.T) {
+			s := New(open(t), time.Hour)
+			var calls atomic.Int64
+
+			failing := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+			post(s, failing, "key", `{}`)
+
+			if w := post(s, created(&calls), "key", `{}`); w.Code != http.StatusCreated || calls.Load() != 1 {
+				t.Errorf("expected the retry to run, got %d after %d calls", w.Code, calls.Load())
+			}
+		})
+
+		t.Run("takes over a key once the lease of its request runs out", func(t *testing.T) {
+			store := open(t)
+			s := New(store, time.Hour)
+			var calls atomic.Int64
+
+			// A replica took the keys for the same request and crashed, one lease ran out already
+			hash := requestHash(httptest.NewRequest(http.MethodPost, "/pullRequest/create", nil), []byte(`{}`))
+			_, err := store.Exec(t.Context(), `
+				INSERT INTO idempotency_keys(key, request_hash, expires_at, locked_until) VALUES
+					('lost', $1, $2, $3), ('held', $1, $2, $4)
+			`, hash, time.Now().Add(time.Hour), time.Now().Add(-time.Second), time.Now().Add(lease))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if w := post(s, created(&calls), "held", `{}`); w.Code != http.StatusConflict {
+				t.Errorf("expected status 409 while the lease lasts, got %d", w.Code)
+			}
+			if w := post(s, created(&calls), "lost", `{}`); w.Code != http.StatusCreated || calls.Load() != 1 {
+				t.Errorf("expected the request to take over the key, got %d after %d calls", w.Code, calls.Load())
+			}
+		})
+	})
+}
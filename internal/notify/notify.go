@@ -0,0 +1,62 @@
+// Package notify delivers daily digests of pending reviews. A Notifier is one delivery channel: a generic
+// JSON webhook, a Slack-compatible incoming webhook or SMTP email. The service sends every digest through all
+// configured notifiers.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Review is an open PR waiting for the reviewer
+type Review struct {
+	PullRequestId   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorId        string    `json:"author_id"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Digest is the list of open reviews of one reviewer on one day of their time zone
+type Digest struct {
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	// Email is where SMTP sends the digest, empty if the user has no address
+	Email string `json:"email,omitempty"`
+	// Date is the day of the digest in the user's time zone, e.g. 2025-11-03
+	Date    string   `json:"date"`
+	Reviews []Review `json:"reviews"`
+}
+
+// ErrSkipped is returned by notifiers which have nowhere to deliver the digest, like SMTP for a user without
+// an email address. The digest isn't delivered, but there is no point in retrying it either.
+var ErrSkipped = errors.New("digest skipped")
+
+type Notifier interface {
+	// Name identifies the notifier in logs
+	Name() string
+	// Send delivers the digest. An error means the digest may not have reached the user.
+	Send(ctx context.Context, d Digest) error
+}
+
+// Subject is the one-line summary of the digest
+func Subject(d Digest) string {
+	if len(d.Reviews) == 1 {
+		return fmt.Sprintf("1 pull request is waiting for review by %s", d.Username)
+	}
+	return fmt.Sprintf("%d pull requests are waiting for review by %s", len(d.Reviews), d.Username)
+}
+
+// Text is the plain text body of the digest, oldest PRs first as they come in the digest
+func Text(d Digest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s on %s:\n\n", Subject(d), d.Date)
+	for _, r := range d.Reviews {
+		fmt.Fprintf(&b, "- %s %s by %s, open since %s\n",
+			r.PullRequestId, r.PullRequestName, r.AuthorId, r.CreatedAt.UTC().Format(time.DateOnly))
+	}
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// requestTimeout limits one delivery to a webhook
+const requestTimeout = 10 * time.Second
+
+// Webhook posts the digest as JSON to the URL
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string) *Webhook {
+	return &Webhook{url: url, client: &http.Client{Timeout: requestTimeout}}
+}
+
+func (w *Webhook) Name() string {
+	return "webhook"
+}
+
+func (w *Webhook) Send(ctx context.Context, d Digest) error {
+	return postJSON(ctx, w.client, w.url, d)
+}
+
+// Slack posts the digest to a Slack incoming webhook. Services compatible with its payload, like Mattermost
+// or Rocket.Chat, work too.
+type Slack struct {
+	url    string
+	client *http.Client
+}
+
+func NewSlack(url string) *Slack {
+	return &Slack{url: url, client: &http.Client{Timeout: requestTimeout}}
+}
+
+func (s *Slack) Name() string {
+	return "slack"
+}
+
+// slackMessage is the payload of an incoming webhook, text is in Slack's mrkdwn
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func (s *Slack) Send(ctx context.Context, d Digest) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s*", Subject(d))
+	for _, r := range d.Reviews {
+		fmt.Fprintf(&b, "\n• `%s` %s by %s, open since %s",
+			r.PullRequestId, r.PullRequestName, r.AuthorId, r.CreatedAt.UTC().Format(time.DateOnly))
+	}
+
+	return postJSON(ctx, s.client, s.url, slackMessage{Text: b.String()})
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+// Package roster reads and writes team rosters, the list of teams with their members, in JSON, YAML and CSV.
+// It only deals with formats, comparing a roster with the database is up to the handler.
+package roster
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatCSV  = "csv"
+)
+
+var contentTypes = map[string]string{
+	FormatJSON: "application/json",
+	FormatYAML: "application/yaml",
+	FormatCSV:  "text/csv",
+}
+
+// csvHeader is the header of exported CSV, is_active may be omitted on import
+var csvHeader = []string{"team_name", "user_id", "username", "is_active"}
+
+type Member struct {
+	UserId   string `json:"user_id" yaml:"user_id"`
+	Username string `json:"username" yaml:"username"`
+	// IsActive is true if not set
+	IsActive *bool `json:"is_active,omitempty" yaml:"is_active,omitempty"`
+}
+
+// Active reports whether the member should be active
+func (m Member) Active() bool {
+	return m.IsActive == nil || *m.IsActive
+}
+
+type Team struct {
+	TeamName string   `json:"team_name" yaml:"team_name"`
+	Members  []Member `json:"members" yaml:"members"`
+}
+
+type Roster struct {
+	Teams []Team `json:"teams" yaml:"teams"`
+}
+
+// ContentType returns the media type of the format
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// FormatOf returns the format of the media type, ok is false if it isn't supported
+func FormatOf(contentType string) (format string, ok bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	if mediaType == "application/x-yaml" {
+		return FormatYAML, true
+	}
+
+	for format, ct := range contentTypes {
+		if ct == mediaType {
+			return format, true
+		}
+	}
+
+	return "", false
+}
+
+// Parse reads the roster in the format and checks that it is consistent
+func Parse(format string, data []byte) (*Roster, error) {
+	var (
+		r   *Roster
+		err error
+	)
+
+	switch format {
+	case FormatJSON:
+		r = &Roster{}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(r)
+	case FormatYAML:
+		r = &Roster{}
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(r)
+	case FormatCSV:
+		r, err = parseCSV(data)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", format, err)
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// parseCSV reads rows of team_name, user_id, username and optional is_active. Columns are found by the header,
+// a row with only team_name stands for a team without members.
+func parseCSV(data []byte) (*Roster, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("header is missing")
+		}
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	for _, name := range csvHeader[:3] {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("column %s is missing", name)
+		}
+	}
+
+	r := &Roster{}
+	teams := make(map[string]int)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		teamName := record[columns["team_name"]]
+		t, ok := teams[teamName]
+		if !ok {
+			t = len(r.Teams)
+			teams[teamName] = t
+			r.Teams = append(r.Teams, Team{TeamName: teamName, Members: []Member{}})
+		}
+
+		member := Member{
+			UserId:   record[columns["user_id"]],
+			Username: record[columns["username"]],
+		}
+
+		// A row of a team without members
+		if member.UserId == "" && member.Username == "" {
+			continue
+		}
+
+		if i, ok := columns["is_active"]; ok && record[i] != "" {
+			isActive, err := strconv.ParseBool(record[i])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: is_active must be true or false", line)
+			}
+			member.IsActive = &isActive
+		}
+
+		r.Teams[t].Members = append(r.Teams[t].Members, member)
+	}
+
+	return r, nil
+}
+
+// validate checks that every team and user has an id and is listed once
+func (r *Roster) validate() error {
+	if len(r.Teams) == 0 {
+		return errors.New("teams cannot be empty")
+	}
+
+	teams := make(map[string]bool)
+	users := make(map[string]bool)
+
+	for i, team := range r.Teams {
+		if team.TeamName == "" {
+			return fmt.Errorf("team at index %d has no team_name", i)
+		}
+		if teams[team.TeamName] {
+			return fmt.Errorf("team %s is listed more than once", team.TeamName)
+		}
+		teams[team.TeamName] = true
+
+		for j, m := range team.Members {
+			if m.UserId == "" || m.Username == "" {
+				return fmt.Errorf("member at index %d of team %s is invalid", j, team.TeamName)
+			}
+			if users[m.UserId] {
+				return fmt.Errorf("user %s is listed more than once", m.UserId)
+			}
+			users[m.UserId] = true
+		}
+	}
+
+	return nil
+}
+
+// Encode writes the roster in the format
+func Encode(w io.Writer, format string, r *Roster) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+		return enc.Close()
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(csvHeader)
+		for _, team := range r.Teams {
+			if len(team.Members) == 0 {
+				cw.Write([]string{team.TeamName, "", "", ""})
+			}
+			for _, m := range team.Members {
+				cw.Write([]string{team.TeamName, m.UserId, m.Username, strconv.FormatBool(m.Active())})
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
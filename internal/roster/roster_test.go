@@ -0,0 +1,153 @@
+package roster
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	inactive := false
+
+	for _, tc := range []struct {
+		name   string
+		format string
+		data   string
+		roster *Roster
+		err    string
+	}{
+		{
+			name:   "json",
+			format: FormatJSON,
+			data:   `{"teams":[{"team_name":"backend","members":[{"user_id":"u1","username":"Alice"},{"user_id":"u6","username":"Frank","is_active":false}]}]}`,
+			roster: &Roster{Teams: []Team{{TeamName: "backend", Members: []Member{
+				{UserId: "u1", Username: "Alice"},
+				{UserId: "u6", Username: "Frank", IsActive: &inactive},
+			}}}},
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			data:   "teams:\n  - team_name: backend\n    members:\n      - user_id: u1\n        username: Alice\n  - team_name: mobile\n    members: []\n",
+			roster: &Roster{Teams: []Team{
+				{TeamName: "backend", Members: []Member{{UserId: "u1", Username: "Alice"}}},
+				{TeamName: "mobile", Members: []Member{}},
+			}},
+		},
+		{
+			name:   "csv with columns in any order and without is_active",
+			format: FormatCSV,
+			data:   "username, user_id, team_name\nAlice,u1,backend\nDave,u4,payments\nBob,u2,backend\n",
+			roster: &Roster{Teams: []Team{
+				{TeamName: "backend", Members: []Member{{UserId: "u1", Username: "Alice"}, {UserId: "u2", Username: "Bob"}}},
+				{TeamName: "payments", Members: []Member{{UserId: "u4", Username: "Dave"}}},
+			}},
+		},
+		{
+			name:   "csv with a team without members",
+			format: FormatCSV,
+			data:   "team_name,user_id,username,is_active\nbackend,u6,Frank,false\nmobile,,,\n",
+			roster: &Roster{Teams: []Team{
+				{TeamName: "backend", Members: []Member{{UserId: "u6", Username: "Frank", IsActive: &inactive}}},
+				{TeamName: "mobile", Members: []Member{}},
+			}},
+		},
+		{name: "unknown json field", format: FormatJSON, data: `{"teams":[],"extra":1}`, err: "invalid json"},
+		{name: "unknown yaml field", format: FormatYAML, data: "teams: []\nextra: 1\n", err: "invalid yaml"},
+		{name: "csv without header", format: FormatCSV, data: "", err: "header is missing"},
+		{name: "csv without a column", format: FormatCSV, data: "team_name,user_id\nbackend,u1\n", err: "column username is missing"},
+		{name: "csv with a wrong is_active", format: FormatCSV, data: "team_name,user_id,username,is_active\nbackend,u1,Alice,yes\n", err: "line 2: is_active must be true or false"},
+		{name: "unsupported format", format: "xml", data: "<teams/>", err: `unsupported format "xml"`},
+		{name: "no teams", format: FormatJSON, data: `{"teams":[]}`, err: "teams cannot be empty"},
+		{name: "team without a name", format: FormatJSON, data: `{"teams":[{"team_name":"","members":[]}]}`, err: "team at index 0 has no team_name"},
+		{
+			name:   "team listed twice",
+			format: FormatJSON,
+			data:   `{"teams":[{"team_name":"backend","members":[]},{"team_name":"backend","members":[]}]}`,
+			err:    "team backend is listed more than once",
+		},
+		{
+			name:   "member without a username",
+			format: FormatCSV,
+			data:   "team_name,user_id,username\nbackend,u1,\n",
+			err:    "member at index 0 of team backend is invalid",
+		},
+		{
+			name:   "user listed twice",
+			format: FormatYAML,
+			data:   "teams:\n  - team_name: backend\n    members: [{user_id: u1, username: Alice}]\n  - team_name: mobile\n    members: [{user_id: u1, username: Alice}]\n",
+			err:    "user u1 is listed more than once",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := Parse(tc.format, []byte(tc.data))
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected an error with %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(r, tc.roster) {
+				t.Errorf("expected %+v, got %+v", tc.roster, r)
+			}
+		})
+	}
+}
+
+// TestEncode reads back what it writes, teams without members included
+func TestEncode(t *testing.T) {
+	active, inactive := true, false
+	r := &Roster{Teams: []Team{
+		{TeamName: "backend", Members: []Member{
+			{UserId: "u1", Username: "Alice", IsActive: &active},
+			{UserId: "u6", Username: "Frank, Jr.", IsActive: &inactive},
+		}},
+		{TeamName: "mobile", Members: []Member{}},
+	}}
+
+	for _, format := range []string{FormatJSON, FormatYAML, FormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := Encode(&b, format, r); err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := Parse(format, b.Bytes())
+			if err != nil {
+				t.Fatalf("%v in\n%s", err, b.String())
+			}
+			if !reflect.DeepEqual(decoded, r) {
+				t.Errorf("expected %+v, got %+v from\n%s", r, decoded, b.String())
+			}
+		})
+	}
+
+	var b bytes.Buffer
+	if err := Encode(&b, FormatCSV, r); err != nil {
+		t.Fatal(err)
+	}
+	expected := "team_name,user_id,username,is_active\nbackend,u1,Alice,true\nbackend,u6,\"Frank, Jr.\",false\nmobile,,,\n"
+	if b.String() != expected {
+		t.Errorf("expected CSV\n%s\ngot\n%s", expected, b.String())
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	for contentType, expected := range map[string]string{
+		"application/json":                FormatJSON,
+		"application/json; charset=utf-8": FormatJSON,
+		"application/yaml":                FormatYAML,
+		"application/x-yaml":              FormatYAML,
+		"text/csv":                        FormatCSV,
+		"text/plain":                      "",
+		"":                                "",
+	} {
+		format, ok := FormatOf(contentType)
+		if format != expected || ok != (expected != "") {
+			t.Errorf("%q: expected %q, got %q, %v", contentType, expected, format, ok)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Package scheduler runs periodic background jobs.
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a unit of periodic work. Returned errors are logged and the job is retried on the next tick.
+type Job func(ctx context.Context) error
+
+// Run calls the job every interval until ctx is cancelled. It blocks, so it is usually started in a goroutine.
+func Run(ctx context.Context, name string, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("scheduler: %s failed: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Package validator checks requests and, optionally, responses against the OpenAPI spec the API is generated from.
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+
+	"github.com/vyacheslavbytsko/Pull-Requests-Reviewers-Service/internal/api"
+)
+
+type Options struct {
+	// ValidateResponses makes the middleware check every response too. A response which doesn't match
+	// the spec is replaced with INTERNAL_ERROR, so it's meant for tests and development, not production.
+	ValidateResponses bool
+}
+
+// Middleware validates requests against the spec. Invalid requests are rejected with INVALID_REQUEST
+// before they reach handlers, requests to routes unknown to the spec are passed through as is.
+func Middleware(swagger *openapi3.T, opts Options) (func(http.Handler) http.Handler, error) {
+	// Servers are not needed to match paths, and they can make the router reject valid hosts
+	swagger.Servers = nil
+
+	router, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI router: %w", err)
+	}
+
+	filterOpts := &openapi3filter.Options{
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		IncludeResponseStatus: true,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				// chi knows better what to answer on unknown routes and methods
+				var routeErr *routers.RouteError
+				if errors.As(err, &routeErr) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				writeError(w, api.INTERNALERROR, "failed to find route", http.StatusInternalServerError)
+				return
+			}
+
+			requestInput := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    filterOpts,
+			}
+
+			if err := openapi3filter.ValidateRequest(r.Context(), requestInput); err != nil {
+				writeError(w, api.INVALIDREQUEST, requestErrorMessage(err), http.StatusBadRequest)
+				return
+			}
+
+			// Streams are written as they go, they can't be held back until validated
+			if !opts.ValidateResponses || streams(route.Operation) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &recorder{header: make(http.Header), status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			responseInput := &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: requestInput,
+				Status:                 rec.status,
+				Header:                 rec.header,
+				Options:                filterOpts,
+			}
+			responseInput.SetBodyBytes(rec.body.Bytes())
+
+			if err := openapi3filter.ValidateResponse(r.Context(), responseInput); err != nil {
+				log.Printf("validator: response of %s %s doesn't match the spec: %v", r.Method, r.URL.Path, err)
+				writeError(w, api.INTERNALERROR, "response doesn't match the spec: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			for k, v := range rec.header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(rec.status)
+			w.Write(rec.body.Bytes())
+		})
+	}, nil
+}
+
+// streams tells whether the operation responds with an event stream
+func streams(op *openapi3.Operation) bool {
+	for _, resp := range op.Responses.Map() {
+		if resp.Value != nil && resp.Value.Content.Get("text/event-stream") != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// requestErrorMessage drops the details kin-openapi adds about the schema itself, clients need only the reason
+func requestErrorMessage(err error) string {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return err.Error()
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(reqErr.Err, &schemaErr) {
+		field := schemaErr.JSONPointer()
+		if reqErr.Parameter != nil {
+			return fmt.Sprintf("parameter %s: %s", reqErr.Parameter.Name, schemaErr.Reason)
+		}
+		if len(field) > 0 {
+			return fmt.Sprintf("request body: %s: %s", strings.Join(field, "."), schemaErr.Reason)
+		}
+		return "request body: " + schemaErr.Reason
+	}
+
+	return reqErr.Error()
+}
+
+// recorder keeps the response in memory until it's validated
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+// writeError writes ErrorResponse; handler package does the same, but validator must not depend on it
+func writeError(w http.ResponseWriter, code api.ErrorResponseErrorCode, msg string, status int) {
+	resp := api.ErrorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = msg
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		fmt.Printf("failed to encode json: %v\n", err)
+	}
+}